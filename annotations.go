@@ -18,7 +18,6 @@ package annotations
 
 import (
 	"log/slog"
-	"maps"
 
 	"github.com/rikatz/ingress-nginx-annotations/annotations/alias"
 	"github.com/rikatz/ingress-nginx-annotations/annotations/auth"
@@ -63,54 +62,73 @@ import (
 	"github.com/rikatz/ingress-nginx-annotations/parser"
 )
 
-func NewAnnotationFactory() parser.AnnotationFields {
-	factory := make(parser.AnnotationFields)
-	maps.Copy(factory, alias.AliasAnnotation.Annotations)
-	maps.Copy(factory, auth.AuthSecretAnnotations.Annotations)
-	maps.Copy(factory, authreq.AuthReqAnnotations.Annotations)
-	maps.Copy(factory, authreqglobal.GlobalAuthAnnotations.Annotations)
-	maps.Copy(factory, authtls.AuthTLSAnnotations.Annotations)
-	maps.Copy(factory, backendprotocol.BackendProtocolConfig.Annotations)
-	maps.Copy(factory, canary.CanaryAnnotations.Annotations)
-	maps.Copy(factory, clientbodybuffersize.ClientBodyBufferSizeConfig.Annotations)
-	maps.Copy(factory, connection.ConnectionHeadersAnnotations.Annotations)
-	maps.Copy(factory, cors.CORSAnnotation.Annotations)
-	maps.Copy(factory, customheaders.CustomHeadersAnnotation.Annotations)
-	maps.Copy(factory, customhttperrors.CustomHTTPErrorsAnnotations.Annotations)
-	maps.Copy(factory, defaultbackend.DefaultBackendAnnotations.Annotations)
-	maps.Copy(factory, disableproxyintercepterrors.DisableProxyInterceptErrorsAnnotations.Annotations)
-	maps.Copy(factory, fastcgi.FastCGIAnnotations.Annotations)
-	maps.Copy(factory, http2pushpreload.HTTP2PushPreloadAnnotations.Annotations)
-	maps.Copy(factory, ipallowlist.AllowlistAnnotations.Annotations)
-	maps.Copy(factory, ipdenylist.DenylistAnnotations.Annotations)
-	maps.Copy(factory, loadbalancing.LoadBalanceAnnotations.Annotations)
-	maps.Copy(factory, log.LogAnnotations.Annotations)
-	maps.Copy(factory, mirror.MirrorAnnotation.Annotations)
-	maps.Copy(factory, modsecurity.ModsecurityAnnotation.Annotations)
-	maps.Copy(factory, opentelemetry.OtelAnnotations.Annotations)
-	maps.Copy(factory, portinredirect.PortsInRedirectAnnotations.Annotations)
-	maps.Copy(factory, proxy.ProxyAnnotations.Annotations)
-	maps.Copy(factory, proxyssl.ProxySSLAnnotation.Annotations)
-	maps.Copy(factory, ratelimit.RateLimitAnnotations.Annotations)
-	maps.Copy(factory, redirect.RedirectAnnotations.Annotations)
-	maps.Copy(factory, rewrite.RewriteAnnotations.Annotations)
-	maps.Copy(factory, satisfy.SatisfyAnnotations.Annotations)
-	maps.Copy(factory, serversnippet.ServerSnippetAnnotations.Annotations)
-	maps.Copy(factory, serviceupstream.ServiceUpstreamAnnotations.Annotations)
-	maps.Copy(factory, sessionaffinity.SessionAffinityAnnotations.Annotations)
-	maps.Copy(factory, snippet.ConfigurationSnippetAnnotations.Annotations)
-	maps.Copy(factory, sslcipher.SSLCipherAnnotations.Annotations)
-	maps.Copy(factory, sslpassthrough.SSLPassthroughAnnotations.Annotations)
-	maps.Copy(factory, streamsnippet.StreamSnippetAnnotations.Annotations)
-	maps.Copy(factory, upstreamhashby.UpstreamHashByAnnotations.Annotations)
-	maps.Copy(factory, upstreamvhost.UpstreamVhostAnnotations.Annotations)
-	maps.Copy(factory, xforwardedprefix.XForwardedForAnnotations.Annotations)
+// features contains all the annotation features known by this library, and
+// the name each one is registered with
+var features = []struct {
+	name       string
+	annotation parser.Annotation
+}{
+	{"alias", alias.AliasAnnotation},
+	{"auth", auth.AuthSecretAnnotations},
+	{"authreq", authreq.AuthReqAnnotations},
+	{"authreqglobal", authreqglobal.GlobalAuthAnnotations},
+	{"authtls", authtls.AuthTLSAnnotations},
+	{"backendprotocol", backendprotocol.BackendProtocolConfig},
+	{"canary", canary.CanaryAnnotations},
+	{"clientbodybuffersize", clientbodybuffersize.ClientBodyBufferSizeConfig},
+	{"connection", connection.ConnectionHeadersAnnotations},
+	{"cors", cors.CORSAnnotation},
+	{"customheaders", customheaders.CustomHeadersAnnotation},
+	{"customhttperrors", customhttperrors.CustomHTTPErrorsAnnotations},
+	{"defaultbackend", defaultbackend.DefaultBackendAnnotations},
+	{"disableproxyintercepterrors", disableproxyintercepterrors.DisableProxyInterceptErrorsAnnotations},
+	{"fastcgi", fastcgi.FastCGIAnnotations},
+	{"http2pushpreload", http2pushpreload.HTTP2PushPreloadAnnotations},
+	{"ipallowlist", ipallowlist.AllowlistAnnotations},
+	{"ipdenylist", ipdenylist.DenylistAnnotations},
+	{"loadbalancing", loadbalancing.LoadBalanceAnnotations},
+	{"log", log.LogAnnotations},
+	{"mirror", mirror.MirrorAnnotation},
+	{"modsecurity", modsecurity.ModsecurityAnnotation},
+	{"opentelemetry", opentelemetry.OtelAnnotations},
+	{"portinredirect", portinredirect.PortsInRedirectAnnotations},
+	{"proxy", proxy.ProxyAnnotations},
+	{"proxyssl", proxyssl.ProxySSLAnnotation},
+	{"ratelimit", ratelimit.RateLimitAnnotations},
+	{"redirect", redirect.RedirectAnnotations},
+	{"rewrite", rewrite.RewriteAnnotations},
+	{"satisfy", satisfy.SatisfyAnnotations},
+	{"serversnippet", serversnippet.ServerSnippetAnnotations},
+	{"serviceupstream", serviceupstream.ServiceUpstreamAnnotations},
+	{"sessionaffinity", sessionaffinity.SessionAffinityAnnotations},
+	{"snippet", snippet.ConfigurationSnippetAnnotations},
+	{"sslcipher", sslcipher.SSLCipherAnnotations},
+	{"sslpassthrough", sslpassthrough.SSLPassthroughAnnotations},
+	{"streamsnippet", streamsnippet.StreamSnippetAnnotations},
+	{"upstreamhashby", upstreamhashby.UpstreamHashByAnnotations},
+	{"upstreamvhost", upstreamvhost.UpstreamVhostAnnotations},
+	{"xforwardedprefix", xforwardedprefix.XForwardedForAnnotations},
+}
 
-	for _, val := range factory {
-		for _, alias := range val.AnnotationAliases {
-			factory[alias] = val
+// NewRegistry returns a Registry containing all the annotation features
+func NewRegistry() *parser.Registry {
+	registry := parser.NewRegistry()
+	for _, feature := range features {
+		if err := registry.Register(feature.name, feature.annotation); err != nil {
+			// This is a bug on the declared annotations, and should be caught by tests
+			panic(err)
 		}
 	}
+	if err := registry.ValidateRules(); err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// NewAnnotationFactory returns all the annotations and its aliases as a flat
+// AnnotationFields. Use NewRegistry to keep the feature and group of each annotation
+func NewAnnotationFactory() parser.AnnotationFields {
+	factory := NewRegistry().Fields()
 
 	slog.Info("loaded annotations", "amount", len(factory))
 
@@ -29,12 +29,14 @@ var AliasAnnotation = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		serverAliasAnnotation: {
 			Validator: parser.ValidateArrayOfServerName,
+			Format:    parser.FormatArrayOfServerName,
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskHigh, // High as this allows regex chars
 			Documentation: `this annotation can be used to define additional server 
 			aliases for this Ingress`,
-			GatewayAPI:    `Supported by the additionals ".spec.hostnames" field`,
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutespec",
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              `Partially supported by the additionals ".spec.hostnames" field. Regular expression aliases starting with '~' are not supported`,
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutespec",
 		},
 	},
 }
@@ -40,34 +40,49 @@ var (
 )
 
 var AuthSecretConfig = parser.AnnotationConfig{
-	Validator:     parser.ValidateRegex(parser.BasicCharsRegex, true),
-	Scope:         parser.AnnotationScopeLocation,
-	Risk:          parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
-	Documentation: `This annotation defines the name of the Secret that contains the usernames and passwords which are granted access to the paths defined in the Ingress rules. `,
+	Validator:               parser.ValidateRegex(parser.BasicCharsRegex, true),
+	Format:                  parser.RegexFormat(parser.BasicCharsRegex, true),
+	Scope:                   parser.AnnotationScopeLocation,
+	Risk:                    parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+	Documentation:           `This annotation defines the name of the Secret that contains the usernames and passwords which are granted access to the paths defined in the Ingress rules. `,
+	GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 }
 
 var AuthSecretAnnotations = parser.Annotation{
 	Group: "authentication",
+	Rules: []parser.Rule{
+		// The controller denies the location if the auth-type is set without a secret
+		parser.Requires(authTypeAnnotation, AuthSecretAnnotation).WithSeverity(parser.SeverityError),
+		parser.Requires(AuthSecretAnnotation, authTypeAnnotation),
+		parser.Requires(authSecretTypeAnnotation, AuthSecretAnnotation),
+		parser.Requires(authRealmAnnotation, authTypeAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		AuthSecretAnnotation: AuthSecretConfig,
 		authSecretTypeAnnotation: {
 			Validator: parser.ValidateRegex(authSecretTypeRegex, true),
+			Format:    parser.RegexFormat(authSecretTypeRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation what is the format of auth-secret value. Can be "auth-file" that defines the content of an htpasswd file, or "auth-map" where each key
 			is a user and each value is the password.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authRealmAnnotation: {
-			Validator:     parser.ValidateRegex(parser.CharsWithSpace, false),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
-			Documentation: `This annotation defines the realm (message) that should be shown to user when authentication is requested.`,
+			Validator:               parser.ValidateRegex(parser.CharsWithSpace, false),
+			Format:                  parser.RegexFormat(parser.CharsWithSpace, false),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+			Documentation:           `This annotation defines the realm (message) that should be shown to user when authentication is requested.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authTypeAnnotation: {
-			Validator:     parser.ValidateRegex(authTypeRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines the basic authentication type. Should be "basic" or "digest"`,
+			Validator:               parser.ValidateRegex(authTypeRegex, true),
+			Format:                  parser.RegexFormat(authTypeRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines the basic authentication type. Should be "basic" or "digest"`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/sha1" //#nosec G505
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AuthFileKey is the key of the Secret containing the htpasswd file, when auth-secret-type is auth-file
+	AuthFileKey = "auth"
+
+	plainHashPrefix = "{PLAIN}"
+	shaHashPrefix   = "{SHA}"
+)
+
+var (
+	// desCryptRegex matches the traditional DES crypt hashes
+	desCryptRegex = regexp.MustCompile(`^[./0-9A-Za-z]{13}$`)
+	// digestHashRegex matches the HA1 hash of the htdigest files
+	digestHashRegex = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+)
+
+// Type is the authentication scheme
+type Type string
+
+const (
+	TypeBasic  Type = "basic"
+	TypeDigest Type = "digest"
+)
+
+// SecretType is the format of the auth-secret
+type SecretType string
+
+const (
+	// SecretTypeAuthFile is a Secret with the auth key, containing an htpasswd file
+	SecretTypeAuthFile SecretType = "auth-file"
+	// SecretTypeAuthMap is a Secret where each key is a user, and its value the password hash
+	SecretTypeAuthMap SecretType = "auth-map"
+)
+
+// HashFormat is the format of the password of a user
+type HashFormat string
+
+const (
+	HashPlain HashFormat = "plain"
+	// HashSHA is the base64 SHA-1 of the password, prefixed with {SHA}
+	HashSHA HashFormat = "sha"
+	// HashSSHA is the base64 salted SHA-1 of the password, prefixed with {SSHA}
+	HashSSHA HashFormat = "ssha"
+	// HashAPR1 is the Apache MD5 hash
+	HashAPR1        HashFormat = "apr1"
+	HashMD5Crypt    HashFormat = "md5-crypt"
+	HashBcrypt      HashFormat = "bcrypt"
+	HashSHA256Crypt HashFormat = "sha256-crypt"
+	HashSHA512Crypt HashFormat = "sha512-crypt"
+	HashDESCrypt    HashFormat = "des-crypt"
+	// HashDigest is the MD5 of user:realm:password of the htdigest files
+	HashDigest  HashFormat = "digest-ha1"
+	HashUnknown HashFormat = "unknown"
+)
+
+// User is an entry of the credentials of the Secret
+type User struct {
+	Name string `json:"name"`
+	// Realm is only set on digest credentials
+	Realm  string     `json:"realm,omitempty"`
+	Hash   string     `json:"-"`
+	Format HashFormat `json:"format"`
+}
+
+// SHA returns the password hash of the user in the {SHA} format. Only {SHA} and
+// {PLAIN} passwords can be converted
+func (u User) SHA() (string, bool) {
+	switch u.Format {
+	case HashSHA:
+		return u.Hash, true
+	case HashPlain:
+		sum := sha1.Sum([]byte(strings.TrimPrefix(u.Hash, plainHashPrefix))) //#nosec G401
+		return shaHashPrefix + base64.StdEncoding.EncodeToString(sum[:]), true
+	}
+	return "", false
+}
+
+// Config is the typed configuration of the basic and digest authentication annotations
+type Config struct {
+	Type Type
+	// Secret contains the credentials, and is always in the namespace of the Ingress
+	Secret     types.NamespacedName
+	SecretType SecretType
+	Realm      string
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the authentication configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		SecretType: SecretTypeAuthFile,
+		set:        make(map[string]bool),
+	}
+}
+
+// Enabled returns if the requests must be authenticated with the users of the Secret
+func (c *Config) Enabled() bool {
+	return c.Type != "" && c.Secret.Name != ""
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Parse returns the authentication configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the authentication configuration of the Ingress. The secret
+// can be in the form namespace/name or name, and must be on the namespace of the
+// Ingress. Annotations that are not set, or are invalid, keep the ingress-nginx
+// defaults. The invalid annotations are returned together as the error, so the
+// configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, AuthSecretAnnotations.Annotations, config)
+
+	if value, ok := r.Value(authTypeAnnotation); ok {
+		switch authType := Type(value); authType {
+		case TypeBasic, TypeDigest:
+			c.Type = authType
+		default:
+			r.Invalid(authTypeAnnotation, value, fmt.Errorf("authentication type must be basic or digest"))
+		}
+	}
+	if value, ok := r.Value(AuthSecretAnnotation); ok {
+		secret, err := parser.ParseSecretReference(value, ing.Namespace, false)
+		if err != nil {
+			r.Invalid(AuthSecretAnnotation, value, err)
+		} else {
+			c.Secret = secret
+		}
+	}
+	if value, ok := r.Value(authSecretTypeAnnotation); ok {
+		switch secretType := SecretType(value); secretType {
+		case SecretTypeAuthFile, SecretTypeAuthMap:
+			c.SecretType = secretType
+		default:
+			r.Invalid(authSecretTypeAnnotation, value, fmt.Errorf("secret type must be auth-file or auth-map"))
+		}
+	}
+	c.Realm = r.String(authRealmAnnotation, c.Realm)
+
+	c.set = r.Set()
+	return c, r.Err()
+}
+
+// ParseCredentials returns the users of the Secret, sorted by name, in the format of
+// the configuration. Lines and keys that cannot be parsed are returned together as the
+// error, and the valid users are always returned
+func (c *Config) ParseCredentials(secret *corev1.Secret) ([]User, error) {
+	if c.SecretType == SecretTypeAuthMap {
+		if len(secret.Data) == 0 {
+			return nil, fmt.Errorf("secret %s/%s does not contain any user", secret.Namespace, secret.Name)
+		}
+		users := make([]User, 0, len(secret.Data))
+		errs := make([]error, 0)
+		for _, name := range slices.Sorted(maps.Keys(secret.Data)) {
+			user, err := c.parseUser(name + ":" + string(secret.Data[name]))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("key %s: %w", name, err))
+				continue
+			}
+			users = append(users, user)
+		}
+		return users, errors.Join(errs...)
+	}
+
+	content, ok := secret.Data[AuthFileKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s does not contain the key %s", secret.Namespace, secret.Name, AuthFileKey)
+	}
+	users := make([]User, 0)
+	errs := make([]error, 0)
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, err := c.parseUser(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+			continue
+		}
+		users = append(users, user)
+	}
+	slices.SortStableFunc(users, func(a, b User) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return users, errors.Join(errs...)
+}
+
+// parseUser parses an htpasswd line, user:hash, or an htdigest line, user:realm:hash
+func (c *Config) parseUser(line string) (User, error) {
+	name, hash, found := strings.Cut(line, ":")
+	if !found || name == "" {
+		return User{}, fmt.Errorf("entry is not in the format user:password")
+	}
+	if c.Type == TypeDigest {
+		realm, ha1, found := strings.Cut(hash, ":")
+		if !found || !digestHashRegex.MatchString(ha1) {
+			return User{}, fmt.Errorf("entry of user %s is not in the format user:realm:hash", name)
+		}
+		return User{Name: name, Realm: realm, Hash: ha1, Format: HashDigest}, nil
+	}
+	return User{Name: name, Hash: hash, Format: ParseHashFormat(hash)}, nil
+}
+
+// ParseHashFormat returns the format of an htpasswd password. nginx passwords without
+// a known prefix are evaluated with crypt, and passwords that are not a known crypt
+// hash are reported as unknown
+func ParseHashFormat(hash string) HashFormat {
+	switch {
+	case strings.HasPrefix(hash, plainHashPrefix):
+		return HashPlain
+	case strings.HasPrefix(hash, shaHashPrefix):
+		return HashSHA
+	case strings.HasPrefix(hash, "{SSHA}"):
+		return HashSSHA
+	case strings.HasPrefix(hash, "$apr1$"):
+		return HashAPR1
+	case strings.HasPrefix(hash, "$1$"):
+		return HashMD5Crypt
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return HashBcrypt
+	case strings.HasPrefix(hash, "$5$"):
+		return HashSHA256Crypt
+	case strings.HasPrefix(hash, "$6$"):
+		return HashSHA512Crypt
+	case desCryptRegex.MatchString(hash):
+		return HashDESCrypt
+	}
+	return HashUnknown
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "defaults",
+			annotations: map[string]string{},
+			expected:    &Config{SecretType: SecretTypeAuthFile},
+		},
+		{
+			name: "basic auth map",
+			annotations: map[string]string{
+				authTypeAnnotation:       "basic",
+				AuthSecretAnnotation:     "users",
+				authSecretTypeAnnotation: "auth-map",
+				authRealmAnnotation:      "Authentication Required",
+			},
+			expected: &Config{
+				Type:       TypeBasic,
+				Secret:     types.NamespacedName{Namespace: "default", Name: "users"},
+				SecretType: SecretTypeAuthMap,
+				Realm:      "Authentication Required",
+			},
+		},
+		{
+			name: "digest",
+			annotations: map[string]string{
+				authTypeAnnotation:   "digest",
+				AuthSecretAnnotation: "default/users",
+			},
+			expected: &Config{
+				Type:       TypeDigest,
+				Secret:     types.NamespacedName{Namespace: "default", Name: "users"},
+				SecretType: SecretTypeAuthFile,
+			},
+		},
+		{
+			name: "cross namespace secret",
+			annotations: map[string]string{
+				authTypeAnnotation:   "basic",
+				AuthSecretAnnotation: "other/users",
+			},
+			expected: &Config{Type: TypeBasic, SecretType: SecretTypeAuthFile},
+			wantErr:  true,
+		},
+		{
+			name: "unanchored auth type",
+			annotations: map[string]string{
+				authTypeAnnotation:   "basicx",
+				AuthSecretAnnotation: "users",
+			},
+			expected: &Config{
+				Secret:     types.NamespacedName{Namespace: "default", Name: "users"},
+				SecretType: SecretTypeAuthFile,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestParseCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		data     map[string][]byte
+		expected []User
+		wantErr  bool
+	}{
+		{
+			name:   "htpasswd file",
+			config: &Config{Type: TypeBasic, SecretType: SecretTypeAuthFile},
+			data: map[string][]byte{AuthFileKey: []byte(`# users
+zoe:{SHA}0DPiKuNIrrVmD8IUCuw1hQxNqZc=
+bob:$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/
+
+carol:$2y$05$c4WoMPo3SXsafkva.HHa6uXQZWr7oboPiC2bT/r7q1BB8I2s0BRqC
+dave:{PLAIN}secret
+erin:rl4Q8WGAfhCxo
+frank
+`)},
+			expected: []User{
+				{Name: "bob", Hash: "$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/", Format: HashAPR1},
+				{Name: "carol", Hash: "$2y$05$c4WoMPo3SXsafkva.HHa6uXQZWr7oboPiC2bT/r7q1BB8I2s0BRqC", Format: HashBcrypt},
+				{Name: "dave", Hash: "{PLAIN}secret", Format: HashPlain},
+				{Name: "erin", Hash: "rl4Q8WGAfhCxo", Format: HashDESCrypt},
+				{Name: "zoe", Hash: "{SHA}0DPiKuNIrrVmD8IUCuw1hQxNqZc=", Format: HashSHA},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing auth key",
+			config:  &Config{Type: TypeBasic, SecretType: SecretTypeAuthFile},
+			data:    map[string][]byte{"users": []byte("bob:{PLAIN}secret")},
+			wantErr: true,
+		},
+		{
+			name:   "auth map",
+			config: &Config{Type: TypeBasic, SecretType: SecretTypeAuthMap},
+			data: map[string][]byte{
+				"bob":   []byte("$6$salt$hash"),
+				"alice": []byte("{SSHA}hash"),
+			},
+			expected: []User{
+				{Name: "alice", Hash: "{SSHA}hash", Format: HashSSHA},
+				{Name: "bob", Hash: "$6$salt$hash", Format: HashSHA512Crypt},
+			},
+		},
+		{
+			name:   "htdigest file",
+			config: &Config{Type: TypeDigest, SecretType: SecretTypeAuthFile},
+			data:   map[string][]byte{AuthFileKey: []byte("bob:example:939e7578ed9e3c518a452acee763bce9\nalice:nohash\n")},
+			expected: []User{
+				{Name: "bob", Realm: "example", Hash: "939e7578ed9e3c518a452acee763bce9", Format: HashDigest},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := tt.config.ParseCredentials(&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{Name: "users", Namespace: "default"},
+				Data:       tt.data,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if len(users) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(users, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, users)
+			}
+		})
+	}
+}
+
+func TestUserSHA(t *testing.T) {
+	tests := []struct {
+		user     User
+		expected string
+		ok       bool
+	}{
+		{user: User{Hash: "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=", Format: HashSHA}, expected: "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=", ok: true},
+		{user: User{Hash: "{PLAIN}secret", Format: HashPlain}, expected: "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=", ok: true},
+		{user: User{Hash: "$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/", Format: HashAPR1}},
+	}
+	for _, tt := range tests {
+		hash, ok := tt.user.SHA()
+		if hash != tt.expected || ok != tt.ok {
+			t.Errorf("expected %q %t for %s, got %q %t", tt.expected, tt.ok, tt.user.Hash, hash, ok)
+		}
+	}
+}
@@ -49,98 +49,151 @@ var (
 
 var AuthReqAnnotations = parser.Annotation{
 	Group: "authentication",
+	Rules: []parser.Rule{
+		// All the external authentication annotations are ignored without auth-url
+		parser.Requires(authReqMethodAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqSigninAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqSigninRedirParamAnnotation, authReqSigninAnnotation),
+		parser.Requires(authReqSnippetAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqCacheKeyAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqCacheDuration, authReqCacheKeyAnnotation),
+		parser.Requires(authReqKeepaliveAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqKeepaliveShareVarsAnnotation, authReqKeepaliveAnnotation),
+		parser.Requires(authReqKeepaliveRequestsAnnotation, authReqKeepaliveAnnotation),
+		parser.Requires(authReqKeepaliveTimeout, authReqKeepaliveAnnotation),
+		parser.Requires(authReqResponseHeadersAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqProxySetHeadersAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqRequestRedirectAnnotation, authReqURLAnnotation),
+		parser.Requires(authReqAlwaysSetCookieAnnotation, authReqURLAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		authReqURLAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLWithNginxVariableRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation allows to indicate the URL where the HTTP request should be sent`,
+			Validator:               parser.ValidateRegex(parser.URLWithNginxVariableRegex, true),
+			Format:                  parser.RegexFormat(parser.URLWithNginxVariableRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation allows to indicate the URL where the HTTP request should be sent`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].externalAuth'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpexternalauthfilter",
 		},
 		authReqMethodAnnotation: {
-			Validator:     parser.ValidateRegex(methodsRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation allows to specify the HTTP method to use`,
+			Validator:               parser.ValidateRegex(methodsRegex, true),
+			Format:                  parser.RegexFormat(methodsRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation allows to specify the HTTP method to use`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqSigninAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLWithNginxVariableRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation allows to specify the location of the error page`,
+			Validator:               parser.ValidateRegex(parser.URLWithNginxVariableRegex, true),
+			Format:                  parser.RegexFormat(parser.URLWithNginxVariableRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation allows to specify the location of the error page`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqSigninRedirParamAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLIsValidRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation allows to specify the URL parameter in the error page which should contain the original URL for a failed signin request`,
+			Validator:               parser.ValidateRegex(parser.URLIsValidRegex, true),
+			Format:                  parser.RegexFormat(parser.URLIsValidRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation allows to specify the URL parameter in the error page which should contain the original URL for a failed signin request`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqSnippetAnnotation: {
-			Validator:     parser.ValidateNull,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskCritical,
-			Documentation: `This annotation allows to specify a custom snippet to use with external authentication`,
+			Validator:               parser.ValidateNull,
+			Format:                  parser.FormatNull,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskCritical,
+			Documentation:           `This annotation allows to specify a custom snippet to use with external authentication`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqCacheKeyAnnotation: {
-			Validator:     parser.ValidateRegex(parser.NGINXVariable, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation enables caching for auth requests.`,
+			Validator:               parser.ValidateRegex(parser.NGINXVariable, true),
+			Format:                  parser.RegexFormat(parser.NGINXVariable, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation enables caching for auth requests.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqKeepaliveAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation specifies the maximum number of keepalive connections to auth-url. Only takes effect when no variables are used in the host part of the URL`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation specifies the maximum number of keepalive connections to auth-url. Only takes effect when no variables are used in the host part of the URL`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqKeepaliveShareVarsAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation specifies whether to share Nginx variables among the current request and the auth request`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation specifies whether to share Nginx variables among the current request and the auth request`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqKeepaliveRequestsAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines the maximum number of requests that can be served through one keepalive connection`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines the maximum number of requests that can be served through one keepalive connection`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqKeepaliveTimeout: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation specifies a duration in seconds which an idle keepalive connection to an upstream server will stay open`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation specifies a duration in seconds which an idle keepalive connection to an upstream server will stay open`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqCacheDuration: {
-			Validator:     parser.ValidateRegex(parser.ExtendedCharsRegex, false),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation allows to specify a caching time for auth responses based on their response codes, e.g. 200 202 30m`,
+			Validator:               parser.ValidateRegex(parser.ExtendedCharsRegex, false),
+			Format:                  parser.RegexFormat(parser.ExtendedCharsRegex, false),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation allows to specify a caching time for auth responses based on their response codes, e.g. 200 202 30m`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqResponseHeadersAnnotation: {
-			Validator:     parser.ValidateRegex(parser.HeadersVariable, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation sets the headers to pass to backend once authentication request completes. They should be separated by comma.`,
+			Validator:               parser.ValidateRegex(parser.HeadersVariable, true),
+			Format:                  parser.RegexFormat(parser.HeadersVariable, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation sets the headers to pass to backend once authentication request completes. They should be separated by comma.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].externalAuth.http.allowedResponseHeaders'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpexternalauthfilter",
 		},
 		authReqProxySetHeadersAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation sets the name of a ConfigMap that specifies headers to pass to the authentication service.
 			Only ConfigMaps on the same namespace are allowed`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestHeaderModifier', that also sends the headers to the backends",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		authReqRequestRedirectAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLIsValidRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation allows to specify the X-Auth-Request-Redirect header value`,
+			Validator:               parser.ValidateRegex(parser.URLIsValidRegex, true),
+			Format:                  parser.RegexFormat(parser.URLIsValidRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation allows to specify the X-Auth-Request-Redirect header value`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		authReqAlwaysSetCookieAnnotation: {
 			Validator: parser.ValidateBool,
+			Format:    parser.FormatBool,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation enables setting a cookie returned by auth request. 
 			By default, the cookie will be set only if an upstream reports with the code 200, 201, 204, 206, 301, 302, 303, 304, 307, or 308`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authreq
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultKeepaliveRequests = 1000
+	defaultKeepaliveTimeout  = 60 * time.Second
+)
+
+var (
+	// headerNameRegex matches the names of the headers of auth-response-headers
+	headerNameRegex = regexp.MustCompile(`^[a-zA-Z\d\-_]+$`)
+	// statusCodeRegex matches a status code of auth-cache-duration
+	statusCodeRegex = regexp.MustCompile(`^\d{3}$`)
+	// cacheDurationRegex matches a duration of auth-cache-duration, in the nginx time units
+	cacheDurationRegex = regexp.MustCompile(`^(\d+)(ms|s|m|h|d|w|M|y)$`)
+
+	// nginxTimeUnits are the units of the nginx durations
+	nginxTimeUnits = map[string]time.Duration{
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"M":  30 * 24 * time.Hour,
+		"y":  365 * 24 * time.Hour,
+	}
+)
+
+// Endpoint is the parsed auth-url
+type Endpoint struct {
+	Scheme string
+	// Host is the host of the URL, without the port. It may contain nginx variables
+	Host string
+	// Port is 0 when the URL does not contain a port
+	Port     int
+	Path     string
+	RawQuery string
+	// HostVariables is true when the host or port contain nginx variables, like
+	// $host, and the authentication service is only known for each request
+	HostVariables bool
+}
+
+// DefaultPort returns the port of the endpoint, or the default port of the scheme
+func (e Endpoint) DefaultPort() int {
+	if e.Port != 0 {
+		return e.Port
+	}
+	if e.Scheme == "https" {
+		return 443
+	}
+	return 80
+}
+
+// CacheDuration is how long the authentication responses with the status codes are cached
+type CacheDuration struct {
+	// StatusCodes are the cached status codes. nginx caches 200, 301 and 302 when empty
+	StatusCodes []int         `json:"statusCodes,omitempty"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// Keepalive configures the connections kept open to the authentication service
+type Keepalive struct {
+	// Connections is the number of idle connections kept open. Zero disables keepalive
+	Connections int
+	// ShareVars shares the nginx variables of the request with the authentication request
+	ShareVars bool
+	Requests  int
+	Timeout   time.Duration
+}
+
+// Config is the typed configuration of the external authentication annotations
+type Config struct {
+	// URL is the authentication service, empty when external authentication is disabled
+	URL      string
+	Endpoint *Endpoint
+	// Method is the method of the authentication requests. When empty, the method of
+	// the request is used
+	Method string
+	// SignIn is the location unauthenticated requests are redirected to
+	SignIn              string
+	SignInRedirectParam string
+	Snippet             string
+	// ResponseHeaders are the headers of the authentication response sent to the backend
+	ResponseHeaders []string
+	// ProxySetHeaders is the ConfigMap with the headers sent to the authentication service
+	ProxySetHeaders types.NamespacedName
+	// RequestRedirect is the value of the X-Auth-Request-Redirect header
+	RequestRedirect string
+	CacheKey        string
+	// CacheDurations are only used when CacheKey is set
+	CacheDurations  []CacheDuration
+	Keepalive       Keepalive
+	AlwaysSetCookie bool
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the external authentication configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		CacheDurations: []CacheDuration{{StatusCodes: []int{200, 202, 401}, Duration: 5 * time.Minute}},
+		Keepalive: Keepalive{
+			Requests: defaultKeepaliveRequests,
+			Timeout:  defaultKeepaliveTimeout,
+		},
+		set: make(map[string]bool),
+	}
+}
+
+// Enabled returns if the requests are authenticated by the service of auth-url
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Parse returns the external authentication configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the external authentication configuration of the Ingress,
+// computed as ingress-nginx does: keepalive is disabled when the host of auth-url
+// contains nginx variables, or when the keepalive requests or timeout are not
+// positive, and an invalid auth-cache-duration uses the default 200 202 401 5m.
+// Annotations that are not set, or are invalid, keep the ingress-nginx defaults. The
+// invalid annotations are returned together as the error, so the configuration is
+// always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, AuthReqAnnotations.Annotations, config)
+
+	if value, ok := r.Value(authReqURLAnnotation); ok {
+		endpoint, err := parseEndpoint(value)
+		if err != nil {
+			r.Invalid(authReqURLAnnotation, value, err)
+		} else {
+			c.URL, c.Endpoint = value, endpoint
+		}
+	}
+	c.Method = r.String(authReqMethodAnnotation, c.Method)
+	c.SignIn = r.String(authReqSigninAnnotation, c.SignIn)
+	c.SignInRedirectParam = r.String(authReqSigninRedirParamAnnotation, c.SignInRedirectParam)
+	c.Snippet = r.String(authReqSnippetAnnotation, c.Snippet)
+	c.RequestRedirect = r.String(authReqRequestRedirectAnnotation, c.RequestRedirect)
+	c.AlwaysSetCookie = r.Bool(authReqAlwaysSetCookieAnnotation, c.AlwaysSetCookie)
+	c.CacheKey = r.String(authReqCacheKeyAnnotation, c.CacheKey)
+
+	if value, ok := r.Value(authReqResponseHeadersAnnotation); ok {
+		headers, err := parseResponseHeaders(value)
+		if err != nil {
+			r.Invalid(authReqResponseHeadersAnnotation, value, err)
+		} else {
+			c.ResponseHeaders = headers
+		}
+	}
+	if value, ok := r.Value(authReqProxySetHeadersAnnotation); ok {
+		ref, err := parser.ParseObjectReference("configmap", value, ing.Namespace, false)
+		if err != nil {
+			r.Invalid(authReqProxySetHeadersAnnotation, value, err)
+		} else {
+			c.ProxySetHeaders = ref
+		}
+	}
+	if value, ok := r.Value(authReqCacheDuration); ok {
+		durations, err := ParseCacheDurations(value)
+		if err != nil {
+			r.Invalid(authReqCacheDuration, value, err)
+		} else {
+			c.CacheDurations = durations
+		}
+	}
+
+	c.Keepalive.Connections = r.Int(authReqKeepaliveAnnotation, c.Keepalive.Connections)
+	if c.Keepalive.Connections < 0 {
+		r.Invalid(authReqKeepaliveAnnotation, strconv.Itoa(c.Keepalive.Connections), fmt.Errorf("keepalive connections cannot be negative"))
+		c.Keepalive.Connections = 0
+	}
+	c.Keepalive.ShareVars = r.Bool(authReqKeepaliveShareVarsAnnotation, c.Keepalive.ShareVars)
+	c.Keepalive.Requests = r.Int(authReqKeepaliveRequestsAnnotation, c.Keepalive.Requests)
+	c.Keepalive.Timeout = r.Seconds(authReqKeepaliveTimeout, c.Keepalive.Timeout)
+	if (c.Endpoint != nil && c.Endpoint.HostVariables) || c.Keepalive.Requests <= 0 || c.Keepalive.Timeout <= 0 {
+		c.Keepalive.Connections = 0
+	}
+
+	c.set = r.Set()
+	return c, r.Err()
+}
+
+// parseEndpoint parses auth-url, that must be an absolute URL
+func parseEndpoint(value string) (*Endpoint, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("url scheme is empty")
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("url host is empty")
+	}
+	if strings.Contains(u.Host, "..") {
+		return nil, fmt.Errorf("invalid url host")
+	}
+	endpoint := &Endpoint{
+		Scheme:        u.Scheme,
+		Host:          u.Hostname(),
+		Path:          u.Path,
+		RawQuery:      u.RawQuery,
+		HostVariables: strings.Contains(u.Host, "$"),
+	}
+	if port := u.Port(); port != "" && !strings.Contains(port, "$") {
+		endpoint.Port, err = strconv.Atoi(port)
+		if err != nil || endpoint.Port > 65535 {
+			return nil, fmt.Errorf("url %s contains an invalid port", value)
+		}
+	}
+	return endpoint, nil
+}
+
+// parseResponseHeaders splits the comma separated headers of auth-response-headers
+func parseResponseHeaders(value string) ([]string, error) {
+	headers := make([]string, 0)
+	for _, header := range strings.Split(value, ",") {
+		header = strings.TrimSpace(header)
+		if !headerNameRegex.MatchString(header) {
+			return nil, fmt.Errorf("header %q is not a valid header name", header)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// ParseCacheDurations parses the comma separated entries of auth-cache-duration. Each
+// entry contains optional status codes followed by a duration, like 200 202 10m, in
+// the format of the nginx proxy_cache_valid directive
+func ParseCacheDurations(value string) ([]CacheDuration, error) {
+	durations := make([]CacheDuration, 0)
+	for _, entry := range strings.Split(value, ",") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid empty cache duration")
+		}
+		duration := CacheDuration{}
+		for _, code := range fields[:len(fields)-1] {
+			if !statusCodeRegex.MatchString(code) {
+				return nil, fmt.Errorf("invalid status code %q in cache duration %q", code, strings.TrimSpace(entry))
+			}
+			n, _ := strconv.Atoi(code)
+			duration.StatusCodes = append(duration.StatusCodes, n)
+		}
+		match := cacheDurationRegex.FindStringSubmatch(fields[len(fields)-1])
+		if match == nil {
+			return nil, fmt.Errorf("invalid duration %q in cache duration %q", fields[len(fields)-1], strings.TrimSpace(entry))
+		}
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q in cache duration %q", fields[len(fields)-1], strings.TrimSpace(entry))
+		}
+		duration.Duration = time.Duration(n) * nginxTimeUnits[match[2]]
+		durations = append(durations, duration)
+	}
+	return durations, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authreq
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	defaults := NewDefaultConfig()
+	defaults.set = nil
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    func(c *Config)
+		wantErr     bool
+	}{
+		{
+			name:        "defaults",
+			annotations: map[string]string{},
+			expected:    func(c *Config) {},
+		},
+		{
+			name: "oauth2-proxy",
+			annotations: map[string]string{
+				authReqURLAnnotation:             "https://oauth2-proxy.auth.svc.cluster.local:4180/oauth2/auth?allowed_groups=admins",
+				authReqSigninAnnotation:          "https://$host/oauth2/start?rd=$escaped_request_uri",
+				authReqResponseHeadersAnnotation: "X-Auth-Request-User, X-Auth-Request-Email",
+				authReqProxySetHeadersAnnotation: "auth-headers",
+				authReqCacheKeyAnnotation:        "$cookie_oauth2_proxy",
+				authReqCacheDuration:             "200 202 10m, 401 30s",
+				authReqKeepaliveAnnotation:       "10",
+				authReqKeepaliveTimeout:          "30",
+				authReqAlwaysSetCookieAnnotation: "true",
+			},
+			expected: func(c *Config) {
+				c.URL = "https://oauth2-proxy.auth.svc.cluster.local:4180/oauth2/auth?allowed_groups=admins"
+				c.Endpoint = &Endpoint{Scheme: "https", Host: "oauth2-proxy.auth.svc.cluster.local", Port: 4180, Path: "/oauth2/auth", RawQuery: "allowed_groups=admins"}
+				c.SignIn = "https://$host/oauth2/start?rd=$escaped_request_uri"
+				c.ResponseHeaders = []string{"X-Auth-Request-User", "X-Auth-Request-Email"}
+				c.ProxySetHeaders = types.NamespacedName{Namespace: "default", Name: "auth-headers"}
+				c.CacheKey = "$cookie_oauth2_proxy"
+				c.CacheDurations = []CacheDuration{
+					{StatusCodes: []int{200, 202}, Duration: 10 * time.Minute},
+					{StatusCodes: []int{401}, Duration: 30 * time.Second},
+				}
+				c.Keepalive.Connections = 10
+				c.Keepalive.Timeout = 30 * time.Second
+				c.AlwaysSetCookie = true
+			},
+		},
+		{
+			name: "variables in the host disable keepalive",
+			annotations: map[string]string{
+				authReqURLAnnotation:       "http://$host/auth",
+				authReqKeepaliveAnnotation: "10",
+			},
+			expected: func(c *Config) {
+				c.URL = "http://$host/auth"
+				c.Endpoint = &Endpoint{Scheme: "http", Host: "$host", Path: "/auth", HostVariables: true}
+			},
+		},
+		{
+			name: "zero keepalive requests disable keepalive",
+			annotations: map[string]string{
+				authReqURLAnnotation:               "http://auth.default.svc/auth",
+				authReqKeepaliveAnnotation:         "10",
+				authReqKeepaliveRequestsAnnotation: "0",
+			},
+			expected: func(c *Config) {
+				c.URL = "http://auth.default.svc/auth"
+				c.Endpoint = &Endpoint{Scheme: "http", Host: "auth.default.svc", Path: "/auth"}
+				c.Keepalive.Requests = 0
+			},
+		},
+		{
+			name: "url without scheme",
+			annotations: map[string]string{
+				authReqURLAnnotation: "auth.default.svc/auth",
+			},
+			expected: func(c *Config) {},
+			wantErr:  true,
+		},
+		{
+			name: "invalid cache duration uses the default",
+			annotations: map[string]string{
+				authReqURLAnnotation:      "http://auth.default.svc/auth",
+				authReqCacheKeyAnnotation: "$remote_user",
+				authReqCacheDuration:      "10m 200",
+			},
+			expected: func(c *Config) {
+				c.URL = "http://auth.default.svc/auth"
+				c.Endpoint = &Endpoint{Scheme: "http", Host: "auth.default.svc", Path: "/auth"}
+				c.CacheKey = "$remote_user"
+			},
+			wantErr: true,
+		},
+		{
+			name: "cross namespace proxy set headers",
+			annotations: map[string]string{
+				authReqURLAnnotation:             "http://auth.default.svc/auth",
+				authReqProxySetHeadersAnnotation: "other/auth-headers",
+			},
+			expected: func(c *Config) {
+				c.URL = "http://auth.default.svc/auth"
+				c.Endpoint = &Endpoint{Scheme: "http", Host: "auth.default.svc", Path: "/auth"}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			expected := *defaults
+			expected.CacheDurations = append([]CacheDuration(nil), defaults.CacheDurations...)
+			tt.expected(&expected)
+			if !reflect.DeepEqual(config, &expected) {
+				t.Errorf("expected %+v, got %+v", &expected, config)
+			}
+		})
+	}
+}
+
+func TestParseCacheDurations(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected []CacheDuration
+		wantErr  bool
+	}{
+		{value: "5m", expected: []CacheDuration{{Duration: 5 * time.Minute}}},
+		{value: "200 202 401 5m", expected: []CacheDuration{{StatusCodes: []int{200, 202, 401}, Duration: 5 * time.Minute}}},
+		{value: "200 1d, 404 500ms", expected: []CacheDuration{
+			{StatusCodes: []int{200}, Duration: 24 * time.Hour},
+			{StatusCodes: []int{404}, Duration: 500 * time.Millisecond},
+		}},
+		{value: "200 1M", expected: []CacheDuration{{StatusCodes: []int{200}, Duration: 30 * 24 * time.Hour}}},
+		{value: "200", wantErr: true},
+		{value: "5m 200", wantErr: true},
+		{value: "2000 5m", wantErr: true},
+		{value: "200 5m,", wantErr: true},
+		{value: "200 5x", wantErr: true},
+	}
+	for _, tt := range tests {
+		durations, err := ParseCacheDurations(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("expected error %t for %q, got %v", tt.wantErr, tt.value, err)
+		}
+		if !reflect.DeepEqual(durations, tt.expected) {
+			t.Errorf("expected %+v for %q, got %+v", tt.expected, tt.value, durations)
+		}
+	}
+}
@@ -28,10 +28,12 @@ var GlobalAuthAnnotations = parser.Annotation{
 	Group: "authentication",
 	Annotations: parser.AnnotationFields{
 		enableGlobalAuthAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `Defines if the global external authentication should be enabled.`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `Defines if the global external authentication should be enabled.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
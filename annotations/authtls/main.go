@@ -38,42 +38,66 @@ var (
 
 var AuthTLSAnnotations = parser.Annotation{
 	Group: "authentication",
+	Rules: []parser.Rule{
+		// All the client certificate annotations are ignored without the CA secret
+		parser.Requires(annotationAuthTLSVerifyClient, annotationAuthTLSSecret),
+		parser.Requires(annotationAuthTLSVerifyDepth, annotationAuthTLSSecret),
+		parser.Requires(annotationAuthTLSErrorPage, annotationAuthTLSSecret),
+		parser.Requires(annotationAuthTLSPassCertToUpstream, annotationAuthTLSSecret),
+		parser.Requires(annotationAuthTLSMatchCN, annotationAuthTLSSecret),
+	},
 	Annotations: parser.AnnotationFields{
 		annotationAuthTLSSecret: {
-			Validator:     parser.ValidateRegex(parser.BasicCharsRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
-			Documentation: `This annotation defines the secret that contains the certificate chain of allowed certs`,
+			Validator:               parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:                  parser.RegexFormat(parser.BasicCharsRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+			Documentation:           `This annotation defines the secret that contains the certificate chain of allowed certs`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by the Gateway 'spec.tls.frontend.default.validation.caCertificateRefs'. It applies to the whole listener",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#frontendtlsvalidation",
 		},
 		annotationAuthTLSVerifyClient: {
-			Validator:     parser.ValidateRegex(authVerifyClientRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
-			Documentation: `This annotation enables verification of client certificates. Can be "on", "off", "optional" or "optional_no_ca"`,
+			Validator:               parser.ValidateRegex(authVerifyClientRegex, true),
+			Format:                  parser.RegexFormat(authVerifyClientRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+			Documentation:           `This annotation enables verification of client certificates. Can be "on", "off", "optional" or "optional_no_ca"`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by the Gateway 'spec.tls.frontend.default.validation.mode'. It applies to the whole listener",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#frontendtlsvalidation",
 		},
 		annotationAuthTLSVerifyDepth: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines validation depth between the provided client certificate and the Certification Authority chain.`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines validation depth between the provided client certificate and the Certification Authority chain.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAuthTLSErrorPage: {
-			Validator:     parser.ValidateRegex(redirectRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation defines the URL/Page that user should be redirected in case of a Certificate Authentication Error`,
+			Validator:               parser.ValidateRegex(redirectRegex, true),
+			Format:                  parser.RegexFormat(redirectRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation defines the URL/Page that user should be redirected in case of a Certificate Authentication Error`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAuthTLSPassCertToUpstream: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines if the received certificates should be passed or not to the upstream server in the header "ssl-client-cert"`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines if the received certificates should be passed or not to the upstream server in the header "ssl-client-cert"`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAuthTLSMatchCN: {
-			Validator:     parser.CommonNameAnnotationValidator,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation adds a sanity check for the CN of the client certificate that is sent over using a string / regex starting with "CN="`,
+			Validator:               parser.CommonNameAnnotationValidator,
+			Format:                  parser.FormatCommonName,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation adds a sanity check for the CN of the client certificate that is sent over using a string / regex starting with "CN="`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authtls
+
+import (
+	"regexp"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultVerifyDepth = 1
+
+// VerifyClient defines if the client certificate is required and verified
+type VerifyClient string
+
+const (
+	// VerifyClientOn requires a client certificate signed by the CA
+	VerifyClientOn VerifyClient = "on"
+	// VerifyClientOff does not request a client certificate
+	VerifyClientOff VerifyClient = "off"
+	// VerifyClientOptional requests a client certificate, and verifies it when sent
+	VerifyClientOptional VerifyClient = "optional"
+	// VerifyClientOptionalNoCA requests a client certificate, without verifying it is signed by the CA
+	VerifyClientOptionalNoCA VerifyClient = "optional_no_ca"
+)
+
+// Config is the typed configuration of the client certificate authentication
+type Config struct {
+	// Secret contains the CA certificates, ca.crt, used to verify the client
+	// certificates. It is always in the namespace of the Ingress, and the zero value
+	// means client certificate authentication is disabled
+	Secret       types.NamespacedName
+	VerifyClient VerifyClient
+	VerifyDepth  int
+	// ErrorPage is the URL or named location used when the verification fails
+	ErrorPage string
+	// PassCertificateToUpstream sends the client certificate to the backend on the ssl-client-cert header
+	PassCertificateToUpstream bool
+	// MatchCN is the regular expression, starting with CN=, matched against the
+	// subject of the client certificate
+	MatchCN string
+
+	// matchCN is the compiled MatchCN
+	matchCN *regexp.Regexp
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the client certificate configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		VerifyClient: VerifyClientOn,
+		VerifyDepth:  defaultVerifyDepth,
+		set:          make(map[string]bool),
+	}
+}
+
+// Enabled returns if the CA secret is set. ingress-nginx ignores all the other annotations without it
+func (c *Config) Enabled() bool {
+	return c.Secret.Name != ""
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// MatchesSubject returns if the subject of a client certificate, in the RFC 2253
+// format used by nginx like CN=client,O=example, is accepted by auth-tls-match-cn.
+// As on nginx, the expression is case sensitive and not anchored. Any subject is
+// accepted when auth-tls-match-cn is not set
+func (c *Config) MatchesSubject(subject string) bool {
+	if c.matchCN == nil {
+		return true
+	}
+	return c.matchCN.MatchString(subject)
+}
+
+// Parse returns the client certificate configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the client certificate configuration of the Ingress. The
+// secret must be in the form namespace/name, on the namespace of the Ingress.
+// Annotations that are not set, or are invalid, keep the ingress-nginx defaults. The
+// invalid annotations are returned together as the error, so the configuration is
+// always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, AuthTLSAnnotations.Annotations, config)
+
+	if value, ok := r.Value(annotationAuthTLSSecret); ok {
+		secret, err := parser.ParseSecretReference(value, ing.Namespace, true)
+		if err != nil {
+			r.Invalid(annotationAuthTLSSecret, value, err)
+		} else {
+			c.Secret = secret
+		}
+	}
+	c.VerifyClient = VerifyClient(r.String(annotationAuthTLSVerifyClient, string(c.VerifyClient)))
+	c.VerifyDepth = r.Int(annotationAuthTLSVerifyDepth, c.VerifyDepth)
+	c.ErrorPage = r.String(annotationAuthTLSErrorPage, c.ErrorPage)
+	c.PassCertificateToUpstream = r.Bool(annotationAuthTLSPassCertToUpstream, c.PassCertificateToUpstream)
+	if value, ok := r.Value(annotationAuthTLSMatchCN); ok {
+		matchCN, err := parser.CompileCommonName(value)
+		if err != nil {
+			r.Invalid(annotationAuthTLSMatchCN, value, err)
+		} else {
+			c.MatchCN, c.matchCN = value, matchCN
+		}
+	}
+
+	c.set = r.Set()
+	return c, r.Err()
+}
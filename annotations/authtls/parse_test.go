@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authtls
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "defaults",
+			annotations: map[string]string{},
+			expected:    &Config{VerifyClient: VerifyClientOn, VerifyDepth: 1},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				annotationAuthTLSSecret:             "default/client-ca",
+				annotationAuthTLSVerifyClient:       "optional",
+				annotationAuthTLSVerifyDepth:        "2",
+				annotationAuthTLSErrorPage:          "https://example.com/error",
+				annotationAuthTLSPassCertToUpstream: "true",
+			},
+			expected: &Config{
+				Secret:                    types.NamespacedName{Namespace: "default", Name: "client-ca"},
+				VerifyClient:              VerifyClientOptional,
+				VerifyDepth:               2,
+				ErrorPage:                 "https://example.com/error",
+				PassCertificateToUpstream: true,
+			},
+		},
+		{
+			name: "cross namespace secret",
+			annotations: map[string]string{
+				annotationAuthTLSSecret: "other/client-ca",
+			},
+			expected: &Config{VerifyClient: VerifyClientOn, VerifyDepth: 1},
+			wantErr:  true,
+		},
+		{
+			name: "invalid verify client",
+			annotations: map[string]string{
+				annotationAuthTLSSecret:       "default/client-ca",
+				annotationAuthTLSVerifyClient: "always",
+			},
+			expected: &Config{
+				Secret:       types.NamespacedName{Namespace: "default", Name: "client-ca"},
+				VerifyClient: VerifyClientOn,
+				VerifyDepth:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "match cn without prefix",
+			annotations: map[string]string{
+				annotationAuthTLSSecret:  "default/client-ca",
+				annotationAuthTLSMatchCN: "payments",
+			},
+			expected: &Config{
+				Secret:       types.NamespacedName{Namespace: "default", Name: "client-ca"},
+				VerifyClient: VerifyClientOn,
+				VerifyDepth:  1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestMatchesSubject(t *testing.T) {
+	tests := []struct {
+		name     string
+		matchCN  string
+		subject  string
+		expected bool
+	}{
+		{name: "not set", subject: "CN=anyone", expected: true},
+		{name: "exact", matchCN: "CN=payments", subject: "CN=payments,O=example", expected: true},
+		{name: "alternatives", matchCN: "CN=(payments|billing)$", subject: "O=example,CN=billing", expected: true},
+		{name: "not anchored", matchCN: "CN=payments", subject: "CN=payments-admin", expected: true},
+		{name: "different", matchCN: "CN=payments", subject: "CN=orders", expected: false},
+		{name: "case sensitive", matchCN: "CN=payments", subject: "CN=Payments", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{annotationAuthTLSSecret: "default/client-ca"}
+			if tt.matchCN != "" {
+				annotations[annotationAuthTLSMatchCN] = tt.matchCN
+			}
+			config, err := Parse(ingresstest.New(annotations))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if config.MatchCN != tt.matchCN {
+				t.Errorf("expected match cn %q, got %q", tt.matchCN, config.MatchCN)
+			}
+			if got := config.MatchesSubject(tt.subject); got != tt.expected {
+				t.Errorf("expected %t for subject %s, got %t", tt.expected, tt.subject, got)
+			}
+		})
+	}
+}
@@ -32,12 +32,14 @@ var BackendProtocolConfig = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		backendProtocolAnnotation: {
 			Validator: parser.ValidateOptions(validProtocols, false, true),
+			Format:    parser.OptionsFormat(validProtocols, false),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
 			Documentation: `this annotation can be used to define which protocol should 
 			be used to communicate with backends`,
-			GatewayAPI:    "Supported by APIs like BackendTLSPolicy and GRPCRoute. FCGI is not supported",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/",
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Supported by APIs like BackendTLSPolicy and GRPCRoute. FCGI is not supported",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/",
 		},
 	},
 }
@@ -32,56 +32,97 @@ const (
 
 var CanaryAnnotations = parser.Annotation{
 	Group: "canary",
+	Rules: []parser.Rule{
+		// All the canary annotations are ignored if the Ingress is not a canary
+		parser.Requires(canaryWeightAnnotation, canaryAnnotation).WithValues("true"),
+		parser.Requires(canaryWeightTotalAnnotation, canaryAnnotation).WithValues("true"),
+		parser.Requires(canaryByHeaderAnnotation, canaryAnnotation).WithValues("true"),
+		parser.Requires(canaryByHeaderValueAnnotation, canaryAnnotation).WithValues("true"),
+		parser.Requires(canaryByHeaderPatternAnnotation, canaryAnnotation).WithValues("true"),
+		parser.Requires(canaryByCookieAnnotation, canaryAnnotation).WithValues("true"),
+		parser.Requires(canaryWeightTotalAnnotation, canaryWeightAnnotation),
+		parser.Requires(canaryByHeaderValueAnnotation, canaryByHeaderAnnotation),
+		parser.Requires(canaryByHeaderPatternAnnotation, canaryByHeaderAnnotation),
+		parser.IgnoredWhen(canaryByHeaderPatternAnnotation, canaryByHeaderValueAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		canaryAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables the Ingress spec to act as an alternative service for requests to route to depending on the rules applied`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables the Ingress spec to act as an alternative service for requests to route to depending on the rules applied`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].backendRefs[]' with multiple weighted backends",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpbackendref",
 		},
 		canaryWeightAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines the integer based (0 - ) percent of random requests that should be routed to the service specified in the canary Ingress`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines the integer based (0 - ) percent of random requests that should be routed to the service specified in the canary Ingress`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].backendRefs[].weight'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpbackendref",
 		},
 		canaryWeightTotalAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation The total weight of traffic. If unspecified, it defaults to 100`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation The total weight of traffic. If unspecified, it defaults to 100`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].backendRefs[].weight'. Weights are relative to the sum of the weights of all the backends",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpbackendref",
 		},
 		canaryByHeaderAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation defines the header that should be used for notifying the Ingress to route the request to the service specified in the Canary Ingress.
 			When the request header is set to 'always', it will be routed to the canary. When the header is set to 'never', it will never be routed to the canary.
 			For any other value, the header will be ignored and the request compared against the other canary rules by precedence`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].matches[].headers'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpheadermatch",
 		},
 		canaryByHeaderValueAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation defines the header value to match for notifying the Ingress to route the request to the service specified in the Canary Ingress. 
 			When the request header is set to this value, it will be routed to the canary. For any other header value, the header will be ignored and the request compared against the other canary rules by precedence. 
 			This annotation has to be used together with 'canary-by-header'. The annotation is an extension of the 'canary-by-header' to allow customizing the header value instead of using hardcoded values. 
 			It doesn't have any effect if the 'canary-by-header' annotation is not defined`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].matches[].headers'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpheadermatch",
 		},
 		canaryByHeaderPatternAnnotation: {
 			Validator: parser.ValidateRegex(parser.IsValidRegex, false),
+			Format:    parser.RegexFormat(parser.IsValidRegex, false),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation works the same way as canary-by-header-value except it does PCRE Regex matching. 
 			Note that when 'canary-by-header-value' is set this annotation will be ignored. 
 			When the given Regex causes error during request processing, the request will be considered as not matching.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].matches[].headers' with type RegularExpression. The regular expression syntax is implementation specific",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpheadermatch",
 		},
 		canaryByCookieAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation defines the cookie that should be used for notifying the Ingress to route the request to the service specified in the Canary Ingress.
 			When the cookie is set to 'always', it will be routed to the canary. When the cookie is set to 'never', it will never be routed to the canary`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].matches[].headers' with a RegularExpression match on the Cookie header",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpheadermatch",
 		},
 	},
 }
@@ -0,0 +1,275 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TrafficSplit is how the traffic of a path of a primary Ingress is split with a canary Ingress
+type TrafficSplit struct {
+	// Host is the host of the path, empty when it applies to any host
+	Host string `json:"host"`
+	// Path is the path of the Ingress rules, empty for the default backend
+	Path string `json:"path,omitempty"`
+	// PathType is the type of the path of the primary Ingress
+	PathType *networking.PathType `json:"pathType,omitempty"`
+	// DefaultBackend is true when the split applies to the default backends of the Ingresses
+	DefaultBackend bool `json:"defaultBackend,omitempty"`
+
+	Primary        types.NamespacedName      `json:"primary"`
+	PrimaryBackend networking.IngressBackend `json:"primaryBackend"`
+	Canary         types.NamespacedName      `json:"canary"`
+	CanaryBackend  networking.IngressBackend `json:"canaryBackend"`
+
+	// Rules are the canary conditions, in the order ingress-nginx evaluates them.
+	// Requests not selected by any rule are routed to the primary backend
+	Rules []Rule `json:"rules"`
+}
+
+// Plan is the result of pairing a set of Ingresses
+type Plan struct {
+	// Splits contains a traffic split for each path with a valid canary
+	Splits []TrafficSplit `json:"splits"`
+	// Results contains the findings of the Ingresses, by namespace and name
+	Results map[types.NamespacedName]*parser.ValidationResult `json:"-"`
+}
+
+// HasErrors returns if any Ingress has findings with Error severity
+func (p *Plan) HasErrors() bool {
+	for _, result := range p.Results {
+		if result.HasErrors() {
+			return true
+		}
+	}
+	return false
+}
+
+// Result returns the findings of an Ingress
+func (p *Plan) Result(ing *networking.Ingress) *parser.ValidationResult {
+	if result, ok := p.Results[namespacedName(ing)]; ok {
+		return result
+	}
+	return &parser.ValidationResult{}
+}
+
+// backendKey identifies a path of an Ingress, the way ingress-nginx merges canaries
+type backendKey struct {
+	host           string
+	path           string
+	defaultBackend bool
+}
+
+func (k backendKey) String() string {
+	if k.defaultBackend {
+		return "default backend"
+	}
+	host := k.host
+	if host == "" {
+		host = "*"
+	}
+	return fmt.Sprintf("host %s path %s", host, k.path)
+}
+
+type primaryBackend struct {
+	ingress  types.NamespacedName
+	backend  networking.IngressBackend
+	pathType *networking.PathType
+}
+
+// Pair pairs canary Ingresses with their primaries, using the default parser configuration
+func Pair(ingresses []*networking.Ingress) *Plan {
+	return PairWithConfig(ingresses, parser.DefaultConfig())
+}
+
+// PairWithConfig pairs each path of the canary Ingresses with the path of a primary
+// Ingress with the same host and path, as ingress-nginx does. Ingresses are processed
+// by creation time, so when more than one canary targets the same path only the oldest
+// one is used. Canaries without a primary, paths with more than one canary and
+// invalid weights are reported as findings of the canary Ingress
+func PairWithConfig(ingresses []*networking.Ingress, config parser.Config) *Plan {
+	p := &pairer{
+		plan: &Plan{
+			Splits:  make([]TrafficSplit, 0),
+			Results: make(map[types.NamespacedName]*parser.ValidationResult),
+		},
+		config:    config,
+		primaries: make(map[backendKey]primaryBackend),
+		canaries:  make(map[backendKey]types.NamespacedName),
+	}
+
+	sorted := slices.DeleteFunc(slices.Clone(ingresses), func(ing *networking.Ingress) bool {
+		return ing == nil
+	})
+	slices.SortStableFunc(sorted, func(a, b *networking.Ingress) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(namespacedName(a).String(), namespacedName(b).String())
+	})
+
+	configs := make([]*Config, len(sorted))
+	for i, ing := range sorted {
+		p.plan.Results[namespacedName(ing)] = &parser.ValidationResult{}
+		// Invalid annotations are reported by the registry validation
+		configs[i], _ = ParseWithConfig(ing, config)
+		if !configs[i].Enabled {
+			p.addPrimary(ing)
+		}
+	}
+	for i, ing := range sorted {
+		if configs[i].Enabled {
+			p.addCanary(ing, configs[i])
+		}
+	}
+	return p.plan
+}
+
+// pairer holds the state of a single pairing
+type pairer struct {
+	plan   *Plan
+	config parser.Config
+	// primaries contains the backend of each path of the primary Ingresses
+	primaries map[backendKey]primaryBackend
+	// canaries contains the canary already paired with each path
+	canaries map[backendKey]types.NamespacedName
+}
+
+// addPrimary registers the paths of a primary Ingress. The oldest Ingress is used when
+// more than one Ingress defines the same path
+func (p *pairer) addPrimary(ing *networking.Ingress) {
+	for key, backend := range backends(ing) {
+		if _, ok := p.primaries[key]; !ok {
+			p.primaries[key] = primaryBackend{ingress: namespacedName(ing), backend: backend.backend, pathType: backend.pathType}
+		}
+	}
+}
+
+// addCanary pairs the paths of a canary Ingress with the primaries
+func (p *pairer) addCanary(ing *networking.Ingress, c *Config) {
+	name := namespacedName(ing)
+	report := func(severity parser.Severity, annotation, reason string) {
+		p.plan.Results[name].Add(p.newFinding(ing, severity, annotation, reason))
+	}
+
+	if c.WeightTotal <= 0 {
+		report(parser.SeverityError, canaryWeightTotalAnnotation, fmt.Sprintf("canary weight total %d must be greater than 0", c.WeightTotal))
+		return
+	}
+	if c.Weight > c.WeightTotal {
+		report(parser.SeverityError, canaryWeightAnnotation, fmt.Sprintf("canary weight %d is higher than the total weight %d", c.Weight, c.WeightTotal))
+		return
+	}
+	rules := c.Rules()
+	if len(rules) == 0 {
+		report(parser.SeverityWarning, canaryAnnotation, "canary does not define a header, cookie or weight, and does not receive any traffic")
+	}
+
+	paths := backends(ing)
+	if len(paths) == 0 {
+		report(parser.SeverityError, canaryAnnotation, "canary Ingress does not define any backend")
+		return
+	}
+	for _, key := range sortedKeys(paths) {
+		primary, ok := p.primaries[key]
+		if !ok {
+			report(parser.SeverityError, canaryAnnotation, fmt.Sprintf("no primary Ingress found for %s", key))
+			continue
+		}
+		if other, ok := p.canaries[key]; ok {
+			report(parser.SeverityError, canaryAnnotation, fmt.Sprintf("%s of Ingress %s already has the canary %s, only one canary is supported", key, primary.ingress, other))
+			continue
+		}
+		p.canaries[key] = name
+		p.plan.Splits = append(p.plan.Splits, TrafficSplit{
+			Host:           key.host,
+			Path:           key.path,
+			PathType:       primary.pathType,
+			DefaultBackend: key.defaultBackend,
+			Primary:        primary.ingress,
+			PrimaryBackend: primary.backend,
+			Canary:         name,
+			CanaryBackend:  paths[key].backend,
+			Rules:          slices.Clone(rules),
+		})
+	}
+}
+
+// newFinding returns the finding of a canary Ingress that is not valid relative to its primary
+func (p *pairer) newFinding(ing *networking.Ingress, severity parser.Severity, annotation, reason string) parser.Finding {
+	fullName := p.config.AnnotationWithPrefix(annotation)
+	return parser.Finding{
+		Type:       parser.FindingInvalidCanary,
+		Severity:   severity,
+		Annotation: fullName,
+		Value:      ing.GetAnnotations()[fullName],
+		Feature:    "canary",
+		Group:      CanaryAnnotations.Group,
+		Risk:       CanaryAnnotations.Annotations[annotation].Risk,
+		Reason:     reason,
+	}
+}
+
+type pathBackend struct {
+	backend  networking.IngressBackend
+	pathType *networking.PathType
+}
+
+// backends returns the backends of the paths of the Ingress, including the default backend
+func backends(ing *networking.Ingress) map[backendKey]pathBackend {
+	result := make(map[backendKey]pathBackend)
+	if ing.Spec.DefaultBackend != nil {
+		result[backendKey{defaultBackend: true}] = pathBackend{backend: *ing.Spec.DefaultBackend}
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			value := path.Path
+			if value == "" {
+				value = "/"
+			}
+			key := backendKey{host: rule.Host, path: value}
+			if _, ok := result[key]; !ok {
+				result[key] = pathBackend{backend: path.Backend, pathType: path.PathType}
+			}
+		}
+	}
+	return result
+}
+
+func sortedKeys(backends map[backendKey]pathBackend) []backendKey {
+	keys := make([]backendKey, 0, len(backends))
+	for key := range backends {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b backendKey) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return keys
+}
+
+func namespacedName(ing *networking.Ingress) types.NamespacedName {
+	return types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}
+}
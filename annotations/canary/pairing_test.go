@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var created = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newPairedIngress returns an Ingress routing the path / of the host to the Service
+func newPairedIngress(name, host, service string, age time.Duration, annotations map[string]string) *networking.Ingress {
+	ing := ingresstest.WithPath(ingresstest.New(annotations), host, "/", networking.PathTypePrefix, service, 80)
+	ing.Name = name
+	ing.CreationTimestamp = v1.NewTime(created.Add(age))
+	return ing
+}
+
+func TestConfigRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []Rule
+	}{
+		{
+			name:        "not a canary",
+			annotations: map[string]string{canaryWeightAnnotation: "10"},
+			expected:    []Rule{},
+		},
+		{
+			name: "precedence of header, cookie and weight",
+			annotations: map[string]string{
+				canaryAnnotation:         "true",
+				canaryWeightAnnotation:   "10",
+				canaryByCookieAnnotation: "use_canary",
+				canaryByHeaderAnnotation: "X-Canary",
+			},
+			expected: []Rule{
+				{Type: RuleHeader, Name: "X-Canary"},
+				{Type: RuleCookie, Name: "use_canary"},
+				{Type: RuleWeight, Weight: 10, WeightTotal: 100},
+			},
+		},
+		{
+			name: "header value has precedence over the pattern",
+			annotations: map[string]string{
+				canaryAnnotation:                "true",
+				canaryByHeaderAnnotation:        "X-Canary",
+				canaryByHeaderValueAnnotation:   "yes",
+				canaryByHeaderPatternAnnotation: "^(y|yes)$",
+			},
+			expected: []Rule{{Type: RuleHeaderValue, Name: "X-Canary", Value: "yes"}},
+		},
+		{
+			name: "header pattern and weight total",
+			annotations: map[string]string{
+				canaryAnnotation:                "true",
+				canaryByHeaderAnnotation:        "X-Canary",
+				canaryByHeaderPatternAnnotation: "^(y|yes)$",
+				canaryWeightAnnotation:          "5",
+				canaryWeightTotalAnnotation:     "1000",
+			},
+			expected: []Rule{
+				{Type: RuleHeaderPattern, Name: "X-Canary", Value: "^(y|yes)$"},
+				{Type: RuleWeight, Weight: 5, WeightTotal: 1000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(newPairedIngress("canary", "app.example.com", "canary", 0, tt.annotations))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if rules := config.Rules(); !reflect.DeepEqual(rules, tt.expected) {
+				t.Errorf("expected rules %+v, got %+v", tt.expected, rules)
+			}
+		})
+	}
+}
+
+func TestPair(t *testing.T) {
+	primary := newPairedIngress("primary", "app.example.com", "app", 0, nil)
+	canary := newPairedIngress("canary", "app.example.com", "app-canary", time.Hour, map[string]string{
+		canaryAnnotation:       "true",
+		canaryWeightAnnotation: "20",
+	})
+
+	plan := Pair([]*networking.Ingress{canary, primary})
+	if plan.HasErrors() {
+		t.Fatalf("unexpected errors %+v", plan.Results)
+	}
+	if len(plan.Splits) != 1 {
+		t.Fatalf("expected 1 split, got %+v", plan.Splits)
+	}
+	split := plan.Splits[0]
+	if split.Host != "app.example.com" || split.Path != "/" {
+		t.Errorf("unexpected split host %s path %s", split.Host, split.Path)
+	}
+	if split.Primary != (types.NamespacedName{Namespace: "default", Name: "primary"}) || split.Canary.Name != "canary" {
+		t.Errorf("unexpected pairing %s and %s", split.Primary, split.Canary)
+	}
+	if split.PrimaryBackend.Service.Name != "app" || split.CanaryBackend.Service.Name != "app-canary" {
+		t.Errorf("unexpected backends %s and %s", split.PrimaryBackend.Service.Name, split.CanaryBackend.Service.Name)
+	}
+	if !reflect.DeepEqual(split.Rules, []Rule{{Type: RuleWeight, Weight: 20, WeightTotal: 100}}) {
+		t.Errorf("unexpected rules %+v", split.Rules)
+	}
+}
+
+func TestPairFindings(t *testing.T) {
+	primary := newPairedIngress("primary", "app.example.com", "app", 0, nil)
+
+	tests := []struct {
+		name      string
+		ingresses []*networking.Ingress
+		// expected is the reason of the finding of the last Ingress
+		expected string
+		severity parser.Severity
+		splits   int
+	}{
+		{
+			name: "missing primary",
+			ingresses: []*networking.Ingress{
+				primary,
+				newPairedIngress("canary", "other.example.com", "canary", time.Hour, map[string]string{canaryAnnotation: "true", canaryWeightAnnotation: "10"}),
+			},
+			expected: "no primary Ingress found for host other.example.com path /",
+			severity: parser.SeverityError,
+		},
+		{
+			name: "multiple canaries on the same path",
+			ingresses: []*networking.Ingress{
+				primary,
+				newPairedIngress("canary-a", "app.example.com", "canary-a", time.Hour, map[string]string{canaryAnnotation: "true", canaryWeightAnnotation: "10"}),
+				newPairedIngress("canary-b", "app.example.com", "canary-b", 2*time.Hour, map[string]string{canaryAnnotation: "true", canaryByHeaderAnnotation: "X-Canary"}),
+			},
+			expected: "already has the canary default/canary-a",
+			severity: parser.SeverityError,
+			splits:   1,
+		},
+		{
+			name: "weight above the total",
+			ingresses: []*networking.Ingress{
+				primary,
+				newPairedIngress("canary", "app.example.com", "canary", time.Hour, map[string]string{
+					canaryAnnotation:            "true",
+					canaryWeightAnnotation:      "20",
+					canaryWeightTotalAnnotation: "10",
+				}),
+			},
+			expected: "canary weight 20 is higher than the total weight 10",
+			severity: parser.SeverityError,
+		},
+		{
+			name: "canary without traffic",
+			ingresses: []*networking.Ingress{
+				primary,
+				newPairedIngress("canary", "app.example.com", "canary", time.Hour, map[string]string{canaryAnnotation: "true"}),
+			},
+			expected: "does not receive any traffic",
+			severity: parser.SeverityWarning,
+			splits:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Pair(tt.ingresses)
+			if len(plan.Splits) != tt.splits {
+				t.Errorf("expected %d splits, got %+v", tt.splits, plan.Splits)
+			}
+			if plan.Result(primary).HasErrors() {
+				t.Errorf("unexpected findings on the primary %+v", plan.Result(primary).Findings)
+			}
+			result := plan.Result(tt.ingresses[len(tt.ingresses)-1])
+			if len(result.Findings) != 1 {
+				t.Fatalf("expected 1 finding, got %+v", result.Findings)
+			}
+			finding := result.Findings[0]
+			if finding.Type != parser.FindingInvalidCanary || finding.Severity != tt.severity || !strings.Contains(finding.Reason, tt.expected) {
+				t.Errorf("expected %s finding containing %q, got %+v", tt.severity, tt.expected, finding)
+			}
+		})
+	}
+}
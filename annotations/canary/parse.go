@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+// defaultWeightTotal is the total weight used when canary-weight-total is not set
+const defaultWeightTotal = 100
+
+// RuleType defines how a request is selected to be routed to the canary
+type RuleType string
+
+const (
+	// RuleHeader routes the request to the canary when the header is "always", and to
+	// the primary, skipping all the other rules, when the header is "never"
+	RuleHeader RuleType = "Header"
+	// RuleHeaderValue routes the request to the canary when the header has the value
+	RuleHeaderValue RuleType = "HeaderValue"
+	// RuleHeaderPattern routes the request to the canary when the header matches the PCRE pattern
+	RuleHeaderPattern RuleType = "HeaderPattern"
+	// RuleCookie routes the request to the canary when the cookie is "always", and to
+	// the primary, skipping the weight, when the cookie is "never"
+	RuleCookie RuleType = "Cookie"
+	// RuleWeight routes a random share of Weight/WeightTotal of the requests to the canary
+	RuleWeight RuleType = "Weight"
+)
+
+// Rule is a single condition of a canary
+type Rule struct {
+	Type RuleType `json:"type"`
+	// Name is the name of the header or cookie
+	Name string `json:"name,omitempty"`
+	// Value is the header value or pattern
+	Value       string `json:"value,omitempty"`
+	Weight      int    `json:"weight,omitempty"`
+	WeightTotal int    `json:"weightTotal,omitempty"`
+}
+
+// Config is the typed configuration of the canary annotations
+type Config struct {
+	Enabled       bool
+	Weight        int
+	WeightTotal   int
+	Header        string
+	HeaderValue   string
+	HeaderPattern string
+	Cookie        string
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the canary configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		WeightTotal: defaultWeightTotal,
+		set:         make(map[string]bool),
+	}
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Rules returns the conditions of the canary in the order ingress-nginx evaluates
+// them: the header, then the cookie and finally the weight. A header only uses one
+// rule, with canary-by-header-value having precedence over canary-by-header-pattern
+func (c *Config) Rules() []Rule {
+	rules := make([]Rule, 0)
+	if !c.Enabled {
+		return rules
+	}
+	switch {
+	case c.Header != "" && c.HeaderValue != "":
+		rules = append(rules, Rule{Type: RuleHeaderValue, Name: c.Header, Value: c.HeaderValue})
+	case c.Header != "" && c.HeaderPattern != "":
+		rules = append(rules, Rule{Type: RuleHeaderPattern, Name: c.Header, Value: c.HeaderPattern})
+	case c.Header != "":
+		rules = append(rules, Rule{Type: RuleHeader, Name: c.Header})
+	}
+	if c.Cookie != "" {
+		rules = append(rules, Rule{Type: RuleCookie, Name: c.Cookie})
+	}
+	if c.Weight > 0 {
+		rules = append(rules, Rule{Type: RuleWeight, Weight: c.Weight, WeightTotal: c.WeightTotal})
+	}
+	return rules
+}
+
+// Parse returns the canary configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the canary configuration of the Ingress. Annotations that are
+// not set, or are invalid, keep the ingress-nginx defaults. The invalid annotations are
+// returned together as the error, so the configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, CanaryAnnotations.Annotations, config)
+
+	c.Enabled = r.Bool(canaryAnnotation, false)
+	c.Weight = r.Int(canaryWeightAnnotation, c.Weight)
+	c.WeightTotal = r.Int(canaryWeightTotalAnnotation, c.WeightTotal)
+	c.Header = r.String(canaryByHeaderAnnotation, c.Header)
+	c.HeaderValue = r.String(canaryByHeaderValueAnnotation, c.HeaderValue)
+	c.HeaderPattern = r.String(canaryByHeaderPatternAnnotation, c.HeaderPattern)
+	c.Cookie = r.String(canaryByCookieAnnotation, c.Cookie)
+
+	c.set = r.Set()
+	return c, r.Err()
+}
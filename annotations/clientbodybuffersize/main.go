@@ -29,12 +29,14 @@ var ClientBodyBufferSizeConfig = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		clientBodyBufferSizeAnnotation: {
 			Validator: parser.ValidateRegex(parser.SizeRegex, true),
+			Format:    parser.RegexFormat(parser.SizeRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
 			Documentation: `Sets buffer size for reading client request body per location. 
 			In case the request body is larger than the buffer, the whole body or only its part is written to a temporary file. 
 			By default, buffer size is equal to two memory pages. This is 8K on x86, other 32-bit platforms, and x86-64. 
 			It is usually 16K on other 64-bit platforms. This annotation is applied to each location provided in the ingress rule.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
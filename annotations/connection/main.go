@@ -32,10 +32,12 @@ var ConnectionHeadersAnnotations = parser.Annotation{
 	Group: "backend",
 	Annotations: parser.AnnotationFields{
 		connectionProxyHeaderAnnotation: {
-			Validator:     parser.ValidateRegex(validConnectionHeaderValue, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation allows setting a specific value for "proxy_set_header Connection" directive. Right now it is restricted to "close" or "keep-alive"`,
+			Validator:               parser.ValidateRegex(validConnectionHeaderValue, true),
+			Format:                  parser.RegexFormat(validConnectionHeaderValue, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation allows setting a specific value for "proxy_set_header Connection" directive. Right now it is restricted to "close" or "keep-alive"`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -60,54 +60,91 @@ const (
 
 var CORSAnnotation = parser.Annotation{
 	Group: "cors",
+	Rules: []parser.Rule{
+		// All the cors annotations are ignored if cors is not enabled
+		parser.Requires(corsAllowOriginAnnotation, corsEnableAnnotation).WithValues("true"),
+		parser.Requires(corsAllowHeadersAnnotation, corsEnableAnnotation).WithValues("true"),
+		parser.Requires(corsAllowMethodsAnnotation, corsEnableAnnotation).WithValues("true"),
+		parser.Requires(corsAllowCredentialsAnnotation, corsEnableAnnotation).WithValues("true"),
+		parser.Requires(corsExposeHeadersAnnotation, corsEnableAnnotation).WithValues("true"),
+		parser.Requires(corsMaxAgeAnnotation, corsEnableAnnotation).WithValues("true"),
+	},
 	Annotations: parser.AnnotationFields{
 		corsEnableAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables Cross-Origin Resource Sharing (CORS) in an Ingress rule`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables Cross-Origin Resource Sharing (CORS) in an Ingress rule`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 		corsAllowOriginAnnotation: {
 			Validator: parser.ValidateRegex(corsOriginRegexValidator, true),
+			Format:    parser.RegexFormat(corsOriginRegexValidator, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation controls what's the accepted Origin for CORS.
 			This is a multi-valued field, separated by ','. It must follow this format: protocol://origin-site.com, protocol://origin-site.com:port, null, or *.
 			It also supports single level wildcard subdomains and follows this format: https://*.foo.bar, http://*.bar.foo:8080 or myprotocol://*.abc.bar.foo:9000
 			Protocol can be any lowercase string, like http, https, or mycustomprotocol.`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors.allowOrigins'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 		corsAllowHeadersAnnotation: {
 			Validator: parser.ValidateRegex(parser.HeadersVariable, true),
+			Format:    parser.RegexFormat(parser.HeadersVariable, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation controls which headers are accepted.
 			This is a multi-valued field, separated by ',' and accepts letters, numbers, _ and -`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors.allowHeaders'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 		corsAllowMethodsAnnotation: {
 			Validator: parser.ValidateRegex(corsMethodsRegex, true),
+			Format:    parser.RegexFormat(corsMethodsRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation controls which methods are accepted.
 			This is a multi-valued field, separated by ',' and accepts only letters (upper and lower case)`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors.allowMethods'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 		corsAllowCredentialsAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation controls if credentials can be passed during CORS operations.`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation controls if credentials can be passed during CORS operations.`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors.allowCredentials'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 		corsExposeHeadersAnnotation: {
 			Validator: parser.ValidateRegex(corsExposeHeadersRegex, true),
+			Format:    parser.RegexFormat(corsExposeHeadersRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation controls which headers are exposed to response.
 			This is a multi-valued field, separated by ',' and accepts letters, numbers, _, - and *.`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors.exposeHeaders'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 		corsMaxAgeAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation controls how long, in seconds, preflight requests can be cached.`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation controls how long, in seconds, preflight requests can be cached.`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].cors.maxAge'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpcorsfilter",
 		},
 	},
 }
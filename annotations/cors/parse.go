@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+// wildcardLabel replaces the wildcard of an origin, matching a single level subdomain
+const wildcardLabel = `[A-Za-z0-9\-]+`
+
+// Config is the typed CORS policy of an Ingress. Lists keep the order of the annotations
+type Config struct {
+	Enabled          bool
+	AllowOrigin      []string
+	AllowHeaders     []string
+	AllowMethods     []string
+	AllowCredentials bool
+	ExposeHeaders    []string
+	MaxAge           int
+
+	// IgnoredOrigins are the origins of cors-allow-origin that are not valid, and
+	// are ignored by ingress-nginx
+	IgnoredOrigins []string
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the CORS policy used by ingress-nginx when only enable-cors is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		AllowOrigin:      []string{"*"},
+		AllowHeaders:     splitList(defaultCorsHeaders),
+		AllowMethods:     splitList(defaultCorsMethods),
+		AllowCredentials: true,
+		ExposeHeaders:    []string{},
+		MaxAge:           defaultCorsMaxAge,
+		set:              make(map[string]bool),
+	}
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// AllowsAnyOrigin returns if requests from any origin are allowed
+func (c *Config) AllowsAnyOrigin() bool {
+	return len(c.AllowOrigin) == 1 && c.AllowOrigin[0] == "*"
+}
+
+// AllowsOrigin returns if the value of the Origin header of a request is allowed,
+// so the CORS headers are added to the response. As on ingress-nginx, the comparison
+// is case insensitive, ports must match exactly and a wildcard matches a single level
+// subdomain, so https://*.foo.bar allows https://a.foo.bar but not https://a.b.foo.bar
+func (c *Config) AllowsOrigin(origin string) bool {
+	if !c.Enabled || origin == "" {
+		return false
+	}
+	if c.AllowsAnyOrigin() {
+		return true
+	}
+	for _, allowed := range c.AllowOrigin {
+		if originRegex(allowed).MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// originRegex returns the expression ingress-nginx uses to match an allowed origin
+func originRegex(origin string) *regexp.Regexp {
+	expression := strings.Replace(regexp.QuoteMeta(origin), `\*`, wildcardLabel, 1)
+	return regexp.MustCompile(`(?i)^` + expression + `$`)
+}
+
+// Parse returns the CORS policy of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the CORS policy of the Ingress. Annotations that are not set,
+// or are invalid, keep the ingress-nginx defaults. The invalid annotations are returned
+// together as the error, so the policy is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, CORSAnnotation.Annotations, config)
+
+	c.Enabled = r.Bool(corsEnableAnnotation, false)
+	if value, ok := r.Value(corsAllowOriginAnnotation); ok {
+		c.AllowOrigin, c.IgnoredOrigins = parseOrigins(value)
+		if len(c.AllowOrigin) == 0 {
+			r.Invalid(corsAllowOriginAnnotation, value, fmt.Errorf("no valid origin"))
+			c.AllowOrigin = []string{"*"}
+		}
+	}
+	if value, ok := r.Value(corsAllowHeadersAnnotation); ok {
+		c.AllowHeaders = splitList(value)
+	}
+	if value, ok := r.Value(corsAllowMethodsAnnotation); ok {
+		c.AllowMethods = splitList(value)
+	}
+	c.AllowCredentials = r.Bool(corsAllowCredentialsAnnotation, c.AllowCredentials)
+	if value, ok := r.Value(corsExposeHeadersAnnotation); ok {
+		c.ExposeHeaders = splitList(value)
+	}
+	c.MaxAge = r.Int(corsMaxAgeAnnotation, c.MaxAge)
+
+	c.set = r.Set()
+	return c, r.Err()
+}
+
+// parseOrigins returns the valid and the ignored origins of cors-allow-origin
+func parseOrigins(value string) (origins, ignored []string) {
+	origins = make([]string, 0)
+	for _, origin := range splitList(value) {
+		if !corsOriginRegex.MatchString(origin) {
+			ignored = append(ignored, origin)
+			continue
+		}
+		origins = append(origins, origin)
+	}
+	return origins, ignored
+}
+
+// splitList returns the trimmed and non empty items of a comma separated list
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
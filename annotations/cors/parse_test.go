@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cors
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "defaults",
+			annotations: map[string]string{corsEnableAnnotation: "true"},
+			expected: &Config{
+				Enabled:          true,
+				AllowOrigin:      []string{"*"},
+				AllowHeaders:     []string{"DNT", "Keep-Alive", "User-Agent", "X-Requested-With", "If-Modified-Since", "Cache-Control", "Content-Type", "Range", "Authorization"},
+				AllowMethods:     []string{"GET", "PUT", "POST", "DELETE", "PATCH", "OPTIONS"},
+				AllowCredentials: true,
+				ExposeHeaders:    []string{},
+				MaxAge:           1728000,
+			},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				corsEnableAnnotation:           "true",
+				corsAllowOriginAnnotation:      "https://origin.com, https://*.example.com:8443,invalid.com",
+				corsAllowHeadersAnnotation:     "X-Forwarded-For, X-App",
+				corsAllowMethodsAnnotation:     "GET,POST",
+				corsAllowCredentialsAnnotation: "false",
+				corsExposeHeadersAnnotation:    "*",
+				corsMaxAgeAnnotation:           "600",
+			},
+			expected: &Config{
+				Enabled:          true,
+				AllowOrigin:      []string{"https://origin.com", "https://*.example.com:8443"},
+				AllowHeaders:     []string{"X-Forwarded-For", "X-App"},
+				AllowMethods:     []string{"GET", "POST"},
+				AllowCredentials: false,
+				ExposeHeaders:    []string{"*"},
+				MaxAge:           600,
+				IgnoredOrigins:   []string{"invalid.com"},
+			},
+		},
+		{
+			name: "invalid values keep the defaults",
+			annotations: map[string]string{
+				corsEnableAnnotation:       "true",
+				corsAllowOriginAnnotation:  "origin.com",
+				corsAllowMethodsAnnotation: "GET;POST",
+				corsMaxAgeAnnotation:       "a day",
+			},
+			expected: &Config{
+				Enabled:          true,
+				AllowOrigin:      []string{"*"},
+				AllowHeaders:     []string{"DNT", "Keep-Alive", "User-Agent", "X-Requested-With", "If-Modified-Since", "Cache-Control", "Content-Type", "Range", "Authorization"},
+				AllowMethods:     []string{"GET", "PUT", "POST", "DELETE", "PATCH", "OPTIONS"},
+				AllowCredentials: true,
+				ExposeHeaders:    []string{},
+				MaxAge:           1728000,
+				IgnoredOrigins:   []string{"origin.com"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestParseIsSet(t *testing.T) {
+	config, err := Parse(ingresstest.New(map[string]string{
+		corsEnableAnnotation: "true",
+		corsMaxAgeAnnotation: "invalid",
+	}))
+	if err == nil {
+		t.Errorf("expected an error for the invalid max age")
+	}
+	if !config.IsSet(corsEnableAnnotation) || config.IsSet(corsMaxAgeAnnotation) || config.IsSet(corsAllowOriginAnnotation) {
+		t.Errorf("unexpected set markers %v", config.set)
+	}
+}
+
+func TestAllowsOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		origins  []string
+		origin   string
+		expected bool
+	}{
+		{name: "any origin", origins: []string{"*"}, origin: "https://anything.com", expected: true},
+		{name: "exact origin", origins: []string{"https://origin.com"}, origin: "https://origin.com", expected: true},
+		{name: "case insensitive", origins: []string{"https://origin.com"}, origin: "HTTPS://Origin.com", expected: true},
+		{name: "different scheme", origins: []string{"https://origin.com"}, origin: "http://origin.com"},
+		{name: "different port", origins: []string{"https://origin.com:8443"}, origin: "https://origin.com"},
+		{name: "port must match exactly", origins: []string{"https://origin.com"}, origin: "https://origin.com:443"},
+		{name: "not a suffix match", origins: []string{"https://origin.com"}, origin: "https://evil-origin.com"},
+		{name: "single level wildcard", origins: []string{"https://*.origin.com"}, origin: "https://app.origin.com", expected: true},
+		{name: "wildcard does not match two levels", origins: []string{"https://*.origin.com"}, origin: "https://a.b.origin.com"},
+		{name: "wildcard does not match the domain", origins: []string{"https://*.origin.com"}, origin: "https://origin.com"},
+		{name: "wildcard with port", origins: []string{"http://*.origin.com:8080"}, origin: "http://app.origin.com:8080", expected: true},
+		{name: "custom protocol", origins: []string{"myprotocol://*.abc.bar.foo:9000"}, origin: "myprotocol://x.abc.bar.foo:9000", expected: true},
+		{name: "null origin", origins: []string{"null"}, origin: "null", expected: true},
+		{name: "second origin", origins: []string{"https://a.com", "https://b.com"}, origin: "https://b.com", expected: true},
+		{name: "empty origin", origins: []string{"*"}, origin: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Enabled = true
+			config.AllowOrigin = tt.origins
+			if allowed := config.AllowsOrigin(tt.origin); allowed != tt.expected {
+				t.Errorf("expected %t for origin %s, got %t", tt.expected, tt.origin, allowed)
+			}
+		})
+	}
+
+	disabled := NewDefaultConfig()
+	if disabled.AllowsOrigin("https://origin.com") {
+		t.Errorf("expected no origin to be allowed when CORS is disabled")
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customheaders_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/customheaders"
+	"github.com/rikatz/ingress-nginx-annotations/references"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestLoadHeaders(t *testing.T) {
+	configMaps := references.NewFake(&corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "headers", Namespace: "default"},
+		Data:       map[string]string{"X-A": "a"},
+	})
+
+	headers, err := customheaders.LoadHeaders(configMaps, types.NamespacedName{Namespace: "default", Name: "headers"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if expected := []customheaders.Header{{Name: "X-A", Value: "a"}}; !reflect.DeepEqual(headers, expected) {
+		t.Errorf("expected %+v, got %+v", expected, headers)
+	}
+
+	if _, err := customheaders.LoadHeaders(configMaps, types.NamespacedName{Namespace: "default", Name: "missing"}); err == nil {
+		t.Errorf("expected error reading a missing configmap")
+	}
+}
@@ -29,12 +29,14 @@ var CustomHeadersAnnotation = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		customHeadersConfigMapAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation sets the name of a ConfigMap that specifies headers to pass to the client.
 			Only ConfigMaps on the same namespace are allowed`,
-			GatewayAPI:    "Supported by 'spec.rules[].filters[].responseHeaderModifier",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by 'spec.rules[].filters[].responseHeaderModifier'. The headers of the ConfigMap must be copied to the filter, and nginx variables in their values are not evaluated",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 	},
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customheaders
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	// tokenRegex matches the header names, that are tokens as defined by RFC 9110
+	tokenRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+	// controlRegex matches the control characters that are not allowed on header values
+	controlRegex = regexp.MustCompile("[\x00-\x08\x0a-\x1f\x7f]")
+
+	// hopByHopHeaders are only meaningful for a single connection, and are not forwarded
+	// by proxies, so setting them would break the connection to the client or the backend
+	hopByHopHeaders = []string{
+		"connection",
+		"keep-alive",
+		"proxy-authenticate",
+		"proxy-authorization",
+		"proxy-connection",
+		"te",
+		"trailer",
+		"transfer-encoding",
+		"upgrade",
+	}
+)
+
+// ConfigMapGetter returns the ConfigMaps containing headers
+type ConfigMapGetter interface {
+	GetConfigMap(namespace, name string) (*corev1.ConfigMap, error)
+}
+
+// Header is an entry of a ConfigMap of headers
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Config is the typed configuration of the custom headers annotation
+type Config struct {
+	// ConfigMap contains the headers added to the responses. It is always in the
+	// namespace of the Ingress, and the zero value means custom headers are not set
+	ConfigMap types.NamespacedName
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the custom headers configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		set: make(map[string]bool),
+	}
+}
+
+// Enabled returns if custom headers are added to the responses
+func (c *Config) Enabled() bool {
+	return c.ConfigMap.Name != ""
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Parse returns the custom headers configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the custom headers configuration of the Ingress. The
+// ConfigMap may be in the form namespace/name, but must be on the namespace of the
+// Ingress. The invalid annotation is returned as the error
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, CustomHeadersAnnotation.Annotations, config)
+
+	if value, ok := r.Value(customHeadersConfigMapAnnotation); ok {
+		ref, err := parser.ParseObjectReference("configmap", value, ing.Namespace, false)
+		if err != nil {
+			r.Invalid(customHeadersConfigMapAnnotation, value, err)
+		} else {
+			c.ConfigMap = ref
+		}
+	}
+
+	c.set = r.Set()
+	return c, r.Err()
+}
+
+// LoadHeaders reads the headers of a ConfigMap, like the ones of custom-headers and
+// auth-proxy-set-headers, and validates them with ParseHeaders
+func LoadHeaders(getter ConfigMapGetter, ref types.NamespacedName) ([]Header, error) {
+	configMap, err := getter.GetConfigMap(ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configmap %s: %w", ref, err)
+	}
+	return ParseHeaders(configMap.Data)
+}
+
+// ParseHeaders returns the headers of the data of a ConfigMap, sorted by name. Header
+// names must be RFC 9110 tokens and cannot be hop-by-hop headers, and values cannot
+// contain line breaks or other control characters, that would inject headers on the
+// request or response. As ingress-nginx denies the location when any header is not
+// valid, the headers are only returned when all of them are valid, otherwise the
+// invalid ones are returned together as the error
+func ParseHeaders(data map[string]string) ([]Header, error) {
+	headers := make([]Header, 0, len(data))
+	errs := make([]error, 0)
+	for _, name := range slices.Sorted(maps.Keys(data)) {
+		if err := ValidateHeader(name, data[name]); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		headers = append(headers, Header{Name: name, Value: data[name]})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return headers, nil
+}
+
+// ValidateHeader returns an error when the header cannot be safely set on a request or response
+func ValidateHeader(name, value string) error {
+	switch {
+	case !tokenRegex.MatchString(name):
+		return fmt.Errorf("header name %q is not a valid token", name)
+	case slices.Contains(hopByHopHeaders, strings.ToLower(name)):
+		return fmt.Errorf("header %s is a hop-by-hop header and cannot be set", name)
+	case parser.MaliciousRegex.MatchString(value):
+		return fmt.Errorf("value of header %s contains a line break", name)
+	case controlRegex.MatchString(value):
+		return fmt.Errorf("value of header %s contains control characters", name)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customheaders
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "defaults",
+			annotations: map[string]string{},
+			expected:    &Config{},
+		},
+		{
+			name:        "configmap name",
+			annotations: map[string]string{customHeadersConfigMapAnnotation: "headers"},
+			expected:    &Config{ConfigMap: types.NamespacedName{Namespace: "default", Name: "headers"}},
+		},
+		{
+			name:        "configmap with namespace",
+			annotations: map[string]string{customHeadersConfigMapAnnotation: "default/headers"},
+			expected:    &Config{ConfigMap: types.NamespacedName{Namespace: "default", Name: "headers"}},
+		},
+		{
+			name:        "cross namespace configmap",
+			annotations: map[string]string{customHeadersConfigMapAnnotation: "other/headers"},
+			expected:    &Config{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected []Header
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			data:     nil,
+			expected: []Header{},
+		},
+		{
+			name: "sorted by name",
+			data: map[string]string{
+				"X-Frame-Options":   "DENY",
+				"Content-Security":  "default-src 'self'",
+				"x-custom_header.1": "value\twith tab",
+			},
+			expected: []Header{
+				{Name: "Content-Security", Value: "default-src 'self'"},
+				{Name: "X-Frame-Options", Value: "DENY"},
+				{Name: "x-custom_header.1", Value: "value\twith tab"},
+			},
+		},
+		{
+			name:    "name is not a token",
+			data:    map[string]string{"X Header": "a", "X-Valid": "b"},
+			wantErr: true,
+		},
+		{
+			name:    "name with separator",
+			data:    map[string]string{"X-Header:": "a"},
+			wantErr: true,
+		},
+		{
+			name:    "hop-by-hop header",
+			data:    map[string]string{"Transfer-Encoding": "chunked"},
+			wantErr: true,
+		},
+		{
+			name:    "hop-by-hop header is case insensitive",
+			data:    map[string]string{"connection": "close"},
+			wantErr: true,
+		},
+		{
+			name:    "value with CRLF",
+			data:    map[string]string{"X-A": "a\r\nSet-Cookie: session=evil"},
+			wantErr: true,
+		},
+		{
+			name:    "value with control character",
+			data:    map[string]string{"X-A": "a\x00b"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := ParseHeaders(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(headers, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, headers)
+			}
+		})
+	}
+}
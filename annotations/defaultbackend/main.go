@@ -29,10 +29,12 @@ var DefaultBackendAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		defaultBackendAnnotation: {
 			Validator: parser.ValidateServiceName,
+			Format:    parser.FormatServiceName,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This service will be used to handle the response when the configured service in the Ingress rule does not have any active endpoints. 
 			It will also be used to handle the error responses if both this annotation and the custom-http-errors annotation are set.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -26,14 +26,19 @@ const (
 
 var DisableProxyInterceptErrorsAnnotations = parser.Annotation{
 	Group: "backend",
+	Rules: []parser.Rule{
+		parser.Requires(disableProxyInterceptErrorsAnnotation, "custom-http-errors"),
+	},
 	Annotations: parser.AnnotationFields{
 		disableProxyInterceptErrorsAnnotation: {
 			Validator: parser.ValidateBool,
+			Format:    parser.FormatBool,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation allows to disable NGINX proxy-intercept-errors when custom-http-errors are set.
 			If a default backend annotation is specified on the ingress, the errors will be routed to that annotation's default backend service (instead of the global default backend).
 			Different ingresses can specify different sets of errors codes and there are UseCases where NGINX shall not intercept all errors returned from upstream.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -34,19 +34,28 @@ var (
 
 var FastCGIAnnotations = parser.Annotation{
 	Group: "fastcgi",
+	Rules: []parser.Rule{
+		// FastCGI annotations are ignored if the backend does not use the FCGI protocol
+		parser.Requires(fastCGIIndexAnnotation, "backend-protocol").WithValues("FCGI"),
+		parser.Requires(fastCGIParamsAnnotation, "backend-protocol").WithValues("FCGI"),
+	},
 	Annotations: parser.AnnotationFields{
 		fastCGIIndexAnnotation: {
-			Validator:     parser.ValidateRegex(regexValidIndexAnnotationAndKey, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation can be used to specify an index file`,
+			Validator:               parser.ValidateRegex(regexValidIndexAnnotationAndKey, true),
+			Format:                  parser.RegexFormat(regexValidIndexAnnotationAndKey, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation can be used to specify an index file`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		fastCGIParamsAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation can be used to specify a ConfigMap containing the fastcgi parameters as a key/value.
 			Only ConfigMaps on the same namespace of ingress can be used. They key and value from ConfigMap are validated for unauthorized characters.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -28,10 +28,12 @@ var HTTP2PushPreloadAnnotations = parser.Annotation{
 	Group: "http2",
 	Annotations: parser.AnnotationFields{
 		http2PushPreloadAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `Enables automatic conversion of preload links specified in the “Link” response header fields into push requests`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `Enables automatic conversion of preload links specified in the “Link” response header fields into push requests`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
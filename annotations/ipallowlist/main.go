@@ -29,11 +29,13 @@ var AllowlistAnnotations = parser.Annotation{
 	Group: "acl",
 	Annotations: parser.AnnotationFields{
 		ipAllowlistAnnotation: {
-			Validator:         parser.ValidateCIDRs,
-			Scope:             parser.AnnotationScopeLocation,
-			Risk:              parser.AnnotationRiskMedium, // Failure on parsing this may cause undesired access
-			Documentation:     `This annotation allows setting a list of IPs and networks allowed to access this Location`,
-			AnnotationAliases: []string{ipWhitelistAnnotation},
+			Validator:               parser.ValidateCIDRs,
+			Format:                  parser.FormatCIDRs,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium, // Failure on parsing this may cause undesired access
+			Documentation:           `This annotation allows setting a list of IPs and networks allowed to access this Location`,
+			AnnotationAliases:       []string{ipWhitelistAnnotation},
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
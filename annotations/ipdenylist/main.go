@@ -28,10 +28,12 @@ var DenylistAnnotations = parser.Annotation{
 	Group: "acl",
 	Annotations: parser.AnnotationFields{
 		ipDenylistAnnotation: {
-			Validator:     parser.ValidateCIDRs,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium, // Failure on parsing this may cause undesired access
-			Documentation: `This annotation allows setting a list of IPs and networks that should be blocked to access this Location`,
+			Validator:               parser.ValidateCIDRs,
+			Format:                  parser.FormatCIDRs,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium, // Failure on parsing this may cause undesired access
+			Documentation:           `This annotation allows setting a list of IPs and networks that should be blocked to access this Location`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -30,13 +30,20 @@ var loadBalanceAlgorithms = []string{"round_robin", "chash", "chashsubset", "sti
 
 var LoadBalanceAnnotations = parser.Annotation{
 	Group: "backend",
+	Rules: []parser.Rule{
+		// The balancer implementation is chosen by affinity and hash before the load-balance algorithm
+		parser.IgnoredWhen(loadBalanceAlgorithmAnnotation, "affinity").WithValues("cookie"),
+		parser.IgnoredWhen(loadBalanceAlgorithmAnnotation, "upstream-hash-by"),
+	},
 	Annotations: parser.AnnotationFields{
 		loadBalanceAlgorithmAnnotation: {
 			Validator: parser.ValidateOptions(loadBalanceAlgorithms, true, true),
+			Format:    parser.OptionsFormat(loadBalanceAlgorithms, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation allows setting the load balancing algorithm that should be used. If none is specified, defaults to
 			the default configured by Ingress admin, otherwise to round_robin`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -29,16 +29,20 @@ var LogAnnotations = parser.Annotation{
 	Group: "log",
 	Annotations: parser.AnnotationFields{
 		enableAccessLogAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This configuration setting allows you to control if this location should generate an access_log`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This configuration setting allows you to control if this location should generate an access_log`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		enableRewriteLogAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This configuration setting allows you to control if this location should generate logs from the rewrite feature usage`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This configuration setting allows you to control if this location should generate logs from the rewrite feature usage`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
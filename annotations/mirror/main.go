@@ -32,30 +32,40 @@ var OnOffRegex = regexp.MustCompile(`^(on|off)$`)
 
 var MirrorAnnotation = parser.Annotation{
 	Group: "mirror",
+	Rules: []parser.Rule{
+		parser.Requires(mirrorRequestBodyAnnotation, mirrorTargetAnnotation),
+		parser.Requires(mirrorHostAnnotation, mirrorTargetAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		mirrorRequestBodyAnnotation: {
-			Validator:     parser.ValidateRegex(OnOffRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines if the request-body should be sent to the mirror backend. Can be 'on' or 'off'`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestMirror'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestmirrorfilter",
+			Validator:               parser.ValidateRegex(OnOffRegex, true),
+			Format:                  parser.RegexFormat(OnOffRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines if the request-body should be sent to the mirror backend. Can be 'on' or 'off'`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].requestMirror'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestmirrorfilter",
 		},
 		mirrorTargetAnnotation: {
-			Validator:     parser.ValidateServerName,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation enables a request to be mirrored to a mirror backend.`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestMirror'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestmirrorfilter",
+			Validator:               parser.ValidateServerName,
+			Format:                  parser.FormatServerName,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation enables a request to be mirrored to a mirror backend.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestMirror'. MUST mirror to a backend on the cluster.",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestmirrorfilter",
 		},
 		mirrorHostAnnotation: {
-			Validator:     parser.ValidateServerName,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation defines if a specific Host header should be set for mirrored request.`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestMirror'. MUST mirror to a backend on the cluster.",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestmirrorfilter",
+			Validator:               parser.ValidateServerName,
+			Format:                  parser.FormatServerName,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation defines if a specific Host header should be set for mirrored request.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestMirror'. MUST mirror to a backend on the cluster.",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestmirrorfilter",
 		},
 	},
 }
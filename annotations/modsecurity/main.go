@@ -31,28 +31,36 @@ var ModsecurityAnnotation = parser.Annotation{
 	Group: "modsecurity",
 	Annotations: parser.AnnotationFields{
 		modsecEnableAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables ModSecurity`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables ModSecurity`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		modsecEnableOwaspCoreAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables the OWASP Core Rule Set`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables the OWASP Core Rule Set`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		modesecTransactionIDAnnotation: {
-			Validator:     parser.ValidateRegex(parser.NGINXVariable, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskHigh,
-			Documentation: `This annotation enables passing an NGINX variable to ModSecurity.`,
+			Validator:               parser.ValidateRegex(parser.NGINXVariable, true),
+			Format:                  parser.RegexFormat(parser.NGINXVariable, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskHigh,
+			Documentation:           `This annotation enables passing an NGINX variable to ModSecurity.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		modsecSnippetAnnotation: {
-			Validator:     parser.ValidateNull,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskCritical,
-			Documentation: `This annotation enables adding a specific snippet configuration for ModSecurity`,
+			Validator:               parser.ValidateNull,
+			Format:                  parser.FormatNull,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskCritical,
+			Documentation:           `This annotation enables adding a specific snippet configuration for ModSecurity`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
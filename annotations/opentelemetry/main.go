@@ -35,22 +35,28 @@ var OtelAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		enableOpenTelemetryAnnotation: {
 			Validator: parser.ValidateBool,
+			Format:    parser.FormatBool,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation defines if Open Telemetry collector should be enable for this location. OpenTelemetry should 
 			already be configured by Ingress administrator`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		otelTrustSpanAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables or disables using spans from incoming requests as parent for created ones`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables or disables using spans from incoming requests as parent for created ones`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		otelOperationNameAnnotation: {
-			Validator:     parser.ValidateRegex(regexOperationName, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation defines what operation name should be added to the span`,
+			Validator:               parser.ValidateRegex(regexOperationName, true),
+			Format:                  parser.RegexFormat(regexOperationName, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation defines what operation name should be added to the span`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -28,10 +28,12 @@ var PortsInRedirectAnnotations = parser.Annotation{
 	Group: "redirect",
 	Annotations: parser.AnnotationFields{
 		portsInRedirectAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `Enables or disables specifying the port in absolute redirects issued by nginx.`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `Enables or disables specifying the port in absolute redirects issued by nginx.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
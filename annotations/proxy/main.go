@@ -47,126 +47,167 @@ var validUpstreamAnnotation = regexp.MustCompile(`^((error|timeout|invalid_heade
 
 var ProxyAnnotations = parser.Annotation{
 	Group: "backend",
+	Rules: []parser.Rule{
+		// proxy_redirect needs both parameters to be set
+		parser.Requires(proxyRedirectFromAnnotation, proxyRedirectToAnnotation),
+		parser.Requires(proxyRedirectToAnnotation, proxyRedirectFromAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		proxyConnectTimeoutAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation allows setting the timeout in seconds of the connect operation to the backend.`,
-			GatewayAPI:    "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'connect' and general timeout",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation allows setting the timeout in seconds of the connect operation to the backend.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'connect' and general timeout",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
 		},
 		proxySendTimeoutAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation allows setting the timeout in seconds of the send operation to the backend.`,
-			GatewayAPI:    "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'send' and general timeout",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation allows setting the timeout in seconds of the send operation to the backend.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'send' and general timeout",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
 		},
 		proxyReadTimeoutAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation allows setting the timeout in seconds of the read operation to the backend.`,
-			GatewayAPI:    "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'read' and general timeout",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation allows setting the timeout in seconds of the read operation to the backend.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'read' and general timeout",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
 		},
 		proxyBuffersNumberAnnotation: {
 			Validator: parser.ValidateInt,
+			Format:    parser.FormatInt,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation sets the number of the buffers in proxy_buffers used for reading the first part of the response received from the proxied server. 
 			By default proxy buffers number is set as 4`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyBufferSizeAnnotation: {
 			Validator: parser.ValidateRegex(parser.SizeRegex, true),
+			Format:    parser.RegexFormat(parser.SizeRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation sets the size of the buffer proxy_buffer_size used for reading the first part of the response received from the proxied server. 
 			By default proxy buffer size is set as "4k".`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyBusyBuffersSizeAnnotation: {
-			Validator:     parser.ValidateRegex(parser.SizeRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation limits the total size of buffers that can be busy sending a response to the client while the response is not yet fully read.`,
+			Validator:               parser.ValidateRegex(parser.SizeRegex, true),
+			Format:                  parser.RegexFormat(parser.SizeRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation limits the total size of buffers that can be busy sending a response to the client while the response is not yet fully read.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyCookiePathAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLIsValidRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation sets a text that should be changed in the path attribute of the "Set-Cookie" header fields of a proxied server response.`,
+			Validator:               parser.ValidateRegex(parser.URLIsValidRegex, true),
+			Format:                  parser.RegexFormat(parser.URLIsValidRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation sets a text that should be changed in the path attribute of the "Set-Cookie" header fields of a proxied server response.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyCookieDomainAnnotation: {
-			Validator:     parser.ValidateRegex(parser.BasicCharsRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation ets a text that should be changed in the domain attribute of the "Set-Cookie" header fields of a proxied server response.`,
+			Validator:               parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:                  parser.RegexFormat(parser.BasicCharsRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation ets a text that should be changed in the domain attribute of the "Set-Cookie" header fields of a proxied server response.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyBodySizeAnnotation: {
-			Validator:     parser.ValidateRegex(parser.SizeRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation allows setting the maximum allowed size of a client request body.`,
+			Validator:               parser.ValidateRegex(parser.SizeRegex, true),
+			Format:                  parser.RegexFormat(parser.SizeRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation allows setting the maximum allowed size of a client request body.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyNextUpstreamAnnotation: {
 			Validator: parser.ValidateRegex(validUpstreamAnnotation, false),
+			Format:    parser.RegexFormat(validUpstreamAnnotation, false),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation defines when the next upstream should be used. 
 			This annotation reflect the directive https://nginx.org/en/docs/http/ngx_http_proxy_module.html#proxy_next_upstream 
 			and only the allowed values on upstream are allowed here.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyNextUpstreamTimeoutAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation limits the time during which a request can be passed to the next server`,
-			GatewayAPI:    "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'send' and general timeout",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation limits the time during which a request can be passed to the next server`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Supported by 'spec.rules[].timeouts.backendRequest'. There is no distinction between 'send' and general timeout",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutetimeouts",
 		},
 		proxyNextUpstreamTriesAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation limits the number of possible tries for passing a request to the next server`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation limits the number of possible tries for passing a request to the next server`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyRequestBufferingAnnotation: {
-			Validator:     parser.ValidateOptions([]string{"on", "off"}, true, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables or disables buffering of a client request body.`,
+			Validator:               parser.ValidateOptions([]string{"on", "off"}, true, true),
+			Format:                  parser.OptionsFormat([]string{"on", "off"}, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables or disables buffering of a client request body.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyRedirectFromAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLIsValidRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `The annotations proxy-redirect-from and proxy-redirect-to will set the first and second parameters of NGINX's proxy_redirect directive respectively`,
+			Validator:               parser.ValidateRegex(parser.URLIsValidRegex, true),
+			Format:                  parser.RegexFormat(parser.URLIsValidRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `The annotations proxy-redirect-from and proxy-redirect-to will set the first and second parameters of NGINX's proxy_redirect directive respectively`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyRedirectToAnnotation: {
-			Validator:     parser.ValidateRegex(parser.URLIsValidRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `The annotations proxy-redirect-from and proxy-redirect-to will set the first and second parameters of NGINX's proxy_redirect directive respectively`,
+			Validator:               parser.ValidateRegex(parser.URLIsValidRegex, true),
+			Format:                  parser.RegexFormat(parser.URLIsValidRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `The annotations proxy-redirect-from and proxy-redirect-to will set the first and second parameters of NGINX's proxy_redirect directive respectively`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyBufferingAnnotation: {
-			Validator:     parser.ValidateOptions([]string{"on", "off"}, true, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables or disables buffering of responses from the proxied server. It can be "on" or "off"`,
+			Validator:               parser.ValidateOptions([]string{"on", "off"}, true, true),
+			Format:                  parser.OptionsFormat([]string{"on", "off"}, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables or disables buffering of responses from the proxied server. It can be "on" or "off"`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyHTTPVersionAnnotation: {
-			Validator:     parser.ValidateOptions([]string{"1.0", "1.1"}, true, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotations sets the HTTP protocol version for proxying. Can be "1.0" or "1.1".`,
+			Validator:               parser.ValidateOptions([]string{"1.0", "1.1"}, true, true),
+			Format:                  parser.OptionsFormat([]string{"1.0", "1.1"}, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotations sets the HTTP protocol version for proxying. Can be "1.0" or "1.1".`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxyMaxTempFileSizeAnnotation: {
-			Validator:     parser.ValidateRegex(parser.SizeRegex, true),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines the maximum size of a temporary file when buffering responses.`,
+			Validator:               parser.ValidateRegex(parser.SizeRegex, true),
+			Format:                  parser.RegexFormat(parser.SizeRegex, true),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines the maximum size of a temporary file when buffering responses.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
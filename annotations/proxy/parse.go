@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+// NextUpstream is a condition that makes NGINX pass the request to the next upstream server
+type NextUpstream string
+
+const (
+	NextUpstreamError         NextUpstream = "error"
+	NextUpstreamTimeout       NextUpstream = "timeout"
+	NextUpstreamInvalidHeader NextUpstream = "invalid_header"
+	NextUpstreamHTTP500       NextUpstream = "http_500"
+	NextUpstreamHTTP502       NextUpstream = "http_502"
+	NextUpstreamHTTP503       NextUpstream = "http_503"
+	NextUpstreamHTTP504       NextUpstream = "http_504"
+	NextUpstreamHTTP403       NextUpstream = "http_403"
+	NextUpstreamHTTP404       NextUpstream = "http_404"
+	NextUpstreamHTTP429       NextUpstream = "http_429"
+	NextUpstreamNonIdempotent NextUpstream = "non_idempotent"
+)
+
+var nextUpstreamConditions = []NextUpstream{
+	NextUpstreamError, NextUpstreamTimeout, NextUpstreamInvalidHeader,
+	NextUpstreamHTTP500, NextUpstreamHTTP502, NextUpstreamHTTP503, NextUpstreamHTTP504,
+	NextUpstreamHTTP403, NextUpstreamHTTP404, NextUpstreamHTTP429, NextUpstreamNonIdempotent,
+}
+
+// NextUpstreamConditions is the sorted set of conditions of proxy-next-upstream.
+// An empty set means the request is never passed to the next upstream ("off")
+type NextUpstreamConditions []NextUpstream
+
+// Has returns if the condition is part of the set
+func (n NextUpstreamConditions) Has(condition NextUpstream) bool {
+	return slices.Contains(n, condition)
+}
+
+// Redirect contains the parameters of the proxy_redirect directive
+type Redirect struct {
+	From string
+	To   string
+}
+
+// Enabled returns if the Location and Refresh headers of the responses are rewritten
+func (r Redirect) Enabled() bool {
+	return r.From != "" && r.From != "off" && r.To != "" && r.To != "off"
+}
+
+// Config is the typed configuration of the proxy annotations. Sizes are in bytes.
+// BusyBuffersSize is 0 when NGINX calculates it from the buffer sizes, a BodySize of 0
+// disables the check of the request body and a NextUpstreamTimeout of 0 means no limit
+type Config struct {
+	ConnectTimeout  time.Duration
+	SendTimeout     time.Duration
+	ReadTimeout     time.Duration
+	BuffersNumber   int
+	BufferSize      int64
+	BusyBuffersSize int64
+	CookieDomain    string
+	CookiePath      string
+	BodySize        int64
+
+	NextUpstream        NextUpstreamConditions
+	NextUpstreamTimeout time.Duration
+	NextUpstreamTries   int
+
+	RequestBuffering bool
+	Buffering        bool
+	HTTPVersion      string
+	MaxTempFileSize  int64
+	Redirect         Redirect
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the proxy configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		ConnectTimeout:    5 * time.Second,
+		SendTimeout:       60 * time.Second,
+		ReadTimeout:       60 * time.Second,
+		BuffersNumber:     4,
+		BufferSize:        4 << 10,
+		CookieDomain:      "off",
+		CookiePath:        "off",
+		BodySize:          1 << 20,
+		NextUpstream:      NextUpstreamConditions{NextUpstreamError, NextUpstreamTimeout},
+		NextUpstreamTries: 3,
+		RequestBuffering:  true,
+		Buffering:         false,
+		HTTPVersion:       "1.1",
+		MaxTempFileSize:   1 << 30,
+		Redirect:          Redirect{From: "off", To: "off"},
+		set:               make(map[string]bool),
+	}
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Parse returns the proxy configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the proxy configuration of the Ingress. Annotations that are
+// not set keep the ingress-nginx defaults. Invalid annotations also keep the defaults,
+// and are returned together as the error, so the configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, ProxyAnnotations.Annotations, config)
+
+	c.ConnectTimeout = r.Seconds(proxyConnectTimeoutAnnotation, c.ConnectTimeout)
+	c.SendTimeout = r.Seconds(proxySendTimeoutAnnotation, c.SendTimeout)
+	c.ReadTimeout = r.Seconds(proxyReadTimeoutAnnotation, c.ReadTimeout)
+	c.BuffersNumber = r.Int(proxyBuffersNumberAnnotation, c.BuffersNumber)
+	c.BufferSize = r.Size(proxyBufferSizeAnnotation, c.BufferSize)
+	c.BusyBuffersSize = r.Size(proxyBusyBuffersSizeAnnotation, c.BusyBuffersSize)
+	c.CookieDomain = r.String(proxyCookieDomainAnnotation, c.CookieDomain)
+	c.CookiePath = r.String(proxyCookiePathAnnotation, c.CookiePath)
+	c.BodySize = r.Size(proxyBodySizeAnnotation, c.BodySize)
+	c.NextUpstream = parseNextUpstream(r, c.NextUpstream)
+	c.NextUpstreamTimeout = r.Seconds(proxyNextUpstreamTimeoutAnnotation, c.NextUpstreamTimeout)
+	c.NextUpstreamTries = r.Int(proxyNextUpstreamTriesAnnotation, c.NextUpstreamTries)
+	c.RequestBuffering = r.OnOff(proxyRequestBufferingAnnotation, c.RequestBuffering)
+	c.Buffering = r.OnOff(proxyBufferingAnnotation, c.Buffering)
+	c.HTTPVersion = r.String(proxyHTTPVersionAnnotation, c.HTTPVersion)
+	c.MaxTempFileSize = r.Size(proxyMaxTempFileSizeAnnotation, c.MaxTempFileSize)
+	c.Redirect.From = r.String(proxyRedirectFromAnnotation, c.Redirect.From)
+	c.Redirect.To = r.String(proxyRedirectToAnnotation, c.Redirect.To)
+
+	c.set = r.Set()
+	return c, r.Err()
+}
+
+// parseNextUpstream returns the sorted conditions of proxy-next-upstream, without duplicates
+func parseNextUpstream(r *parser.AnnotationReader, def NextUpstreamConditions) NextUpstreamConditions {
+	value, ok := r.Value(proxyNextUpstreamAnnotation)
+	if !ok {
+		return def
+	}
+	conditions := make(NextUpstreamConditions, 0)
+	for _, field := range strings.Fields(value) {
+		if field == "off" {
+			// off disables all the other conditions
+			return NextUpstreamConditions{}
+		}
+		condition := NextUpstream(field)
+		if !slices.Contains(nextUpstreamConditions, condition) {
+			r.Invalid(proxyNextUpstreamAnnotation, value, fmt.Errorf("%s is not a valid condition", field))
+			return def
+		}
+		if !conditions.Has(condition) {
+			conditions = append(conditions, condition)
+		}
+	}
+	slices.Sort(conditions)
+	return conditions
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+)
+
+func TestParseDefaults(t *testing.T) {
+	config, err := Parse(ingresstest.New(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defaults := NewDefaultConfig()
+	if !reflect.DeepEqual(config, defaults) {
+		t.Errorf("expected defaults %+v, got %+v", defaults, config)
+	}
+	if config.IsSet(proxyReadTimeoutAnnotation) {
+		t.Errorf("expected read timeout to not be set")
+	}
+	if config.Redirect.Enabled() {
+		t.Errorf("expected proxy redirect to be disabled by default")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		check       func(t *testing.T, c *Config)
+		wantErr     bool
+	}{
+		{
+			name: "timeouts are converted to durations",
+			annotations: map[string]string{
+				proxyConnectTimeoutAnnotation:      "10",
+				proxyReadTimeoutAnnotation:         "120",
+				proxyNextUpstreamTimeoutAnnotation: "0",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.ConnectTimeout != 10*time.Second || c.ReadTimeout != 2*time.Minute || c.SendTimeout != time.Minute {
+					t.Errorf("unexpected timeouts %s %s %s", c.ConnectTimeout, c.ReadTimeout, c.SendTimeout)
+				}
+				if !c.IsSet(proxyNextUpstreamTimeoutAnnotation) || c.IsSet(proxySendTimeoutAnnotation) {
+					t.Errorf("unexpected set markers")
+				}
+			},
+		},
+		{
+			name: "sizes are converted to bytes",
+			annotations: map[string]string{
+				proxyBufferSizeAnnotation:      "8k",
+				proxyBodySizeAnnotation:        "10m",
+				proxyMaxTempFileSizeAnnotation: "0",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.BufferSize != 8192 || c.BodySize != 10<<20 || c.MaxTempFileSize != 0 {
+					t.Errorf("unexpected sizes %d %d %d", c.BufferSize, c.BodySize, c.MaxTempFileSize)
+				}
+			},
+		},
+		{
+			name:        "next upstream conditions are sorted without duplicates",
+			annotations: map[string]string{proxyNextUpstreamAnnotation: "timeout error http_502 error"},
+			check: func(t *testing.T, c *Config) {
+				expected := NextUpstreamConditions{NextUpstreamError, NextUpstreamHTTP502, NextUpstreamTimeout}
+				if !reflect.DeepEqual(c.NextUpstream, expected) {
+					t.Errorf("expected %v, got %v", expected, c.NextUpstream)
+				}
+			},
+		},
+		{
+			name:        "next upstream off disables all conditions",
+			annotations: map[string]string{proxyNextUpstreamAnnotation: "off"},
+			check: func(t *testing.T, c *Config) {
+				if len(c.NextUpstream) != 0 || c.NextUpstream.Has(NextUpstreamError) {
+					t.Errorf("expected no conditions, got %v", c.NextUpstream)
+				}
+			},
+		},
+		{
+			name: "buffering and redirect",
+			annotations: map[string]string{
+				proxyBufferingAnnotation:        "on",
+				proxyRequestBufferingAnnotation: "off",
+				proxyRedirectFromAnnotation:     "http://internal/",
+				proxyRedirectToAnnotation:       "https://example.com/",
+			},
+			check: func(t *testing.T, c *Config) {
+				if !c.Buffering || c.RequestBuffering {
+					t.Errorf("unexpected buffering %t %t", c.Buffering, c.RequestBuffering)
+				}
+				if !c.Redirect.Enabled() || c.Redirect.To != "https://example.com/" {
+					t.Errorf("unexpected redirect %+v", c.Redirect)
+				}
+			},
+		},
+		{
+			name: "invalid values keep the defaults",
+			annotations: map[string]string{
+				proxyConnectTimeoutAnnotation: "-1",
+				proxyBufferSizeAnnotation:     "8x",
+				proxyHTTPVersionAnnotation:    "2.0",
+				proxyBuffersNumberAnnotation:  "8",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.ConnectTimeout != 5*time.Second || c.BufferSize != 4096 || c.HTTPVersion != "1.1" {
+					t.Errorf("expected defaults, got %s %d %s", c.ConnectTimeout, c.BufferSize, c.HTTPVersion)
+				}
+				if c.IsSet(proxyConnectTimeoutAnnotation) || c.IsSet(proxyBufferSizeAnnotation) {
+					t.Errorf("invalid annotations should not be set")
+				}
+				if c.BuffersNumber != 8 || !c.IsSet(proxyBuffersNumberAnnotation) {
+					t.Errorf("expected valid annotations to be parsed, got %d", c.BuffersNumber)
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			tt.check(t, config)
+		})
+	}
+}
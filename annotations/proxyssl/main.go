@@ -40,59 +40,84 @@ const (
 
 var ProxySSLAnnotation = parser.Annotation{
 	Group: "proxy",
+	Rules: []parser.Rule{
+		// All the proxy ssl annotations are ignored without the secret
+		parser.Requires(proxySSLCiphersAnnotation, proxySSLSecretAnnotation),
+		parser.Requires(proxySSLProtocolsAnnotation, proxySSLSecretAnnotation),
+		parser.Requires(proxySSLNameAnnotation, proxySSLSecretAnnotation),
+		parser.Requires(proxySSLVerifyAnnotation, proxySSLSecretAnnotation),
+		parser.Requires(proxySSLVerifyDepthAnnotation, proxySSLSecretAnnotation),
+		parser.Requires(proxySSLServerNameAnnotation, proxySSLSecretAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		proxySSLSecretAnnotation: {
 			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:    parser.RegexFormat(parser.BasicCharsRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation specifies a Secret with the certificate tls.crt, key tls.key in PEM format used for authentication to a proxied HTTPS server. 
 			It should also contain trusted CA certificates ca.crt in PEM format used to verify the certificate of the proxied HTTPS server. 
 			This annotation expects the Secret name in the form "namespace/secretName"
 			Just secrets on the same namespace of the ingress can be used.`,
-			GatewayAPI:    "Partially supported by the BackendTLSPolicy '.spec.validations.caCertificateRefs'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by the BackendTLSPolicy '.spec.validations.caCertificateRefs'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
 		},
 		proxySSLCiphersAnnotation: {
 			Validator: parser.ValidateRegex(proxySSLCiphersRegex, true),
+			Format:    parser.RegexFormat(proxySSLCiphersRegex, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation Specifies the enabled ciphers for requests to a proxied HTTPS server. 
 			The ciphers are specified in the format understood by the OpenSSL library.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxySSLProtocolsAnnotation: {
-			Validator:     parser.ValidateRegex(proxySSLProtocolRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables the specified protocols for requests to a proxied HTTPS server.`,
+			Validator:               parser.ValidateRegex(proxySSLProtocolRegex, true),
+			Format:                  parser.RegexFormat(proxySSLProtocolRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables the specified protocols for requests to a proxied HTTPS server.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxySSLNameAnnotation: {
 			Validator: parser.ValidateServerName,
+			Format:    parser.FormatServerName,
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskHigh,
 			Documentation: `This annotation allows to set proxy_ssl_name. This allows overriding the server name used to verify the certificate of the proxied HTTPS server. 
 			This value is also passed through SNI when a connection is established to the proxied HTTPS server.`,
-			GatewayAPI:    "Supported by the BackendTLSPolicy '.spec.validations.hostname'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by the BackendTLSPolicy '.spec.validations.hostname'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
 		},
 		proxySSLVerifyAnnotation: {
-			Validator:     parser.ValidateRegex(proxySSLOnOffRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables or disables verification of the proxied HTTPS server certificate. (default: off)`,
+			Validator:               parser.ValidateRegex(proxySSLOnOffRegex, true),
+			Format:                  parser.RegexFormat(proxySSLOnOffRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables or disables verification of the proxied HTTPS server certificate. (default: off)`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by the BackendTLSPolicy, that always verifies the certificate of the backend. Only on can be converted",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
 		},
 		proxySSLVerifyDepthAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation Sets the verification depth in the proxied HTTPS server certificates chain. (default: 1).`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation Sets the verification depth in the proxied HTTPS server certificates chain. (default: 1).`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		proxySSLServerNameAnnotation: {
-			Validator:     parser.ValidateRegex(proxySSLOnOffRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables passing of the server name through TLS Server Name Indication extension (SNI, RFC 6066) when establishing a connection with the proxied HTTPS server.`,
-			GatewayAPI:    "Supported by the BackendTLSPolicy '.spec.validations.hostname'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
+			Validator:               parser.ValidateRegex(proxySSLOnOffRegex, true),
+			Format:                  parser.RegexFormat(proxySSLOnOffRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables passing of the server name through TLS Server Name Indication extension (SNI, RFC 6066) when establishing a connection with the proxied HTTPS server.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by the BackendTLSPolicy '.spec.validations.hostname', that is always sent as SNI. SNI cannot be disabled",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#backendtlspolicyvalidation",
 		},
 	},
 }
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyssl
+
+import (
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultCiphers     = "DEFAULT"
+	defaultProtocols   = "TLSv1 TLSv1.1 TLSv1.2"
+	defaultVerifyDepth = 1
+)
+
+// Config is the typed configuration of the TLS connections to the backends
+type Config struct {
+	// Secret contains the client certificate, tls.crt and tls.key, and the trusted CA
+	// certificates, ca.crt. It is always in the namespace of the Ingress, and the zero
+	// value means TLS to the backends is not configured
+	Secret types.NamespacedName
+	// Ciphers are the enabled ciphers, in the OpenSSL format
+	Ciphers   string
+	Protocols []string
+	// Name is the name used to verify the certificate of the backend, and sent as SNI.
+	// When empty, nginx uses the host of proxy_pass, that is the name of the upstream
+	Name string
+	// Verify enables the verification of the certificate of the backend
+	Verify      bool
+	VerifyDepth int
+	// ServerName enables SNI
+	ServerName bool
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the backend TLS configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		Ciphers:     defaultCiphers,
+		Protocols:   strings.Fields(defaultProtocols),
+		VerifyDepth: defaultVerifyDepth,
+		set:         make(map[string]bool),
+	}
+}
+
+// Enabled returns if the secret is set. ingress-nginx ignores all the other annotations without it
+func (c *Config) Enabled() bool {
+	return c.Secret.Name != ""
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Parse returns the backend TLS configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the backend TLS configuration of the Ingress. The secret must
+// be in the form namespace/name, on the namespace of the Ingress. Annotations that are
+// not set, or are invalid, keep the ingress-nginx defaults. The invalid annotations
+// are returned together as the error, so the configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, ProxySSLAnnotation.Annotations, config)
+
+	if value, ok := r.Value(proxySSLSecretAnnotation); ok {
+		secret, err := parser.ParseSecretReference(value, ing.Namespace, true)
+		if err != nil {
+			r.Invalid(proxySSLSecretAnnotation, value, err)
+		} else {
+			c.Secret = secret
+		}
+	}
+	c.Ciphers = r.String(proxySSLCiphersAnnotation, c.Ciphers)
+	if value, ok := r.Value(proxySSLProtocolsAnnotation); ok && len(strings.Fields(value)) > 0 {
+		c.Protocols = strings.Fields(value)
+	}
+	c.Name = r.String(proxySSLNameAnnotation, c.Name)
+	c.Verify = r.OnOff(proxySSLVerifyAnnotation, c.Verify)
+	c.VerifyDepth = r.Int(proxySSLVerifyDepthAnnotation, c.VerifyDepth)
+	c.ServerName = r.OnOff(proxySSLServerNameAnnotation, c.ServerName)
+
+	c.set = r.Set()
+	return c, r.Err()
+}
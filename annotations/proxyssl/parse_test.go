@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyssl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "defaults",
+			annotations: map[string]string{},
+			expected: &Config{
+				Ciphers:     "DEFAULT",
+				Protocols:   []string{"TLSv1", "TLSv1.1", "TLSv1.2"},
+				VerifyDepth: 1,
+			},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				proxySSLSecretAnnotation:      "default/backend-tls",
+				proxySSLCiphersAnnotation:     "HIGH:!aNULL",
+				proxySSLProtocolsAnnotation:   "TLSv1.2 TLSv1.3",
+				proxySSLNameAnnotation:        "backend.example.com",
+				proxySSLVerifyAnnotation:      "on",
+				proxySSLVerifyDepthAnnotation: "3",
+				proxySSLServerNameAnnotation:  "on",
+			},
+			expected: &Config{
+				Secret:      types.NamespacedName{Namespace: "default", Name: "backend-tls"},
+				Ciphers:     "HIGH:!aNULL",
+				Protocols:   []string{"TLSv1.2", "TLSv1.3"},
+				Name:        "backend.example.com",
+				Verify:      true,
+				VerifyDepth: 3,
+				ServerName:  true,
+			},
+		},
+		{
+			name: "cross namespace secret",
+			annotations: map[string]string{
+				proxySSLSecretAnnotation: "other/backend-tls",
+			},
+			expected: &Config{
+				Ciphers:     "DEFAULT",
+				Protocols:   []string{"TLSv1", "TLSv1.1", "TLSv1.2"},
+				VerifyDepth: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "secret without namespace",
+			annotations: map[string]string{
+				proxySSLSecretAnnotation: "backend-tls",
+			},
+			expected: &Config{
+				Ciphers:     "DEFAULT",
+				Protocols:   []string{"TLSv1", "TLSv1.1", "TLSv1.2"},
+				VerifyDepth: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
@@ -33,51 +33,70 @@ const (
 
 var RateLimitAnnotations = parser.Annotation{
 	Group: "rate-limit",
+	Rules: []parser.Rule{
+		parser.Requires(limitRateAfterAnnotation, limitRateAnnotation),
+		parser.Requires(limitRateBurstMultiplierAnnotation, limitRateRPSAnnotation, limitRateRPMAnnotation),
+		parser.Requires(limitAllowlistAnnotation, limitRateRPSAnnotation, limitRateRPMAnnotation, limitRateConnectionsAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		limitRateAnnotation: {
 			Validator: parser.ValidateInt,
+			Format:    parser.FormatInt,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
 			Documentation: `Limits the rate of response transmission to a client. The rate is specified in bytes per second. 
 			The zero value disables rate limiting. The limit is set per a request, and so if a client simultaneously opens two connections, the overall rate will be twice as much as the specified limit.
 			References: https://nginx.org/en/docs/http/ngx_http_core_module.html#limit_rate`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		limitRateAfterAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `Sets the initial amount after which the further transmission of a response to a client will be rate limited.`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `Sets the initial amount after which the further transmission of a response to a client will be rate limited.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		limitRateRPMAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `Requests per minute that will be allowed.`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `Requests per minute that will be allowed.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		limitRateRPSAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `Requests per second that will be allowed.`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `Requests per second that will be allowed.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		limitRateConnectionsAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `Number of connections that will be allowed`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `Number of connections that will be allowed`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		limitRateBurstMultiplierAnnotation: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `Burst multiplier for a limit-rate enabled location.`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `Burst multiplier for a limit-rate enabled location.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		limitAllowlistAnnotation: {
-			Validator:         parser.ValidateCIDRs,
-			Scope:             parser.AnnotationScopeLocation,
-			Risk:              parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation:     `List of CIDR/IP addresses that will not be rate-limited.`,
-			AnnotationAliases: []string{limitWhitelistAnnotation},
+			Validator:               parser.ValidateCIDRs,
+			Format:                  parser.FormatCIDRs,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `List of CIDR/IP addresses that will not be rate-limited.`,
+			AnnotationAliases:       []string{limitWhitelistAnnotation},
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/net"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+const (
+	// defaultBurstMultiplier is the burst multiplier used when limit-burst-multiplier is not set
+	defaultBurstMultiplier = 5
+	// defaultSharedSize is the size, in megabytes, of the shared memory zones
+	defaultSharedSize = 5
+)
+
+// Zone is a limit_req or limit_conn shared memory zone. ingress-nginx creates one zone
+// per Ingress and limit, keyed by the client address, so each client has its own limit
+// on each controller replica
+type Zone struct {
+	Name string `json:"name"`
+	// Limit is the number of requests per second or minute, or the number of connections
+	Limit int `json:"limit"`
+	// Burst is the number of requests above the limit accepted without delay
+	Burst int `json:"burst"`
+	// SharedSize is the size of the zone in megabytes
+	SharedSize int `json:"sharedSize"`
+}
+
+// Enabled returns if the zone limits the clients
+func (z Zone) Enabled() bool {
+	return z.Limit > 0
+}
+
+// Limits are the effective limits of a single client address on a single controller
+// replica. A zero value means the client is not limited
+type Limits struct {
+	// RequestsPerSecond is the sustained rate, the lowest of limit-rps and limit-rpm
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	// RequestsPerMinute is the sustained rate, the lowest of limit-rps and limit-rpm
+	RequestsPerMinute float64 `json:"requestsPerMinute,omitempty"`
+	// Burst is the number of requests above the rate accepted at once, the lowest of the zones
+	Burst int `json:"burst,omitempty"`
+	// Connections is the number of concurrent connections
+	Connections int `json:"connections,omitempty"`
+}
+
+// String returns the limits in the units used by nginx
+func (l Limits) String() string {
+	parts := make([]string, 0)
+	if l.RequestsPerSecond > 0 {
+		parts = append(parts, fmt.Sprintf("%g r/s (%g r/m) with a burst of %d requests", l.RequestsPerSecond, l.RequestsPerMinute, l.Burst))
+	}
+	if l.Connections > 0 {
+		parts = append(parts, fmt.Sprintf("%d connections", l.Connections))
+	}
+	if len(parts) == 0 {
+		return "unlimited"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Config is the typed configuration of the rate limit annotations
+type Config struct {
+	Connections     Zone
+	RPS             Zone
+	RPM             Zone
+	BurstMultiplier int
+	// Allowlist contains the sorted CIDRs and addresses that are not limited
+	Allowlist []string
+	// LimitRate is the response rate of each request in kilobytes per second, as
+	// ingress-nginx configures limit_rate with the k suffix
+	LimitRate int
+	// LimitRateAfter is the number of kilobytes sent before the response rate is limited
+	LimitRateAfter int
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the rate limit configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		BurstMultiplier: defaultBurstMultiplier,
+		set:             make(map[string]bool),
+	}
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Enabled returns if requests or connections of the clients are limited
+func (c *Config) Enabled() bool {
+	return c.RPS.Enabled() || c.RPM.Enabled() || c.Connections.Enabled()
+}
+
+// Limits returns the effective limits of a client. Both zones are applied when
+// limit-rps and limit-rpm are set, so the lowest rate and burst are effective
+func (c *Config) Limits() Limits {
+	limits := Limits{}
+	if c.Connections.Enabled() {
+		limits.Connections = c.Connections.Limit
+	}
+	for _, zone := range []struct {
+		zone    Zone
+		perUnit float64
+	}{
+		{zone: c.RPS, perUnit: 1},
+		{zone: c.RPM, perUnit: 60},
+	} {
+		if !zone.zone.Enabled() {
+			continue
+		}
+		rps := float64(zone.zone.Limit) / zone.perUnit
+		if limits.RequestsPerSecond == 0 || rps < limits.RequestsPerSecond {
+			limits.RequestsPerSecond = rps
+			limits.RequestsPerMinute = rps * 60
+		}
+		if limits.Burst == 0 || zone.zone.Burst < limits.Burst {
+			limits.Burst = zone.zone.Burst
+		}
+	}
+	return limits
+}
+
+// Exempt returns if the client address is in the allowlist, and is not limited
+func (c *Config) Exempt(address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, allowed := range c.Allowlist {
+		if prefix, err := netip.ParsePrefix(allowed); err == nil {
+			if prefix.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if other, err := netip.ParseAddr(allowed); err == nil && other.Unmap() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the rate limit configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the rate limit configuration of the Ingress, computed as
+// ingress-nginx does: each limit uses its own zone, named after the namespace, name
+// and UID of the Ingress, and the burst of the zone is the limit multiplied by
+// limit-burst-multiplier. Annotations that are not set, or are invalid, keep the
+// ingress-nginx defaults. The invalid annotations are returned together as the
+// error, so the configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, RateLimitAnnotations.Annotations, config)
+
+	rps := r.Int(limitRateRPSAnnotation, 0)
+	rpm := r.Int(limitRateRPMAnnotation, 0)
+	connections := r.Int(limitRateConnectionsAnnotation, 0)
+	if multiplier := r.Int(limitRateBurstMultiplierAnnotation, c.BurstMultiplier); multiplier >= 1 {
+		c.BurstMultiplier = multiplier
+	} else {
+		r.Invalid(limitRateBurstMultiplierAnnotation, fmt.Sprint(multiplier), fmt.Errorf("burst multiplier must be at least 1"))
+	}
+	if value, ok := r.Value(limitAllowlistAnnotation); ok {
+		cidrs, err := net.ParseCIDRs(value)
+		if err != nil {
+			r.Invalid(limitAllowlistAnnotation, value, err)
+		} else {
+			c.Allowlist = cidrs
+		}
+	}
+	c.LimitRate = r.Int(limitRateAnnotation, c.LimitRate)
+	c.LimitRateAfter = r.Int(limitRateAfterAnnotation, c.LimitRateAfter)
+
+	if rps != 0 || rpm != 0 || connections != 0 {
+		zoneName := fmt.Sprintf("%v_%v_%v", ing.GetNamespace(), ing.GetName(), ing.GetUID())
+		c.Connections = Zone{Name: zoneName + "_conn", Limit: connections, Burst: connections * c.BurstMultiplier, SharedSize: defaultSharedSize}
+		c.RPS = Zone{Name: zoneName + "_rps", Limit: rps, Burst: rps * c.BurstMultiplier, SharedSize: defaultSharedSize}
+		c.RPM = Zone{Name: zoneName + "_rpm", Limit: rpm, Burst: rpm * c.BurstMultiplier, SharedSize: defaultSharedSize}
+	}
+
+	c.set = r.Set()
+	return c, r.Err()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		limits      Limits
+		wantErr     bool
+	}{
+		{
+			name:        "disabled",
+			annotations: map[string]string{limitRateAnnotation: "100"},
+			expected:    &Config{BurstMultiplier: 5, LimitRate: 100},
+		},
+		{
+			name: "zones use the default burst multiplier",
+			annotations: map[string]string{
+				limitRateRPSAnnotation:         "10",
+				limitRateConnectionsAnnotation: "3",
+			},
+			expected: &Config{
+				Connections:     Zone{Name: "default_foo_uid_conn", Limit: 3, Burst: 15, SharedSize: 5},
+				RPS:             Zone{Name: "default_foo_uid_rps", Limit: 10, Burst: 50, SharedSize: 5},
+				RPM:             Zone{Name: "default_foo_uid_rpm", SharedSize: 5},
+				BurstMultiplier: 5,
+			},
+			limits: Limits{RequestsPerSecond: 10, RequestsPerMinute: 600, Burst: 50, Connections: 3},
+		},
+		{
+			name: "lowest rate is effective",
+			annotations: map[string]string{
+				limitRateRPSAnnotation:             "10",
+				limitRateRPMAnnotation:             "300",
+				limitRateBurstMultiplierAnnotation: "2",
+				limitWhitelistAnnotation:           "10.0.0.0/8, 192.168.1.1",
+			},
+			expected: &Config{
+				Connections:     Zone{Name: "default_foo_uid_conn", SharedSize: 5},
+				RPS:             Zone{Name: "default_foo_uid_rps", Limit: 10, Burst: 20, SharedSize: 5},
+				RPM:             Zone{Name: "default_foo_uid_rpm", Limit: 300, Burst: 600, SharedSize: 5},
+				BurstMultiplier: 2,
+				Allowlist:       []string{"10.0.0.0/8", "192.168.1.1"},
+			},
+			limits: Limits{RequestsPerSecond: 5, RequestsPerMinute: 300, Burst: 20},
+		},
+		{
+			name: "invalid burst multiplier uses the default",
+			annotations: map[string]string{
+				limitRateRPMAnnotation:             "60",
+				limitRateBurstMultiplierAnnotation: "0",
+			},
+			expected: &Config{
+				Connections:     Zone{Name: "default_foo_uid_conn", SharedSize: 5},
+				RPS:             Zone{Name: "default_foo_uid_rps", SharedSize: 5},
+				RPM:             Zone{Name: "default_foo_uid_rpm", Limit: 60, Burst: 300, SharedSize: 5},
+				BurstMultiplier: 5,
+			},
+			limits:  Limits{RequestsPerSecond: 1, RequestsPerMinute: 60, Burst: 300},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := ingresstest.New(tt.annotations)
+			ing.UID = "uid"
+			config, err := Parse(ing)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+			if limits := config.Limits(); limits != tt.limits {
+				t.Errorf("expected limits %+v, got %+v", tt.limits, limits)
+			}
+		})
+	}
+}
+
+func TestExempt(t *testing.T) {
+	config := &Config{Allowlist: []string{"10.0.0.0/8", "192.168.1.1", "2001:db8::/32"}}
+	for address, expected := range map[string]bool{
+		"10.1.2.3":           true,
+		"192.168.1.1":        true,
+		"::ffff:192.168.1.1": true,
+		"192.168.1.2":        false,
+		"2001:db8::1":        true,
+		"2001:db9::1":        false,
+		"invalid":            false,
+	} {
+		if exempt := config.Exempt(address); exempt != expected {
+			t.Errorf("expected %s exempt %t, got %t", address, expected, exempt)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redirect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/portinredirect"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+const (
+	// portsInRedirectAnnotation is declared by the portinredirect feature
+	portsInRedirectAnnotation = "use-port-in-redirects"
+
+	// DefaultFromToWWWCode is the status code of from-to-www-redirect, the default of
+	// the http-redirect-code setting of the ingress-nginx ConfigMap
+	DefaultFromToWWWCode = http.StatusPermanentRedirect
+)
+
+// Target is the URL a location is redirected to
+type Target struct {
+	Scheme string
+	Host   string
+	// Port is 0 when the URL does not contain a port
+	Port     int
+	Path     string
+	RawQuery string
+	Fragment string
+}
+
+// Config is the typed configuration of the redirect annotations
+type Config struct {
+	// URL is the target of the redirect, empty when the location is not redirected.
+	// nginx returns it as the Location header as is, without the path of the request
+	URL    string
+	Target *Target
+	// Code is the status code of the redirect
+	Code int
+	// Permanent is true when the redirect is configured by permanent-redirect
+	Permanent bool
+	// FromToWWW redirects the requests of www.host to host, or of host to www.host
+	// when the host of the Ingress starts with www
+	FromToWWW bool
+	// Relative disables absolute_redirect, so the redirects issued by nginx are relative
+	Relative bool
+	// UsePortInRedirects enables port_in_redirect on the redirects issued by nginx
+	UsePortInRedirects bool
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the redirect configuration used by ingress-nginx when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{set: make(map[string]bool)}
+}
+
+// Enabled returns if the locations are redirected to the URL
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// FromToWWWHost returns the host redirected to the Ingress host by from-to-www-redirect.
+// It returns false for empty and wildcard hosts, that ingress-nginx does not redirect
+func FromToWWWHost(host string) (string, bool) {
+	if host == "" || strings.Contains(host, "*") {
+		return "", false
+	}
+	if from, ok := strings.CutPrefix(host, "www."); ok {
+		return from, from != ""
+	}
+	return "www." + host, true
+}
+
+// Parse returns the redirect configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the redirect configuration of the Ingress. As on ingress-nginx,
+// temporal-redirect has precedence over permanent-redirect, status codes outside of
+// 300-307 for temporal redirects and 300-308 for permanent redirects use the defaults
+// 302 and 301, and the URL must use the http or https scheme. Unlike ingress-nginx, the
+// codes 304, 305 and 306 also use the defaults, as they are not redirects and clients
+// do not follow their Location. Annotations that are not set, or are invalid, keep the
+// ingress-nginx defaults. The invalid annotations are returned together as the error,
+// so the configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, RedirectAnnotations.Annotations, config)
+
+	c.FromToWWW = r.Bool(fromToWWWRedirAnnotation, c.FromToWWW)
+	c.Relative = r.Bool(relativeRedirectsAnnotation, c.Relative)
+	// selected is true once a redirect is set. An invalid temporal redirect disables
+	// the redirect, instead of using the permanent one
+	selected := false
+	for _, redirect := range []struct {
+		annotation, codeAnnotation string
+		defaultCode, maxCode       int
+		permanent                  bool
+	}{
+		{temporalRedirectAnnotation, temporalRedirectAnnotationCode, http.StatusFound, http.StatusTemporaryRedirect, false},
+		{permanentRedirectAnnotation, permanentRedirectAnnotationCode, http.StatusMovedPermanently, http.StatusPermanentRedirect, true},
+	} {
+		code := r.Int(redirect.codeAnnotation, redirect.defaultCode)
+		if code < http.StatusMultipleChoices || code > redirect.maxCode {
+			r.Invalid(redirect.codeAnnotation, strconv.Itoa(code), fmt.Errorf("status code must be between %d and %d", http.StatusMultipleChoices, redirect.maxCode))
+			code = redirect.defaultCode
+		} else if !isRedirectCode(code) {
+			r.Invalid(redirect.codeAnnotation, strconv.Itoa(code), fmt.Errorf("status code %d is not a redirect", code))
+			code = redirect.defaultCode
+		}
+		value, ok := r.Value(redirect.annotation)
+		if !ok || selected {
+			continue
+		}
+		selected = true
+		target, err := parseTarget(value)
+		if err != nil {
+			r.Invalid(redirect.annotation, value, err)
+			continue
+		}
+		c.URL, c.Target, c.Code, c.Permanent = value, target, code, redirect.permanent
+	}
+
+	c.set = r.Set()
+	ports := parser.NewAnnotationReader(ing, portinredirect.PortsInRedirectAnnotations.Annotations, config)
+	c.UsePortInRedirects = ports.Bool(portsInRedirectAnnotation, c.UsePortInRedirects)
+	if ports.IsSet(portsInRedirectAnnotation) {
+		c.set[portsInRedirectAnnotation] = true
+	}
+	return c, errors.Join(r.Err(), ports.Err())
+}
+
+// isRedirectCode returns if clients follow the Location of a response with the 3xx code.
+// 304 Not Modified has no Location, 305 Use Proxy is deprecated and 306 is unused
+func isRedirectCode(code int) bool {
+	return code != http.StatusNotModified && code != http.StatusUseProxy && code != 306
+}
+
+// parseTarget parses the URL of a redirect, that must be an absolute http or https URL
+func parseTarget(value string) (*Target, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("only http and https are valid protocols (%s)", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("redirect %s does not contain a host", value)
+	}
+	target := &Target{Scheme: u.Scheme, Host: u.Hostname(), Path: u.Path, RawQuery: u.RawQuery, Fragment: u.Fragment}
+	if port := u.Port(); port != "" {
+		target.Port, err = strconv.Atoi(port)
+		if err != nil || target.Port > 65535 {
+			return nil, fmt.Errorf("redirect %s contains an invalid port", value)
+		}
+	}
+	return target, nil
+}
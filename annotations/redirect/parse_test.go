@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redirect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "no redirect",
+			annotations: map[string]string{},
+			expected:    &Config{},
+		},
+		{
+			name: "permanent redirect with code",
+			annotations: map[string]string{
+				permanentRedirectAnnotation:     "https://www.example.com:8443/new",
+				permanentRedirectAnnotationCode: "308",
+				fromToWWWRedirAnnotation:        "true",
+				relativeRedirectsAnnotation:     "true",
+				portsInRedirectAnnotation:       "true",
+			},
+			expected: &Config{
+				URL:                "https://www.example.com:8443/new",
+				Target:             &Target{Scheme: "https", Host: "www.example.com", Port: 8443, Path: "/new"},
+				Code:               308,
+				Permanent:          true,
+				FromToWWW:          true,
+				Relative:           true,
+				UsePortInRedirects: true,
+			},
+		},
+		{
+			name: "temporal redirect has precedence",
+			annotations: map[string]string{
+				permanentRedirectAnnotation:    "https://permanent.example.com",
+				temporalRedirectAnnotation:     "http://temporal.example.com?from=ingress",
+				temporalRedirectAnnotationCode: "307",
+			},
+			expected: &Config{
+				URL:    "http://temporal.example.com?from=ingress",
+				Target: &Target{Scheme: "http", Host: "temporal.example.com", RawQuery: "from=ingress"},
+				Code:   307,
+			},
+		},
+		{
+			name: "temporal code outside of the range uses the default",
+			annotations: map[string]string{
+				temporalRedirectAnnotation:     "https://temporal.example.com",
+				temporalRedirectAnnotationCode: "308",
+			},
+			expected: &Config{
+				URL:    "https://temporal.example.com",
+				Target: &Target{Scheme: "https", Host: "temporal.example.com"},
+				Code:   302,
+			},
+			wantErr: true,
+		},
+		{
+			name: "temporal code that is not a redirect uses the default",
+			annotations: map[string]string{
+				temporalRedirectAnnotation:     "https://temporal.example.com",
+				temporalRedirectAnnotationCode: "304",
+			},
+			expected: &Config{
+				URL:    "https://temporal.example.com",
+				Target: &Target{Scheme: "https", Host: "temporal.example.com"},
+				Code:   302,
+			},
+			wantErr: true,
+		},
+		{
+			name: "permanent code that is not a redirect uses the default",
+			annotations: map[string]string{
+				permanentRedirectAnnotation:     "https://permanent.example.com",
+				permanentRedirectAnnotationCode: "306",
+			},
+			expected: &Config{
+				URL:       "https://permanent.example.com",
+				Target:    &Target{Scheme: "https", Host: "permanent.example.com"},
+				Code:      301,
+				Permanent: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "use proxy code uses the default",
+			annotations: map[string]string{
+				permanentRedirectAnnotation:     "https://permanent.example.com",
+				permanentRedirectAnnotationCode: "305",
+			},
+			expected: &Config{
+				URL:       "https://permanent.example.com",
+				Target:    &Target{Scheme: "https", Host: "permanent.example.com"},
+				Code:      301,
+				Permanent: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid temporal redirect disables the redirect",
+			annotations: map[string]string{
+				permanentRedirectAnnotation: "https://permanent.example.com",
+				temporalRedirectAnnotation:  "ftp://temporal.example.com",
+			},
+			expected: &Config{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestFromToWWWHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+		ok       bool
+	}{
+		{host: "example.com", expected: "www.example.com", ok: true},
+		{host: "www.example.com", expected: "example.com", ok: true},
+		{host: "*.example.com"},
+		{host: ""},
+	}
+	for _, tt := range tests {
+		from, ok := FromToWWWHost(tt.host)
+		if from != tt.expected || ok != tt.ok {
+			t.Errorf("expected %q %t for host %q, got %q %t", tt.expected, tt.ok, tt.host, from, ok)
+		}
+	}
+}
@@ -31,54 +31,72 @@ const (
 
 var RedirectAnnotations = parser.Annotation{
 	Group: "redirect",
+	Rules: []parser.Rule{
+		// Temporal redirects have precedence over permanent redirects
+		parser.IgnoredWhen(permanentRedirectAnnotation, temporalRedirectAnnotation),
+		parser.Requires(permanentRedirectAnnotationCode, permanentRedirectAnnotation),
+		parser.Requires(temporalRedirectAnnotationCode, temporalRedirectAnnotation),
+	},
 	Annotations: parser.AnnotationFields{
 		fromToWWWRedirAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `In some scenarios, it is required to redirect from www.domain.com to domain.com or vice versa, which way the redirect is performed depends on the configured host value in the Ingress object.`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `In some scenarios, it is required to redirect from www.domain.com to domain.com or vice versa, which way the redirect is performed depends on the configured host value in the Ingress object.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'. Only the status codes 301 and 302 are supported, instead of the default 308",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		temporalRedirectAnnotation: {
 			Validator: parser.ValidateRegex(parser.URLIsValidRegex, false),
+			Format:    parser.RegexFormat(parser.URLIsValidRegex, false),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium, // Medium, as it allows arbitrary URLs that needs to be validated
 			Documentation: `This annotation allows you to return a temporal redirect (Return Code 302) instead of sending data to the upstream. 
 			For example setting this annotation to https://www.google.com would redirect everything to Google with a Return Code of 302 (Moved Temporarily).`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		temporalRedirectAnnotationCode: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `This annotation allows you to modify the status code used for temporal redirects.`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `This annotation allows you to modify the status code used for temporal redirects.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestRedirect.statusCode'. Only 301 and 302 are supported",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		permanentRedirectAnnotation: {
 			Validator: parser.ValidateRegex(parser.URLIsValidRegex, false),
+			Format:    parser.RegexFormat(parser.URLIsValidRegex, false),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium, // Medium, as it allows arbitrary URLs that needs to be validated
 			Documentation: `This annotation allows to return a permanent redirect (Return Code 301) instead of sending data to the upstream. 
 			For example setting this annotation https://www.google.com would redirect everything to Google with a code 301`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		permanentRedirectAnnotationCode: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `This annotation allows you to modify the status code used for permanent redirects.`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation:           `This annotation allows you to modify the status code used for permanent redirects.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestRedirect.statusCode'. Only 301 and 302 are supported",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		relativeRedirectsAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `If enabled, redirects issued by nginx will be relative. See https://nginx.org/en/docs/http/ngx_http_core_module.html#absolute_redirect`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `If enabled, redirects issued by nginx will be relative. See https://nginx.org/en/docs/http/ngx_http_core_module.html#absolute_redirect`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
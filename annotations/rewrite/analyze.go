@@ -0,0 +1,276 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rewrite
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/xforwardedprefix"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+// xForwardedPrefixAnnotation also accepts references to the capture groups of the path
+const xForwardedPrefixAnnotation = "x-forwarded-prefix"
+
+var (
+	// captureReferenceRegex matches the references to regex capture groups, like $1
+	captureReferenceRegex = regexp.MustCompile(`\$([0-9]+)`)
+
+	// segmentPrefixPathRegex matches a literal prefix followed by (/|$)(.*), the path
+	// documented by ingress-nginx to rewrite a prefix
+	segmentPrefixPathRegex = regexp.MustCompile(`^(/[A-Za-z0-9_~%/-]*[A-Za-z0-9_~%-])\(/\|\$\)\(\.\*\)$`)
+	// slashPrefixPathRegex matches a literal prefix followed by /(.*)
+	slashPrefixPathRegex = regexp.MustCompile(`^(/[A-Za-z0-9_~%/-]*[A-Za-z0-9_~%-])/\(\.\*\)$`)
+	// prefixTargetRegex matches a literal path followed by the reference to a capture group
+	prefixTargetRegex = regexp.MustCompile(`^((?:/[A-Za-z0-9_~%.-]+)*)/\$([0-9]+)$`)
+)
+
+// RewriteType defines how the path of a request is rewritten
+type RewriteType string
+
+const (
+	// RewriteNone does not rewrite the path
+	RewriteNone RewriteType = ""
+	// RewriteFullPath replaces the whole path, like the Gateway API ReplaceFullPath
+	RewriteFullPath RewriteType = "FullPath"
+	// RewritePrefixMatch replaces a literal prefix of the path, like the Gateway API ReplacePrefixMatch
+	RewritePrefixMatch RewriteType = "PrefixMatch"
+	// RewriteRegex needs a regular expression substitution, that Gateway API does not
+	// support and requires an implementation specific extension
+	RewriteRegex RewriteType = "Regex"
+)
+
+// Rewrite is how rewrite-target rewrites the requests of a path
+type Rewrite struct {
+	Type RewriteType `json:"type"`
+	// Prefix is the literal prefix of the path replaced by a PrefixMatch rewrite
+	Prefix string `json:"prefix,omitempty"`
+	// Replacement is the path of a FullPath rewrite, or the replacement of the prefix
+	Replacement string `json:"replacement,omitempty"`
+	// Reason explains why a Regex rewrite cannot be expressed with Gateway API
+	Reason string `json:"reason,omitempty"`
+	// Warnings are the differences between the rewrite of ingress-nginx and of Gateway API
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PathAnalysis is the analysis of a path of the Ingress rules
+type PathAnalysis struct {
+	Host     string              `json:"host"`
+	Path     string              `json:"path"`
+	PathType networking.PathType `json:"pathType"`
+	// Regex is true when ingress-nginx evaluates the path as a case insensitive
+	// regular expression, anchored at the beginning of the path
+	Regex bool `json:"regex"`
+	// CaptureGroups is the number of capture groups of the path
+	CaptureGroups int     `json:"captureGroups"`
+	Rewrite       Rewrite `json:"rewrite"`
+}
+
+// Analysis is the result of the analysis of the paths of an Ingress
+type Analysis struct {
+	Paths []PathAnalysis `json:"paths"`
+	// Result contains the references to capture groups that do not exist
+	Result *parser.ValidationResult `json:"-"`
+}
+
+// Path returns the analysis of the path of a host, if the Ingress defines it
+func (a *Analysis) Path(host, path string) (PathAnalysis, bool) {
+	for _, p := range a.Paths {
+		if p.Host == host && p.Path == path {
+			return p, true
+		}
+	}
+	return PathAnalysis{}, false
+}
+
+// Analyze analyzes the paths of the Ingress using the default parser configuration
+func Analyze(ing *networking.Ingress) *Analysis {
+	return AnalyzeWithConfig(ing, parser.DefaultConfig())
+}
+
+// AnalyzeWithConfig analyzes each path of the Ingress rules: it counts the capture
+// groups of the paths evaluated as regular expressions, reports the references of
+// rewrite-target, x-forwarded-prefix and app-root to groups that do not exist, and
+// decides how rewrite-target can be expressed. ingress-nginx evaluates the
+// ImplementationSpecific paths as regular expressions when use-regex or
+// rewrite-target are set, other paths never have capture groups. Invalid annotations
+// are ignored, as they are reported by the registry validation
+func AnalyzeWithConfig(ing *networking.Ingress, config parser.Config) *Analysis {
+	analysis := &Analysis{
+		Paths:  make([]PathAnalysis, 0),
+		Result: &parser.ValidationResult{},
+	}
+	r := parser.NewAnnotationReader(ing, RewriteAnnotations.Annotations, config)
+	target := r.String(rewriteTargetAnnotation, "")
+	useRegex := r.Bool(useRegexAnnotation, false) || target != ""
+	references := map[string]string{
+		rewriteTargetAnnotation: target,
+		appRootAnnotation:       r.String(appRootAnnotation, ""),
+		xForwardedPrefixAnnotation: parser.NewAnnotationReader(ing, xforwardedprefix.XForwardedForAnnotations.Annotations, config).
+			String(xForwardedPrefixAnnotation, ""),
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			p := PathAnalysis{Host: rule.Host, Path: path.Path, PathType: networking.PathTypeImplementationSpecific}
+			if p.Path == "" {
+				p.Path = "/"
+			}
+			if path.PathType != nil {
+				p.PathType = *path.PathType
+			}
+			p.Regex = useRegex && p.PathType == networking.PathTypeImplementationSpecific
+			if p.Regex {
+				p.CaptureGroups = countCaptureGroups(p.Path)
+			}
+
+			for _, name := range slices.Sorted(maps.Keys(references)) {
+				for _, n := range danglingReferences(references[name], p.CaptureGroups) {
+					analysis.Result.Add(newFinding(ing, config, name, references[name],
+						fmt.Sprintf("$%d is not a capture group of path %s of host %s, that has %d groups, and is replaced by an empty string", n, p.Path, hostOrAny(p.Host), p.CaptureGroups)))
+				}
+			}
+			if target != "" {
+				p.Rewrite = analyzeRewrite(p, target)
+			}
+			analysis.Paths = append(analysis.Paths, p)
+		}
+	}
+	return analysis
+}
+
+// analyzeRewrite decides how the rewrite of a path can be expressed with Gateway API
+func analyzeRewrite(p PathAnalysis, target string) Rewrite {
+	if !captureReferenceRegex.MatchString(target) {
+		return Rewrite{Type: RewriteFullPath, Replacement: target}
+	}
+	if len(danglingReferences(target, p.CaptureGroups)) > 0 {
+		return Rewrite{Type: RewriteRegex, Reason: fmt.Sprintf("rewrite-target %s references capture groups that path %s does not have", target, p.Path)}
+	}
+
+	for _, candidate := range []struct {
+		path  *regexp.Regexp
+		group int
+		warn  string
+	}{
+		{path: segmentPrefixPathRegex, group: 2},
+		{path: slashPrefixPathRegex, group: 1, warn: "path %s requires a slash after %s on ingress-nginx, while the prefix match also matches %s"},
+	} {
+		prefix := candidate.path.FindStringSubmatch(p.Path)
+		if prefix == nil {
+			continue
+		}
+		replacement := prefixTargetRegex.FindStringSubmatch(target)
+		if replacement == nil || replacement[2] != strconv.Itoa(candidate.group) {
+			continue
+		}
+		rewrite := Rewrite{Type: RewritePrefixMatch, Prefix: prefix[1], Replacement: replacement[1]}
+		if rewrite.Replacement == "" {
+			rewrite.Replacement = "/"
+		} else {
+			rewrite.Warnings = append(rewrite.Warnings, fmt.Sprintf("request %s is rewritten to %s/ by ingress-nginx, and to %s by Gateway API", rewrite.Prefix, rewrite.Replacement, rewrite.Replacement))
+		}
+		if candidate.warn != "" {
+			rewrite.Warnings = append(rewrite.Warnings, fmt.Sprintf(candidate.warn, p.Path, rewrite.Prefix, rewrite.Prefix))
+		}
+		rewrite.Warnings = append(rewrite.Warnings, fmt.Sprintf("path %s is matched case insensitively by ingress-nginx, while the prefix match of %s is case sensitive", p.Path, rewrite.Prefix))
+		return rewrite
+	}
+	return Rewrite{
+		Type:   RewriteRegex,
+		Reason: fmt.Sprintf("rewrite of path %s to %s needs a regular expression substitution, that requires an implementation specific extension", p.Path, target),
+	}
+}
+
+// countCaptureGroups returns the number of capture groups of a path. Paths that are
+// not valid RE2 expressions, like the ones using PCRE lookarounds, are scanned for
+// opening parentheses that are not escaped, inside a class or non capturing
+func countCaptureGroups(path string) int {
+	if re, err := regexp.Compile(path); err == nil {
+		return re.NumSubexp()
+	}
+	groups := 0
+	inClass := false
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\':
+			i++
+		case inClass:
+			inClass = path[i] != ']'
+		case path[i] == '[':
+			inClass = true
+		case path[i] == '(':
+			if i+1 >= len(path) || path[i+1] != '?' {
+				groups++
+			} else if i+2 < len(path) && (path[i+2] == 'P' || path[i+2] == '<' || path[i+2] == '\'') && (i+3 >= len(path) || (path[i+3] != '=' && path[i+3] != '!')) {
+				// Named groups also capture, (?<= and (?<! are lookbehinds
+				groups++
+			}
+		}
+	}
+	return groups
+}
+
+// danglingReferences returns the sorted capture groups referenced by the value that
+// the path does not have. $0 is the whole match, and always exists
+func danglingReferences(value string, groups int) []int {
+	dangling := make([]int, 0)
+	for _, match := range captureReferenceRegex.FindAllStringSubmatch(value, -1) {
+		n, err := strconv.Atoi(match[1])
+		if err != nil || n <= groups || slices.Contains(dangling, n) {
+			continue
+		}
+		dangling = append(dangling, n)
+	}
+	slices.Sort(dangling)
+	return dangling
+}
+
+func newFinding(ing *networking.Ingress, config parser.Config, annotation, value, reason string) parser.Finding {
+	finding := parser.Finding{
+		Type:       parser.FindingInvalidCaptureGroup,
+		Severity:   parser.SeverityWarning,
+		Annotation: config.AnnotationWithPrefix(annotation),
+		Value:      value,
+		Feature:    "rewrite",
+		Group:      RewriteAnnotations.Group,
+		Reason:     reason,
+	}
+	if field, ok := RewriteAnnotations.Annotations[annotation]; ok {
+		finding.Risk = field.Risk
+	} else {
+		finding.Feature = "xforwardedprefix"
+		finding.Group = xforwardedprefix.XForwardedForAnnotations.Group
+		finding.Risk = xforwardedprefix.XForwardedForAnnotations.Annotations[annotation].Risk
+	}
+	return finding
+}
+
+func hostOrAny(host string) string {
+	if host == "" {
+		return "*"
+	}
+	return host
+}
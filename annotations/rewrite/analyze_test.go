@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rewrite
+
+import (
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		path          string
+		pathType      networking.PathType
+		regex         bool
+		captureGroups int
+		rewrite       RewriteType
+		prefix        string
+		replacement   string
+		findings      int
+	}{
+		{
+			name:        "rewrite without capture groups",
+			annotations: map[string]string{rewriteTargetAnnotation: "/"},
+			path:        "/api",
+			pathType:    networking.PathTypePrefix,
+			rewrite:     RewriteFullPath,
+			replacement: "/",
+		},
+		{
+			name:          "documented prefix rewrite",
+			annotations:   map[string]string{rewriteTargetAnnotation: "/$2"},
+			path:          "/something(/|$)(.*)",
+			pathType:      networking.PathTypeImplementationSpecific,
+			regex:         true,
+			captureGroups: 2,
+			rewrite:       RewritePrefixMatch,
+			prefix:        "/something",
+			replacement:   "/",
+		},
+		{
+			name:          "prefix rewrite to another prefix",
+			annotations:   map[string]string{rewriteTargetAnnotation: "/v2/$1"},
+			path:          "/api/v1/(.*)",
+			pathType:      networking.PathTypeImplementationSpecific,
+			regex:         true,
+			captureGroups: 1,
+			rewrite:       RewritePrefixMatch,
+			prefix:        "/api/v1",
+			replacement:   "/v2",
+		},
+		{
+			name:          "regex substitution",
+			annotations:   map[string]string{rewriteTargetAnnotation: "/$2/$1"},
+			path:          "/(users|groups)/([0-9]+)",
+			pathType:      networking.PathTypeImplementationSpecific,
+			regex:         true,
+			captureGroups: 2,
+			rewrite:       RewriteRegex,
+		},
+		{
+			name:        "prefix paths are not regular expressions",
+			annotations: map[string]string{rewriteTargetAnnotation: "/$1"},
+			path:        "/api",
+			pathType:    networking.PathTypePrefix,
+			rewrite:     RewriteRegex,
+			findings:    1,
+		},
+		{
+			name: "dangling references of all the annotations",
+			annotations: map[string]string{
+				useRegexAnnotation:         "true",
+				xForwardedPrefixAnnotation: "/$1",
+				appRootAnnotation:          "/app/$2",
+			},
+			path:          "/(?!internal)(.*)",
+			pathType:      networking.PathTypeImplementationSpecific,
+			regex:         true,
+			captureGroups: 1,
+			findings:      1,
+		},
+		{
+			name:        "paths are literal without use-regex",
+			annotations: map[string]string{xForwardedPrefixAnnotation: "/$1"},
+			path:        "/(.*)",
+			pathType:    networking.PathTypeImplementationSpecific,
+			findings:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := Analyze(ingresstest.WithPath(ingresstest.New(tt.annotations), "app.example.com", tt.path, tt.pathType, "", 0))
+			p, ok := analysis.Path("app.example.com", tt.path)
+			if !ok {
+				t.Fatalf("expected the analysis of path %s, got %+v", tt.path, analysis.Paths)
+			}
+			if p.Regex != tt.regex || p.CaptureGroups != tt.captureGroups {
+				t.Errorf("expected regex %t with %d groups, got %t with %d", tt.regex, tt.captureGroups, p.Regex, p.CaptureGroups)
+			}
+			if p.Rewrite.Type != tt.rewrite || p.Rewrite.Prefix != tt.prefix || p.Rewrite.Replacement != tt.replacement {
+				t.Errorf("expected rewrite %s of %q to %q, got %+v", tt.rewrite, tt.prefix, tt.replacement, p.Rewrite)
+			}
+			if p.Rewrite.Type == RewriteRegex && p.Rewrite.Reason == "" {
+				t.Errorf("expected the reason of the regex rewrite")
+			}
+			if len(analysis.Result.Findings) != tt.findings {
+				t.Fatalf("expected %d findings, got %+v", tt.findings, analysis.Result.Findings)
+			}
+			for _, finding := range analysis.Result.Findings {
+				if finding.Type != parser.FindingInvalidCaptureGroup || finding.Severity != parser.SeverityWarning {
+					t.Errorf("unexpected finding %+v", finding)
+				}
+			}
+		})
+	}
+}
+
+func TestCountCaptureGroups(t *testing.T) {
+	for path, expected := range map[string]int{
+		"/api":                    0,
+		"/api(/|$)(.*)":           2,
+		"/(?:a|b)/(.*)":           1,
+		`/\(literal\)/(.*)`:       1,
+		"/[(]/(.*)":               1,
+		"/(?!internal)(.*)":       1,
+		"/(?<name>[a-z]+)/(?<=x)": 1,
+	} {
+		if groups := countCaptureGroups(path); groups != expected {
+			t.Errorf("expected %d groups on %s, got %d", expected, path, groups)
+		}
+	}
+}
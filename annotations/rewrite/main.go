@@ -34,49 +34,63 @@ var RewriteAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		rewriteTargetAnnotation: {
 			Validator: parser.ValidateRegex(parser.RegexPathWithCapture, false),
+			Format:    parser.RegexFormat(parser.RegexPathWithCapture, false),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation allows to specify the target URI where the traffic must be redirected. It can contain regular characters and captured 
 			groups specified as '$1', '$2', etc.`,
-			GatewayAPI:    "Partially supported by HTTPRoute 'spec.rules[].filters[].urlRewrite'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].urlRewrite'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		sslRedirectAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation defines if the location section is only accessible via SSL`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation defines if the location section is only accessible via SSL`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'. Only the status codes 301 and 302 are supported, instead of the default 308",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		preserveTrailingSlashAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation defines if the trailing slash should be preserved in the URI with 'ssl-redirect'`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation defines if the trailing slash should be preserved in the URI with 'ssl-redirect'`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		forceSSLRedirectAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation forces the redirection to HTTPS even if the Ingress is not TLS Enabled`,
-			GatewayAPI:    "Supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation forces the redirection to HTTPS even if the Ingress is not TLS Enabled`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestRedirect'. Only the status codes 301 and 302 are supported, instead of the default 308",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 		useRegexAnnotation: {
 			Validator: parser.ValidateBool,
+			Format:    parser.FormatBool,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation defines if the paths defined on an Ingress use regular expressions. To use regex on path
 			the pathType should also be defined as 'ImplementationSpecific'.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].matches[].path' with type RegularExpression. The regular expression syntax is implementation specific",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httppathmatch",
 		},
 		appRootAnnotation: {
-			Validator:     parser.ValidateRegex(parser.RegexPathWithCapture, false),
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation defines the Application Root that the Controller must redirect if it's in / context`,
-			GatewayAPI:    "Partially supported by HTTPRoute 'spec.rules[].filters[].urlRewrite'",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
+			Validator:               parser.ValidateRegex(parser.RegexPathWithCapture, false),
+			Format:                  parser.RegexFormat(parser.RegexPathWithCapture, false),
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation defines the Application Root that the Controller must redirect if it's in / context`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].urlRewrite'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httproutefilter",
 		},
 	},
 }
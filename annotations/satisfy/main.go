@@ -29,11 +29,13 @@ var SatisfyAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		satisfyAnnotation: {
 			Validator: parser.ValidateOptions([]string{"any", "all"}, true, true),
+			Format:    parser.OptionsFormat([]string{"any", "all"}, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `By default, a request would need to satisfy all authentication requirements in order to be allowed. 
 			By using this annotation, requests that satisfy either any or all authentication requirements are allowed, based on the configuration value.
 			Valid options are "all" and "any"`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
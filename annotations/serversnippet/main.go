@@ -28,10 +28,12 @@ var ServerSnippetAnnotations = parser.Annotation{
 	Group: "snippets",
 	Annotations: parser.AnnotationFields{
 		serverSnippetAnnotation: {
-			Validator:     parser.ValidateNull,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskCritical, // Critical, this annotation is not validated at all and allows arbitrary configurations
-			Documentation: `This annotation allows setting a custom NGINX configuration on a server block. This annotation does not contain any validation and it's usage is not recommended!`,
+			Validator:               parser.ValidateNull,
+			Format:                  parser.FormatNull,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskCritical, // Critical, this annotation is not validated at all and allows arbitrary configurations
+			Documentation:           `This annotation allows setting a custom NGINX configuration on a server block. This annotation does not contain any validation and it's usage is not recommended!`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
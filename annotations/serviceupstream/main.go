@@ -28,10 +28,12 @@ var ServiceUpstreamAnnotations = parser.Annotation{
 	Group: "backend",
 	Annotations: parser.AnnotationFields{
 		serviceUpstreamAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow, // Critical, this annotation is not validated at all and allows arbitrary configurations
-			Documentation: `This annotation makes NGINX use Service's Cluster IP and Port instead of Endpoints as the backend endpoints`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow, // Critical, this annotation is not validated at all and allows arbitrary configurations
+			Documentation:           `This annotation makes NGINX use Service's Cluster IP and Port instead of Endpoints as the backend endpoints`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
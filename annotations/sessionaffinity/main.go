@@ -63,84 +63,130 @@ const (
 
 var SessionAffinityAnnotations = parser.Annotation{
 	Group: "affinity",
+	Rules: []parser.Rule{
+		// All the session annotations are ignored if cookie affinity is not enabled
+		parser.Requires(annotationAffinityMode, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCanaryBehavior, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieName, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieSecure, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieExpires, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieMaxAge, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookiePath, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieDomain, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieSameSite, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieConditionalSameSiteNone, annotationAffinityType).WithValues(cookieAffinity),
+		parser.Requires(annotationAffinityCookieChangeOnFailure, annotationAffinityType).WithValues(cookieAffinity),
+	},
 	Annotations: parser.AnnotationFields{
 		annotationAffinityType: {
-			Validator:     parser.ValidateOptions([]string{cookieAffinity}, true, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation enables and sets the affinity type in all Upstreams of an Ingress. This way, a request will always be directed to the same upstream server. The only affinity type available for NGINX is cookie`,
+			Validator:               parser.ValidateOptions([]string{cookieAffinity}, true, true),
+			Format:                  parser.OptionsFormat([]string{cookieAffinity}, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation enables and sets the affinity type in all Upstreams of an Ingress. This way, a request will always be directed to the same upstream server. The only affinity type available for NGINX is cookie`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].sessionPersistence' with type Cookie",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#sessionpersistence",
 		},
 		annotationAffinityMode: {
 			Validator: parser.ValidateOptions([]string{"balanced", "persistent"}, true, true),
+			Format:    parser.OptionsFormat([]string{"balanced", "persistent"}, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation defines the stickiness of a session. 
 			Setting this to balanced (default) will redistribute some sessions if a deployment gets scaled up, therefore rebalancing the load on the servers. 
 			Setting this to persistent will not rebalance sessions to new servers, therefore providing maximum stickiness.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCanaryBehavior: {
 			Validator: parser.ValidateOptions([]string{"sticky", "legacy"}, true, true),
+			Format:    parser.OptionsFormat([]string{"sticky", "legacy"}, true),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation defines the behavior of canaries when session affinity is enabled.
 			Setting this to sticky (default) will ensure that users that were served by canaries, will continue to be served by canaries.
 			Setting this to legacy will restore original canary behavior, when session affinity was ignored.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCookieName: {
-			Validator:     parser.ValidateRegex(parser.BasicCharsRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation allows to specify the name of the cookie that will be used to route the requests`,
+			Validator:               parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:                  parser.RegexFormat(parser.BasicCharsRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation allows to specify the name of the cookie that will be used to route the requests`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by HTTPRoute 'spec.rules[].sessionPersistence.sessionName'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#sessionpersistence",
 		},
 		annotationAffinityCookieSecure: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation set the cookie as secure regardless the protocol of the incoming request`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation set the cookie as secure regardless the protocol of the incoming request`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCookieExpires: {
-			Validator:     parser.ValidateRegex(affinityCookieExpiresRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation is a legacy version of "session-cookie-max-age" for compatibility with older browsers, generates an "Expires" cookie directive by adding the seconds to the current date`,
+			Validator:               parser.ValidateRegex(affinityCookieExpiresRegex, true),
+			Format:                  parser.RegexFormat(affinityCookieExpiresRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation is a legacy version of "session-cookie-max-age" for compatibility with older browsers, generates an "Expires" cookie directive by adding the seconds to the current date`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].sessionPersistence.absoluteTimeout'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#sessionpersistence",
 		},
 		annotationAffinityCookieMaxAge: {
-			Validator:     parser.ValidateRegex(affinityCookieExpiresRegex, false),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation sets the time until the cookie expires`,
+			Validator:               parser.ValidateRegex(affinityCookieExpiresRegex, false),
+			Format:                  parser.RegexFormat(affinityCookieExpiresRegex, false),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation sets the time until the cookie expires`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].sessionPersistence.absoluteTimeout'",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#sessionpersistence",
 		},
 		annotationAffinityCookiePath: {
-			Validator:     parser.ValidateRegex(parser.URLIsValidRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation defines the Path that will be set on the cookie (required if your Ingress paths use regular expressions)`,
+			Validator:               parser.ValidateRegex(parser.URLIsValidRegex, true),
+			Format:                  parser.RegexFormat(parser.URLIsValidRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation defines the Path that will be set on the cookie (required if your Ingress paths use regular expressions)`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCookieDomain: {
-			Validator:     parser.ValidateRegex(parser.BasicCharsRegex, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskMedium,
-			Documentation: `This annotation defines the Domain attribute of the sticky cookie.`,
+			Validator:               parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Format:                  parser.RegexFormat(parser.BasicCharsRegex, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskMedium,
+			Documentation:           `This annotation defines the Domain attribute of the sticky cookie.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCookieSameSite: {
 			Validator: parser.ValidateOptions([]string{"none", "lax", "strict"}, false, true),
+			Format:    parser.OptionsFormat([]string{"none", "lax", "strict"}, false),
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation is used to apply a SameSite attribute to the sticky cookie. 
 			Browser accepted values are None, Lax, and Strict`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCookieConditionalSameSiteNone: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation is used to omit SameSite=None from browsers with SameSite attribute incompatibilities`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation is used to omit SameSite=None from browsers with SameSite attribute incompatibilities`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		annotationAffinityCookieChangeOnFailure: {
 			Validator: parser.ValidateBool,
+			Format:    parser.FormatBool,
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This annotation, when set to false will send request to upstream pointed by sticky cookie even if previous attempt failed. 
 			When set to true and previous attempt failed, sticky cookie will be changed to point to another upstream.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
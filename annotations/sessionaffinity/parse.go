@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sessionaffinity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+// defaultCookieName is the name of the sticky cookie when session-cookie-name is not set
+const defaultCookieName = "INGRESSCOOKIE"
+
+// Mode defines how sessions are distributed when the upstream servers change
+type Mode string
+
+const (
+	// ModeBalanced redistributes some sessions when servers are added
+	ModeBalanced Mode = "balanced"
+	// ModePersistent never moves sessions to new servers
+	ModePersistent Mode = "persistent"
+)
+
+// CanaryBehavior defines how session affinity interacts with canaries
+type CanaryBehavior string
+
+const (
+	// CanaryBehaviorSticky keeps the users served by a canary on the canary
+	CanaryBehaviorSticky CanaryBehavior = "sticky"
+	// CanaryBehaviorLegacy ignores session affinity when selecting the canary
+	CanaryBehaviorLegacy CanaryBehavior = "legacy"
+)
+
+// Cookie contains the attributes of the sticky cookie. A zero MaxAge and Expires
+// means the cookie is a session cookie, and an empty Path means the path of the
+// Ingress location is used
+type Cookie struct {
+	Name                    string
+	Path                    string
+	Domain                  string
+	Secure                  bool
+	SameSite                string
+	ConditionalSameSiteNone bool
+	MaxAge                  time.Duration
+	Expires                 time.Duration
+	ChangeOnFailure         bool
+}
+
+// Lifetime returns how long the cookie is valid, 0 for a session cookie. As in browsers,
+// Max-Age has precedence over Expires
+func (c Cookie) Lifetime() time.Duration {
+	if c.MaxAge != 0 {
+		return c.MaxAge
+	}
+	return c.Expires
+}
+
+// Config is the typed configuration of the session affinity annotations
+type Config struct {
+	// Type is the affinity type, cookie or empty when session affinity is disabled
+	Type           string
+	Mode           Mode
+	CanaryBehavior CanaryBehavior
+	Cookie         Cookie
+
+	// set contains the annotations explicitly set on the Ingress
+	set map[string]bool
+}
+
+// NewDefaultConfig returns the session affinity configuration used by ingress-nginx
+// when no annotation is set
+func NewDefaultConfig() *Config {
+	return &Config{
+		Mode:           ModeBalanced,
+		CanaryBehavior: CanaryBehaviorSticky,
+		Cookie:         Cookie{Name: defaultCookieName},
+		set:            make(map[string]bool),
+	}
+}
+
+// Enabled returns if cookie based session affinity is enabled
+func (c *Config) Enabled() bool {
+	return c.Type == cookieAffinity
+}
+
+// IsSet returns if the annotation, without prefix, was explicitly set on the Ingress.
+// When it returns false, the field contains the ingress-nginx default
+func (c *Config) IsSet(annotation string) bool {
+	return c.set[annotation]
+}
+
+// Parse returns the session affinity configuration of the Ingress using the default parser configuration
+func Parse(ing *networking.Ingress) (*Config, error) {
+	return ParseWithConfig(ing, parser.DefaultConfig())
+}
+
+// ParseWithConfig returns the session affinity configuration of the Ingress. Annotations
+// that are not set, or are invalid, keep the ingress-nginx defaults. The invalid
+// annotations are returned together as the error, so the configuration is always usable
+func ParseWithConfig(ing *networking.Ingress, config parser.Config) (*Config, error) {
+	c := NewDefaultConfig()
+	r := parser.NewAnnotationReader(ing, SessionAffinityAnnotations.Annotations, config)
+
+	c.Type = strings.ToLower(r.String(annotationAffinityType, c.Type))
+	c.Mode = Mode(strings.ToLower(r.String(annotationAffinityMode, string(c.Mode))))
+	c.CanaryBehavior = CanaryBehavior(strings.ToLower(r.String(annotationAffinityCanaryBehavior, string(c.CanaryBehavior))))
+
+	c.Cookie.Name = r.String(annotationAffinityCookieName, c.Cookie.Name)
+	c.Cookie.Path = r.String(annotationAffinityCookiePath, c.Cookie.Path)
+	c.Cookie.Domain = r.String(annotationAffinityCookieDomain, c.Cookie.Domain)
+	c.Cookie.Secure = r.Bool(annotationAffinityCookieSecure, c.Cookie.Secure)
+	c.Cookie.SameSite = sameSite(r.String(annotationAffinityCookieSameSite, c.Cookie.SameSite))
+	c.Cookie.ConditionalSameSiteNone = r.Bool(annotationAffinityCookieConditionalSameSiteNone, c.Cookie.ConditionalSameSiteNone)
+	c.Cookie.MaxAge = time.Duration(r.Int(annotationAffinityCookieMaxAge, 0)) * time.Second
+	c.Cookie.Expires = time.Duration(r.Int(annotationAffinityCookieExpires, 0)) * time.Second
+	c.Cookie.ChangeOnFailure = r.Bool(annotationAffinityCookieChangeOnFailure, c.Cookie.ChangeOnFailure)
+
+	c.set = r.Set()
+	return c, r.Err()
+}
+
+// sameSite returns the SameSite attribute with the capitalization used by browsers
+func sameSite(value string) string {
+	if value == "" {
+		return ""
+	}
+	value = strings.ToLower(value)
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sessionaffinity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		wantErr     bool
+	}{
+		{
+			name:        "disabled",
+			annotations: map[string]string{},
+			expected: &Config{
+				Mode:           ModeBalanced,
+				CanaryBehavior: CanaryBehaviorSticky,
+				Cookie:         Cookie{Name: "INGRESSCOOKIE"},
+			},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				annotationAffinityType:                          "cookie",
+				annotationAffinityMode:                          "persistent",
+				annotationAffinityCanaryBehavior:                "legacy",
+				annotationAffinityCookieName:                    "route",
+				annotationAffinityCookiePath:                    "/app",
+				annotationAffinityCookieDomain:                  "example.com",
+				annotationAffinityCookieSecure:                  "true",
+				annotationAffinityCookieSameSite:                "NONE",
+				annotationAffinityCookieConditionalSameSiteNone: "true",
+				annotationAffinityCookieMaxAge:                  "3600",
+				annotationAffinityCookieExpires:                 "172800",
+				annotationAffinityCookieChangeOnFailure:         "true",
+			},
+			expected: &Config{
+				Type:           "cookie",
+				Mode:           ModePersistent,
+				CanaryBehavior: CanaryBehaviorLegacy,
+				Cookie: Cookie{
+					Name:                    "route",
+					Path:                    "/app",
+					Domain:                  "example.com",
+					Secure:                  true,
+					SameSite:                "None",
+					ConditionalSameSiteNone: true,
+					MaxAge:                  time.Hour,
+					Expires:                 48 * time.Hour,
+					ChangeOnFailure:         true,
+				},
+			},
+		},
+		{
+			name: "invalid values keep the defaults",
+			annotations: map[string]string{
+				annotationAffinityType:         "cookie",
+				annotationAffinityMode:         "random",
+				annotationAffinityCookieSecure: "yes please",
+			},
+			expected: &Config{
+				Type:           "cookie",
+				Mode:           ModeBalanced,
+				CanaryBehavior: CanaryBehaviorSticky,
+				Cookie:         Cookie{Name: "INGRESSCOOKIE"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(ingresstest.New(tt.annotations))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			config.set = nil
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestCookieLifetime(t *testing.T) {
+	tests := []struct {
+		cookie   Cookie
+		expected time.Duration
+	}{
+		{cookie: Cookie{}, expected: 0},
+		{cookie: Cookie{Expires: time.Hour}, expected: time.Hour},
+		{cookie: Cookie{MaxAge: time.Minute, Expires: time.Hour}, expected: time.Minute},
+	}
+	for _, tt := range tests {
+		if lifetime := tt.cookie.Lifetime(); lifetime != tt.expected {
+			t.Errorf("expected lifetime %s for %+v, got %s", tt.expected, tt.cookie, lifetime)
+		}
+	}
+}
@@ -36,16 +36,20 @@ var SSLCipherAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		sslPreferServerCipherAnnotation: {
 			Validator: parser.ValidateBool,
+			Format:    parser.FormatBool,
 			Scope:     parser.AnnotationScopeIngress,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `The following annotation will set the ssl_prefer_server_ciphers directive at the server level. 
 			This configuration specifies that server ciphers should be preferred over client ciphers when using the TLS protocols.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		sslCipherAnnotation: {
-			Validator:     parser.ValidateRegex(regexValidSSLCipher, true),
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `Using this annotation will set the ssl_ciphers directive at the server level. This configuration is active for all the paths in the host.`,
+			Validator:               parser.ValidateRegex(regexValidSSLCipher, true),
+			Format:                  parser.RegexFormat(regexValidSSLCipher, true),
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `Using this annotation will set the ssl_ciphers directive at the server level. This configuration is active for all the paths in the host.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
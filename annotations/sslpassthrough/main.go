@@ -28,12 +28,14 @@ var SSLPassthroughAnnotations = parser.Annotation{
 	Group: "", // TBD
 	Annotations: parser.AnnotationFields{
 		sslPassthroughAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeIngress,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows regexes but on a very limited set
-			Documentation: `This annotation instructs the controller to send TLS connections directly to the backend instead of letting NGINX decrypt the communication.`,
-			GatewayAPI:    "Supported by the TLSRoute API",
-			GatewayAPIRef: "https://gateway-api.sigs.k8s.io/reference/spec/#tlsroute",
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeIngress,
+			Risk:                    parser.AnnotationRiskLow, // Low, as it allows regexes but on a very limited set
+			Documentation:           `This annotation instructs the controller to send TLS connections directly to the backend instead of letting NGINX decrypt the communication.`,
+			GatewayAPICompatibility: parser.GatewayAPICompatible,
+			GatewayAPI:              "Supported by the TLSRoute API",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#tlsroute",
 		},
 	},
 }
@@ -35,25 +35,37 @@ var (
 
 var UpstreamHashByAnnotations = parser.Annotation{
 	Group: "backend",
+	Rules: []parser.Rule{
+		parser.Requires(upstreamHashBySubsetAnnotation, upstreamHashByAnnotation),
+		parser.Requires(upstreamHashBySubsetSize, upstreamHashBySubsetAnnotation).WithValues("true"),
+		// The balancer uses the sticky session implementation when cookie affinity is enabled
+		parser.IgnoredWhen(upstreamHashByAnnotation, "affinity").WithValues("cookie"),
+	},
 	Annotations: parser.AnnotationFields{
 		upstreamHashByAnnotation: {
 			Validator: parser.ValidateRegex(hashByRegex, true),
+			Format:    parser.RegexFormat(hashByRegex, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskHigh, // High, this annotation allows accessing NGINX variables
 			Documentation: `This annotation defines the nginx variable, text value or any combination thereof to use for consistent hashing. 
 			For example: nginx.ingress.kubernetes.io/upstream-hash-by: "$request_uri" or nginx.ingress.kubernetes.io/upstream-hash-by: "$request_uri$host" or nginx.ingress.kubernetes.io/upstream-hash-by: "${request_uri}-text-value" to consistently hash upstream requests by the current request URI.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		upstreamHashBySubsetAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation maps requests to subset of nodes instead of a single one.`,
+			Validator:               parser.ValidateBool,
+			Format:                  parser.FormatBool,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation maps requests to subset of nodes instead of a single one.`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 		upstreamHashBySubsetSize: {
-			Validator:     parser.ValidateInt,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow,
-			Documentation: `This annotation determines the size of each subset (default 3)`,
+			Validator:               parser.ValidateInt,
+			Format:                  parser.FormatInt,
+			Scope:                   parser.AnnotationScopeLocation,
+			Risk:                    parser.AnnotationRiskLow,
+			Documentation:           `This annotation determines the size of each subset (default 3)`,
+			GatewayAPICompatibility: parser.GatewayAPIIncompatible,
 		},
 	},
 }
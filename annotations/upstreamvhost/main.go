@@ -29,10 +29,14 @@ var UpstreamVhostAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		upstreamVhostAnnotation: {
 			Validator: parser.ValidateServerName,
+			Format:    parser.FormatServerName,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow, // Low, as it allows regexes but on a very limited set
 			Documentation: `This configuration setting allows you to control the value for host in the following statement: proxy_set_header Host $host, which forms part of the location block. 
 			This is useful if you need to call the upstream server by something other than $host`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].urlRewrite.hostname'. It is not translated by the converter",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpurlrewritefilter",
 		},
 	},
 }
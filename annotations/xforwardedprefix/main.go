@@ -29,10 +29,14 @@ var XForwardedForAnnotations = parser.Annotation{
 	Annotations: parser.AnnotationFields{
 		xForwardedForPrefixAnnotation: {
 			Validator: parser.ValidateRegex(parser.RegexPathWithCapture, true),
+			Format:    parser.RegexFormat(parser.RegexPathWithCapture, true),
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskMedium,
 			Documentation: `This annotation can be used to add the non-standard X-Forwarded-Prefix header to the upstream request with a string value. It can 
 			contain regular characters and captured groups specified as '$1', '$2', etc.`,
+			GatewayAPICompatibility: parser.GatewayAPIPartial,
+			GatewayAPI:              "Partially supported by HTTPRoute 'spec.rules[].filters[].requestHeaderModifier'. It is not translated by the converter",
+			GatewayAPIRef:           "https://gateway-api.sigs.k8s.io/reference/spec/#httpheaderfilter",
 		},
 	},
 }
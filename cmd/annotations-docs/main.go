@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command annotations-docs generates the reference documentation of all the
+// annotations, as Markdown, HTML or JSON:
+//
+//	annotations-docs -format html > annotations.html
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	annotations "github.com/rikatz/ingress-nginx-annotations"
+	"github.com/rikatz/ingress-nginx-annotations/docs"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+func main() {
+	format := flag.String("format", "markdown", "output format: markdown, html or json")
+	prefix := flag.String("prefix", parser.DefaultAnnotationsPrefix, "annotations prefix used on the documentation")
+	flag.Parse()
+
+	config := parser.DefaultConfig()
+	config.Prefix = *prefix
+	reference := docs.NewReference(annotations.NewRegistry().WithConfig(config))
+
+	var err error
+	switch *format {
+	case "markdown":
+		err = reference.WriteMarkdown(os.Stdout)
+	case "html":
+		err = reference.WriteHTML(os.Stdout)
+	case "json":
+		err = reference.WriteJSON(os.Stdout)
+	default:
+		err = fmt.Errorf("invalid format %s", *format)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
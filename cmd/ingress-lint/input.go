@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// stdinSource is the name used to read the manifests from stdin
+const stdinSource = "-"
+
+// manifest is an Ingress read from a source
+type manifest struct {
+	// Source is the file the Ingress was read from, or "-" for stdin
+	Source  string
+	Ingress *networking.Ingress
+}
+
+// readSources reads all the Ingresses of the provided files and directories.
+// Directories are walked recursively, reading only YAML and JSON files
+func readSources(sources []string, stdin io.Reader) ([]manifest, error) {
+	manifests := make([]manifest, 0)
+	for _, source := range sources {
+		if source == stdinSource {
+			ingresses, err := readIngresses(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("error reading stdin: %w", err)
+			}
+			manifests = appendManifests(manifests, source, ingresses)
+			continue
+		}
+
+		err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Files are always read when explicitly provided
+			if d.IsDir() || (path != source && !isManifest(path)) {
+				return nil
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			ingresses, err := readIngresses(file)
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", path, err)
+			}
+			manifests = appendManifests(manifests, path, ingresses)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manifests, nil
+}
+
+func appendManifests(manifests []manifest, source string, ingresses []*networking.Ingress) []manifest {
+	for _, ing := range ingresses {
+		manifests = append(manifests, manifest{Source: source, Ingress: ing})
+	}
+	return manifests
+}
+
+func isManifest(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// readIngresses decodes a stream of YAML documents or JSON objects, returning
+// the Ingresses it contains. Lists, like the output of "kubectl get ingress -o yaml",
+// are expanded and any other kind of object is ignored
+func readIngresses(r io.Reader) ([]*networking.Ingress, error) {
+	ingresses := make([]*networking.Ingress, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ingresses, nil
+			}
+			return nil, err
+		}
+		found, err := decodeObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		ingresses = append(ingresses, found...)
+	}
+}
+
+func decodeObject(raw json.RawMessage) ([]*networking.Ingress, error) {
+	// Empty documents, like the ones between "---" separators, are decoded as null
+	if len(bytes.TrimSpace(raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil, nil
+	}
+
+	var object struct {
+		metav1.TypeMeta `json:",inline"`
+		Items           []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasSuffix(object.Kind, "List"):
+		ingresses := make([]*networking.Ingress, 0)
+		for _, item := range object.Items {
+			found, err := decodeObject(item)
+			if err != nil {
+				return nil, err
+			}
+			ingresses = append(ingresses, found...)
+		}
+		return ingresses, nil
+	case object.Kind == "Ingress":
+		ing := &networking.Ingress{}
+		if err := json.Unmarshal(raw, ing); err != nil {
+			return nil, fmt.Errorf("error decoding Ingress: %w", err)
+		}
+		return []*networking.Ingress{ing}, nil
+	default:
+		return nil, nil
+	}
+}
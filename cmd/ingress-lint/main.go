@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command ingress-lint validates the annotations of Ingress manifests.
+//
+// It reads YAML or JSON files, directories and stdin ("-"), including multiple
+// documents and lists, and exits with a non zero code when any error is found:
+//
+//	helm template chart | ingress-lint -o sarif -max-risk High -
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	annotations "github.com/rikatz/ingress-nginx-annotations"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+const (
+	exitOK       = 0
+	exitFindings = 1
+	exitFailure  = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the linter and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ingress-lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ingress-lint [flags] <file|directory|->...\n\n")
+		flags.PrintDefaults()
+	}
+
+	output := flags.String("o", outputHuman, "output format: human, json or sarif")
+	maxRisk := flags.String("max-risk", parser.AnnotationRiskCritical.ToString(), "maximum risk allowed for annotations: Low, Medium, High or Critical")
+	prefix := flags.String("prefix", parser.DefaultAnnotationsPrefix, "annotations prefix used by the controller")
+	allowedGroups := flags.String("allowed-groups", "", "comma separated list of allowed annotation groups. All groups are allowed when empty")
+	reportUnknown := flags.Bool("report-unknown", true, "report annotations using the prefix that do not exist")
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitFailure
+	}
+
+	if *output != outputHuman && *output != outputJSON && *output != outputSARIF {
+		fmt.Fprintf(stderr, "error: invalid output format %s\n", *output)
+		return exitFailure
+	}
+	risk, err := parser.ParseAnnotationRisk(*maxRisk)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return exitFailure
+	}
+
+	config := parser.DefaultConfig()
+	config.Prefix = *prefix
+	config.EnableValidation = true
+	config.MaxRisk = risk
+	config.ReportUnknown = *reportUnknown
+	for _, group := range strings.Split(*allowedGroups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			config.AllowedGroups = append(config.AllowedGroups, parser.AnnotationGroup(group))
+		}
+	}
+
+	manifests, err := readSources(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return exitFailure
+	}
+
+	results, hasErrors, err := lint(manifests, annotations.NewRegistry().WithConfig(config))
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return exitFailure
+	}
+	if err := writeResults(stdout, *output, results); err != nil {
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return exitFailure
+	}
+
+	if hasErrors {
+		return exitFindings
+	}
+	return exitOK
+}
+
+// lint validates each Ingress, returning its findings and if any of them is an error
+func lint(manifests []manifest, registry *parser.Registry) ([]lintResult, bool, error) {
+	results := make([]lintResult, 0, len(manifests))
+	hasErrors := false
+	for _, m := range manifests {
+		result, err := registry.Validate(m.Ingress)
+		if err != nil {
+			return nil, false, err
+		}
+		hasErrors = hasErrors || result.HasErrors()
+		findings := result.Findings
+		if findings == nil {
+			findings = []parser.Finding{}
+		}
+		results = append(results, lintResult{
+			Source:    m.Source,
+			Namespace: m.Ingress.Namespace,
+			Name:      m.Ingress.Name,
+			Findings:  findings,
+		})
+	}
+	return results, hasErrors, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+func TestReadSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []string
+		stdin    string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "list is expanded",
+			sources:  []string{"testdata/list.yaml"},
+			expected: []string{"testdata/list.yaml:valid", "testdata/list.yaml:invalid"},
+		},
+		{
+			name:     "directory reads only manifests and ignores other kinds",
+			sources:  []string{"testdata/chart"},
+			expected: []string{"testdata/chart/ingress.json:json", "testdata/chart/templates.yaml:app"},
+		},
+		{
+			name:    "multiple documents on stdin",
+			sources: []string{"-"},
+			stdin: `kind: Ingress
+metadata:
+  name: first
+---
+kind: Ingress
+metadata:
+  name: second
+`,
+			expected: []string{"-:first", "-:second"},
+		},
+		{
+			name:    "invalid document",
+			sources: []string{"-"},
+			stdin:   "kind: [Ingress",
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			sources: []string{"testdata/missing.yaml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifests, err := readSources(tt.sources, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readSources() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := make([]string, 0, len(manifests))
+			for _, m := range manifests {
+				got = append(got, m.Source+":"+m.Ingress.Name)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+		contains []string
+	}{
+		{
+			name:     "errors return non zero",
+			args:     []string{"testdata/list.yaml"},
+			exitCode: exitFindings,
+			contains: []string{"testdata/list.yaml: default/invalid: Error:", "2 ingresses checked, 1 errors, 0 warnings"},
+		},
+		{
+			name:     "risky annotation is an error",
+			args:     []string{"-max-risk", "High", "testdata/chart"},
+			exitCode: exitFindings,
+			contains: []string{"server-snippet is too risky"},
+		},
+		{
+			name:     "valid manifests",
+			args:     []string{"testdata/chart"},
+			exitCode: exitOK,
+			contains: []string{"2 ingresses checked, 0 errors, 0 warnings"},
+		},
+		{
+			name:     "json output",
+			args:     []string{"-o", "json", "testdata/list.yaml"},
+			exitCode: exitFindings,
+			contains: []string{`"type": "InvalidValue"`, `"name": "valid"`},
+		},
+		{
+			name:     "invalid output",
+			args:     []string{"-o", "xml", "testdata/list.yaml"},
+			exitCode: exitFailure,
+		},
+		{
+			name:     "invalid risk",
+			args:     []string{"-max-risk", "Extreme", "testdata/list.yaml"},
+			exitCode: exitFailure,
+		},
+		{
+			name:     "no sources",
+			exitCode: exitFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(""), &stdout, &stderr); code != tt.exitCode {
+				t.Errorf("expected exit code %d, got %d. stderr: %s", tt.exitCode, code, stderr.String())
+			}
+			for _, expected := range tt.contains {
+				if !strings.Contains(stdout.String(), expected) {
+					t.Errorf("expected output to contain %q, got:\n%s", expected, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunSARIF(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-o", "sarif", "testdata/list.yaml"}, nil, &stdout, &stderr); code != exitFindings {
+		t.Fatalf("expected exit code %d, got %d", exitFindings, code)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF output: %s", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log %+v", log)
+	}
+	if rules := log.Runs[0].Tool.Driver.Rules; len(rules) != len(parser.FindingTypes) {
+		t.Errorf("expected %d rules, got %d", len(parser.FindingTypes), len(rules))
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].RuleID != "InvalidValue" || results[0].Level != "error" {
+		t.Errorf("unexpected result %+v", results[0])
+	}
+	if uri := results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "testdata/list.yaml" {
+		t.Errorf("unexpected location %s", uri)
+	}
+}
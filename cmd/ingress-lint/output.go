@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+const (
+	outputHuman = "human"
+	outputJSON  = "json"
+	outputSARIF = "sarif"
+)
+
+// lintResult contains the findings of a single Ingress
+type lintResult struct {
+	Source    string           `json:"source"`
+	Namespace string           `json:"namespace,omitempty"`
+	Name      string           `json:"name"`
+	Findings  []parser.Finding `json:"findings"`
+}
+
+func (r lintResult) object() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "/" + r.Name
+}
+
+// writeResults prints the results using the output format
+func writeResults(w io.Writer, format string, results []lintResult) error {
+	switch format {
+	case outputHuman:
+		return writeHuman(w, results)
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case outputSARIF:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(toSARIF(results))
+	default:
+		return fmt.Errorf("invalid output format %s", format)
+	}
+}
+
+func writeHuman(w io.Writer, results []lintResult) error {
+	var errors, warnings int
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			if finding.Severity == parser.SeverityError {
+				errors++
+			} else {
+				warnings++
+			}
+			if _, err := fmt.Fprintf(w, "%s: %s: %s\n", result.Source, result.object(), finding); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d ingresses checked, %d errors, %d warnings\n", len(results), errors, warnings)
+	return err
+}
+
+// SARIF 2.1.0 types, containing only the fields used by the linter.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func toSARIF(results []lintResult) sarifLog {
+	rules := make([]sarifRule, 0, len(parser.FindingTypes))
+	for findingType, description := range parser.FindingTypes {
+		rules = append(rules, sarifRule{ID: string(findingType), ShortDescription: sarifMessage{Text: description}})
+	}
+	slices.SortFunc(rules, func(a, b sarifRule) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	sarifResults := make([]sarifResult, 0)
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			level := "warning"
+			if finding.Severity == parser.SeverityError {
+				level = "error"
+			}
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:  string(finding.Type),
+				Level:   level,
+				Message: sarifMessage{Text: fmt.Sprintf("Ingress %s: %s", result.object(), finding.Err())},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: result.Source},
+					},
+					LogicalLocations: []sarifLogicalLocation{{
+						FullyQualifiedName: result.object(),
+						Kind:               "resource",
+					}},
+				}},
+			})
+		}
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "ingress-lint",
+				InformationURI: "https://github.com/rikatz/ingress-nginx-annotations",
+				Rules:          rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+}
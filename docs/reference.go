@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package docs generates the reference documentation of the annotations from
+// the metadata of the registry, as Markdown, HTML or JSON
+package docs
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+// ungrouped is the group name used for the features that do not declare a group
+const ungrouped parser.AnnotationGroup = "ungrouped"
+
+// Reference is the documentation of all the annotations of a registry
+type Reference struct {
+	// Prefix is the prefix used on the annotation names
+	Prefix string `json:"prefix"`
+	// Groups contains the annotations of each group, sorted by group name
+	Groups []GroupReference `json:"groups"`
+}
+
+// GroupReference is the documentation of the annotations of a group
+type GroupReference struct {
+	Name        parser.AnnotationGroup `json:"name"`
+	Annotations []AnnotationReference  `json:"annotations"`
+}
+
+// AnnotationReference is the documentation of a single annotation
+type AnnotationReference struct {
+	// Name is the full name of the annotation, including the prefix
+	Name string `json:"name"`
+	// Feature is the feature that declares the annotation
+	Feature string `json:"feature"`
+	// Aliases are the other full names of the annotation
+	Aliases []string `json:"aliases,omitempty"`
+	// Scope defines where the annotation applies
+	Scope parser.AnnotationScope `json:"scope"`
+	// Risk is the risk of allowing users to set the annotation
+	Risk parser.AnnotationRisk `json:"risk"`
+	// Format describes the values accepted by the annotation validator
+	Format string `json:"format,omitempty"`
+	// Documentation is the description of the annotation
+	Documentation string `json:"documentation"`
+	// Rules describes how the annotation relates to other annotations
+	Rules []string `json:"rules,omitempty"`
+	// GatewayAPICompatibility defines if the annotation can be represented by Gateway API
+	GatewayAPICompatibility parser.GatewayAPICompatibility `json:"gatewayAPICompatibility"`
+	// GatewayAPI describes the Gateway API replacement of the annotation
+	GatewayAPI string `json:"gatewayAPI,omitempty"`
+	// GatewayAPIRef is a link to the documentation of the Gateway API replacement
+	GatewayAPIRef string `json:"gatewayAPIRef,omitempty"`
+}
+
+// NewReference returns the documentation of all the annotations of the registry,
+// using the registry prefix
+func NewReference(registry *parser.Registry) *Reference {
+	config := registry.Config()
+	reference := &Reference{
+		Prefix: config.AnnotationWithPrefix(""),
+		Groups: make([]GroupReference, 0),
+	}
+
+	rules := make(map[string][]string)
+	for _, rule := range registry.Rules() {
+		rules[rule.Annotation] = append(rules[rule.Annotation], rule.String())
+	}
+
+	groups := registry.Groups()
+	// Features without a group are documented at the end
+	if len(groups) > 0 && groups[0] == "" {
+		groups = append(groups[1:], "")
+	}
+	for _, group := range groups {
+		groupReference := GroupReference{Name: group, Annotations: make([]AnnotationReference, 0)}
+		if group == "" {
+			groupReference.Name = ungrouped
+		}
+		for _, ann := range registry.ByGroup(group) {
+			var aliases []string
+			for _, alias := range ann.Config.AnnotationAliases {
+				aliases = append(aliases, config.AnnotationWithPrefix(alias))
+			}
+			groupReference.Annotations = append(groupReference.Annotations, AnnotationReference{
+				Name:                    config.AnnotationWithPrefix(ann.Name),
+				Feature:                 ann.Feature,
+				Aliases:                 aliases,
+				Scope:                   ann.Config.Scope,
+				Risk:                    ann.Config.Risk,
+				Format:                  ann.Config.Format,
+				Documentation:           normalize(ann.Config.Documentation),
+				Rules:                   rules[ann.Name],
+				GatewayAPICompatibility: ann.Config.GatewayAPICompatibility,
+				GatewayAPI:              ann.Config.GatewayAPI,
+				GatewayAPIRef:           ann.Config.GatewayAPIRef,
+			})
+		}
+		reference.Groups = append(reference.Groups, groupReference)
+	}
+	return reference
+}
+
+// WriteJSON writes the reference as indented JSON
+func (r *Reference) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// normalize removes the indentation of the documentation, that is usually
+// declared as a multi line string on the annotation packages
+func normalize(documentation string) string {
+	lines := strings.Split(documentation, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, " "))
+}
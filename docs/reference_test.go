@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+func newTestReference(t *testing.T) *Reference {
+	t.Helper()
+	registry := parser.NewRegistry()
+	if err := registry.Register("limits", parser.Annotation{
+		Group: "rate-limit",
+		Rules: []parser.Rule{parser.Requires("limit-allowlist", "limit-rps")},
+		Annotations: parser.AnnotationFields{
+			"limit-allowlist": {
+				Validator:         parser.ValidateCIDRs,
+				Format:            parser.FormatCIDRs,
+				Scope:             parser.AnnotationScopeLocation,
+				Risk:              parser.AnnotationRiskLow,
+				AnnotationAliases: []string{"limit-whitelist"},
+				Documentation: `This annotation defines the networks
+				that are not rate limited`,
+				GatewayAPICompatibility: parser.GatewayAPIIncompatible,
+			},
+			"limit-rps": {
+				Validator:               parser.ValidateRegex(regexp.MustCompile(`^(\d+|off)$`), true),
+				Format:                  parser.RegexFormat(regexp.MustCompile(`^(\d+|off)$`), true),
+				Scope:                   parser.AnnotationScopeLocation,
+				Risk:                    parser.AnnotationRiskMedium,
+				Documentation:           `This annotation <b>limits</b> requests per second`,
+				GatewayAPICompatibility: parser.GatewayAPIPartial,
+				GatewayAPI:              "Partially supported by extensions",
+				GatewayAPIRef:           "https://example.com/ratelimit",
+			},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error registering feature: %s", err)
+	}
+	if err := registry.Register("passthrough", parser.Annotation{
+		Annotations: parser.AnnotationFields{
+			"ssl-passthrough": {Validator: parser.ValidateBool},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error registering feature: %s", err)
+	}
+	return NewReference(registry)
+}
+
+func TestNewReference(t *testing.T) {
+	reference := newTestReference(t)
+
+	if reference.Prefix != "nginx.ingress.kubernetes.io/" {
+		t.Errorf("unexpected prefix %s", reference.Prefix)
+	}
+	if len(reference.Groups) != 2 || reference.Groups[0].Name != "rate-limit" || reference.Groups[1].Name != ungrouped {
+		t.Fatalf("unexpected groups %+v", reference.Groups)
+	}
+
+	allowlist := reference.Groups[0].Annotations[0]
+	if allowlist.Name != "nginx.ingress.kubernetes.io/limit-allowlist" {
+		t.Errorf("unexpected annotation %s", allowlist.Name)
+	}
+	if len(allowlist.Aliases) != 1 || allowlist.Aliases[0] != "nginx.ingress.kubernetes.io/limit-whitelist" {
+		t.Errorf("unexpected aliases %v", allowlist.Aliases)
+	}
+	if allowlist.Documentation != "This annotation defines the networks that are not rate limited" {
+		t.Errorf("expected documentation without indentation, got %q", allowlist.Documentation)
+	}
+	if allowlist.Format != "comma separated list of IPs or CIDRs" {
+		t.Errorf("unexpected format %q", allowlist.Format)
+	}
+	if len(allowlist.Rules) != 1 || allowlist.Rules[0] != "limit-allowlist requires limit-rps" {
+		t.Errorf("unexpected rules %v", allowlist.Rules)
+	}
+}
+
+func TestWriteReference(t *testing.T) {
+	reference := newTestReference(t)
+
+	tests := []struct {
+		name     string
+		write    func(*bytes.Buffer) error
+		contains []string
+	}{
+		{
+			name:  "markdown",
+			write: func(b *bytes.Buffer) error { return reference.WriteMarkdown(b) },
+			contains: []string{
+				"## rate-limit",
+				"### nginx.ingress.kubernetes.io/limit-rps",
+				"| Aliases | nginx.ingress.kubernetes.io/limit-whitelist |",
+				"| Risk | Medium |",
+				"| Format | `value matching ^(\\d+\\|off)$, after removing spaces` |",
+				"| Gateway API | Partial: Partially supported by extensions ([reference](https://example.com/ratelimit)) |",
+				"## ungrouped",
+			},
+		},
+		{
+			name:  "html",
+			write: func(b *bytes.Buffer) error { return reference.WriteHTML(b) },
+			contains: []string{
+				`<h2 id="group-rate-limit">rate-limit</h2>`,
+				`<p>This annotation &lt;b&gt;limits&lt;/b&gt; requests per second</p>`,
+				`<a href="https://example.com/ratelimit">reference</a>`,
+			},
+		},
+		{
+			name:  "json",
+			write: func(b *bytes.Buffer) error { return reference.WriteJSON(b) },
+			contains: []string{
+				`"name": "nginx.ingress.kubernetes.io/limit-allowlist"`,
+				`"risk": "Medium"`,
+				`"gatewayAPICompatibility": "Partial"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.write(&b); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, expected := range tt.contains {
+				if !strings.Contains(b.String(), expected) {
+					t.Errorf("expected output to contain %q, got:\n%s", expected, b.String())
+				}
+			}
+		})
+	}
+
+	var b bytes.Buffer
+	if err := reference.WriteJSON(&b); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	decoded := &Reference{}
+	if err := json.Unmarshal(b.Bytes(), decoded); err != nil {
+		t.Fatalf("JSON export cannot be decoded: %s", err)
+	}
+	if decoded.Groups[0].Annotations[1].Risk != parser.AnnotationRiskMedium {
+		t.Errorf("expected risk to be decoded, got %v", decoded.Groups[0].Annotations[1].Risk)
+	}
+}
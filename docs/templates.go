@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docs
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+)
+
+var funcs = map[string]any{
+	"join": strings.Join,
+	// cell escapes the pipes of a value used on a Markdown table
+	"cell": func(value string) string {
+		return strings.ReplaceAll(value, "|", `\|`)
+	},
+	// code wraps a value used on a Markdown table on a code span, using a longer
+	// delimiter when it contains backticks
+	"code": func(value string) string {
+		value = strings.ReplaceAll(value, "|", `\|`)
+		if strings.Contains(value, "`") {
+			return "`` " + value + " ``"
+		}
+		return "`" + value + "`"
+	},
+}
+
+var markdownTemplate = texttemplate.Must(texttemplate.New("markdown").Funcs(funcs).Parse(`# Annotations reference
+
+This page is generated from the annotations registry. All the annotations use the prefix ` + "`{{ .Prefix }}`" + `.
+{{ range .Groups }}
+## {{ .Name }}
+{{ range .Annotations }}
+### {{ .Name }}
+
+{{ .Documentation }}
+
+| Field | Value |
+|-------|-------|
+| Feature | {{ .Feature }} |
+{{- if .Aliases }}
+| Aliases | {{ join .Aliases ", " }} |
+{{- end }}
+| Scope | {{ .Scope }} |
+| Risk | {{ .Risk.ToString }} |
+{{- if .Format }}
+| Format | {{ code .Format }} |
+{{- end }}
+| Gateway API | {{ .GatewayAPICompatibility }}{{ if .GatewayAPI }}: {{ cell .GatewayAPI }}{{ end }}{{ if .GatewayAPIRef }} ([reference]({{ .GatewayAPIRef }})){{ end }} |
+{{- range .Rules }}
+| Rule | {{ cell . }} |
+{{- end }}
+{{ end }}
+{{- end }}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Annotations reference</title>
+</head>
+<body>
+<h1>Annotations reference</h1>
+<p>This page is generated from the annotations registry. All the annotations use the prefix <code>{{ .Prefix }}</code>.</p>
+<ul>
+{{- range .Groups }}
+<li><a href="#group-{{ .Name }}">{{ .Name }}</a></li>
+{{- end }}
+</ul>
+{{- range .Groups }}
+<h2 id="group-{{ .Name }}">{{ .Name }}</h2>
+{{- range .Annotations }}
+<h3 id="{{ .Name }}">{{ .Name }}</h3>
+<p>{{ .Documentation }}</p>
+<table>
+<tr><th>Feature</th><td>{{ .Feature }}</td></tr>
+{{- if .Aliases }}
+<tr><th>Aliases</th><td>{{ join .Aliases ", " }}</td></tr>
+{{- end }}
+<tr><th>Scope</th><td>{{ .Scope }}</td></tr>
+<tr><th>Risk</th><td>{{ .Risk.ToString }}</td></tr>
+{{- if .Format }}
+<tr><th>Format</th><td><code>{{ .Format }}</code></td></tr>
+{{- end }}
+<tr><th>Gateway API</th><td>{{ .GatewayAPICompatibility }}{{ if .GatewayAPI }}: {{ .GatewayAPI }}{{ end }}{{ if .GatewayAPIRef }} (<a href="{{ .GatewayAPIRef }}">reference</a>){{ end }}</td></tr>
+{{- range .Rules }}
+<tr><th>Rule</th><td>{{ . }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+</body>
+</html>
+`))
+
+// WriteMarkdown writes the reference as a Markdown page, with a section per group
+func (r *Reference) WriteMarkdown(w io.Writer) error {
+	return markdownTemplate.Execute(w, r)
+}
+
+// WriteHTML writes the reference as a standalone HTML page, with a section per group
+func (r *Reference) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
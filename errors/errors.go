@@ -19,6 +19,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -111,46 +112,135 @@ func Errorf(format string, args ...interface{}) error {
 	return fmt.Errorf(format, args...)
 }
 
+// ValidationError is returned when an annotation contains an invalid value.
+// Besides the reason, it carries the annotation, its value and the feature it
+// belongs to, so callers can render it using errors.As
 type ValidationError struct {
+	// Reason is the error returned by the annotation validator
 	Reason error
-}
-
+	// Annotation is the full name of the annotation
+	Annotation string
+	// Value is the invalid value of the annotation
+	Value string
+	// Feature is the feature that declares the annotation, when known
+	Feature string
+	// Group is the group of the feature that declares the annotation, when known
+	Group string
+	// Risk is the risk of the annotation, when known
+	Risk string
+}
+
+// RiskyAnnotationError is returned when an annotation has a risk higher than the
+// allowed for the environment
 type RiskyAnnotationError struct {
 	Reason error
+	// Annotation is the full name of the annotation
+	Annotation string
+	// Feature is the feature that declares the annotation, when known
+	Feature string
+	// Group is the group of the feature that declares the annotation, when known
+	Group string
+	// Risk is the risk of the annotation
+	Risk string
+	// MaxRisk is the maximum risk allowed for the environment
+	MaxRisk string
 }
 
 func (e ValidationError) Error() string {
-	return e.Reason.Error()
+	switch {
+	case e.Annotation == "" && e.Reason != nil:
+		return e.Reason.Error()
+	case e.Reason == nil:
+		return fmt.Sprintf("annotation %s contains invalid value", e.Annotation)
+	default:
+		return fmt.Sprintf("annotation %s contains invalid value: %s", e.Annotation, e.Reason)
+	}
 }
 
-// NewValidationError returns a new LocationDenied error
+func (e ValidationError) Unwrap() error {
+	return e.Reason
+}
+
+// NewValidationError returns a new ValidationError for an annotation
 func NewValidationError(annotation string) error {
 	return ValidationError{
-		Reason: fmt.Errorf("annotation %s contains invalid value", annotation),
+		Annotation: annotation,
+	}
+}
+
+// NewAnnotationValidationError returns a new ValidationError containing the
+// invalid value and the reason returned by the annotation validator
+func NewAnnotationValidationError(annotation, value string, reason error) error {
+	return ValidationError{
+		Annotation: annotation,
+		Value:      value,
+		Reason:     reason,
 	}
 }
 
 // IsValidationError checks if the err is an error which
 // indicates that some annotation value is invalid
 func IsValidationError(e error) bool {
-	_, ok := e.(ValidationError)
-	return ok
+	var target ValidationError
+	return errors.As(e, &target)
 }
 
-// NewRiskyAnnotations returns a new LocationDenied error
+// NewRiskyAnnotations returns a new RiskyAnnotationError for an annotation group
 func NewRiskyAnnotations(name string) error {
 	return RiskyAnnotationError{
 		Reason: fmt.Errorf("annotation group %s contains risky annotation based on ingress configuration", name),
+		Group:  name,
 	}
 }
 
 // IsRiskyAnnotationError checks if the err is an error which
-// indicates that some annotation value is invalid
+// indicates that some annotation is too risky for the environment
 func IsRiskyAnnotationError(e error) bool {
-	_, ok := e.(ValidationError)
-	return ok
+	var target RiskyAnnotationError
+	return errors.As(e, &target)
 }
 
 func (e RiskyAnnotationError) Error() string {
-	return e.Reason.Error()
+	if e.Reason != nil {
+		return e.Reason.Error()
+	}
+	if e.Risk == "" || e.MaxRisk == "" {
+		return fmt.Sprintf("annotation %s is too risky for environment", e.Annotation)
+	}
+	return fmt.Sprintf("annotation %s is too risky for environment: risk %s is higher than the allowed %s", e.Annotation, e.Risk, e.MaxRisk)
+}
+
+func (e RiskyAnnotationError) Unwrap() error {
+	return e.Reason
+}
+
+// UnknownAnnotationError is returned when an annotation using the controller
+// prefix does not exist
+type UnknownAnnotationError struct {
+	// Annotation is the full name of the unknown annotation
+	Annotation string
+	// Suggestions contains the full name of existing annotations similar to the unknown one
+	Suggestions []string
+}
+
+func (e UnknownAnnotationError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("annotation %s does not exist", e.Annotation)
+	}
+	return fmt.Sprintf("annotation %s does not exist, did you mean %s?", e.Annotation, strings.Join(e.Suggestions, ", "))
+}
+
+// NewUnknownAnnotation returns a new UnknownAnnotationError
+func NewUnknownAnnotation(annotation string, suggestions []string) error {
+	return UnknownAnnotationError{
+		Annotation:  annotation,
+		Suggestions: suggestions,
+	}
+}
+
+// IsUnknownAnnotationError checks if the err is an error which
+// indicates that some annotation does not exist
+func IsUnknownAnnotationError(e error) bool {
+	var target UnknownAnnotationError
+	return errors.As(e, &target)
 }
@@ -50,3 +50,42 @@ func TestInvalidContent(t *testing.T) {
 		t.Error("expected false")
 	}
 }
+
+func TestIsUnknownAnnotation(t *testing.T) {
+	err := NewUnknownAnnotation("nginx.ingress.kubernetes.io/enable-cor", []string{"nginx.ingress.kubernetes.io/enable-cors"})
+	if !IsUnknownAnnotationError(err) {
+		t.Error("expected true")
+	}
+	if err.Error() != "annotation nginx.ingress.kubernetes.io/enable-cor does not exist, did you mean nginx.ingress.kubernetes.io/enable-cors?" {
+		t.Errorf("unexpected message %s", err)
+	}
+	if IsUnknownAnnotationError(ErrMissingAnnotations) {
+		t.Error("expected false")
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := NewAnnotationValidationError("nginx.ingress.kubernetes.io/limit-rps", "xpto", New("not a number"))
+	if !IsValidationError(err) {
+		t.Error("expected true")
+	}
+	if IsRiskyAnnotationError(err) {
+		t.Error("expected false")
+	}
+	if err.Error() != "annotation nginx.ingress.kubernetes.io/limit-rps contains invalid value: not a number" {
+		t.Errorf("unexpected message %s", err)
+	}
+	if !IsValidationError(Errorf("wrapped: %w", err)) {
+		t.Error("expected wrapped error to be a validation error")
+	}
+}
+
+func TestRiskyAnnotationError(t *testing.T) {
+	err := NewRiskyAnnotations("snippets")
+	if !IsRiskyAnnotationError(err) {
+		t.Error("expected true")
+	}
+	if IsValidationError(err) {
+		t.Error("expected false")
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package annotations_test
 
 import (
+	"errors"
 	"testing"
 
 	annotations "github.com/rikatz/ingress-nginx-annotations"
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
 	networking "k8s.io/api/networking/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -40,6 +42,32 @@ func TestExample(t *testing.T) {
 	}
 
 	err := factory.Validate(ing)
-	t.Errorf("an error happened: %s", err)
+	if err == nil {
+		t.Fatal("expected the invalid annotations to be reported")
+	}
+
+	// The registry returns the same validation as structured findings, containing
+	// the feature and group of each annotation
+	result, err := annotations.NewRegistry().Validate(ing)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(result.Errors()) != 2 {
+		t.Fatalf("expected 2 findings, got %v", result.Findings)
+	}
+	for _, finding := range result.Errors() {
+		var validationErr ing_errors.ValidationError
+		if !errors.As(finding.Err(), &validationErr) {
+			t.Errorf("expected a ValidationError, got %T", finding.Err())
+		}
+		if finding.Feature == "" || finding.Group == "" {
+			t.Errorf("expected finding to contain its feature and group: %+v", finding)
+		}
+	}
 
+	// Rules between annotations are reported as warnings, as disable-proxy-intercept-errors
+	// has no effect without custom-http-errors
+	if len(result.Warnings()) != 1 {
+		t.Errorf("expected 1 warning, got %v", result.Warnings())
+	}
 }
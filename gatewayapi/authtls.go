@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/authtls"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	authTLSSecretAnnotation             = "auth-tls-secret"
+	authTLSVerifyClientAnnotation       = "auth-tls-verify-client"
+	authTLSVerifyDepthAnnotation        = "auth-tls-verify-depth"
+	authTLSErrorPageAnnotation          = "auth-tls-error-page"
+	authTLSPassCertToUpstreamAnnotation = "auth-tls-pass-certificate-to-upstream"
+	authTLSMatchCNAnnotation            = "auth-tls-match-cn"
+)
+
+var authTLSAnnotations = []string{
+	authTLSSecretAnnotation,
+	authTLSVerifyClientAnnotation,
+	authTLSVerifyDepthAnnotation,
+	authTLSErrorPageAnnotation,
+	authTLSPassCertToUpstreamAnnotation,
+	authTLSMatchCNAnnotation,
+}
+
+// ClientCertificate is the client certificate validation required by the routes of an
+// Ingress. ingress-nginx configures it on the locations of the Ingress, while Gateway
+// API validates client certificates during the TLS handshake, for all the HTTPS
+// listeners of a Gateway or of one of its ports
+type ClientCertificate struct {
+	// Routes are the names of the HTTPRoutes that require client certificates
+	Routes []string `json:"routes"`
+	// FrontendTLS must be merged into spec.tls.frontend of the Gateways of the routes.
+	// The validation is the default of the Gateway, and can be moved to perPort to
+	// only apply to the port of the listeners
+	FrontendTLS gatewayv1.FrontendTLSConfig `json:"frontendTLS"`
+	// Listener are the settings that ingress-nginx applies to the routes, and that
+	// Gateway API applies to every route attached to the Gateway or port
+	Listener []string `json:"listener,omitempty"`
+}
+
+// FrontendTLSPlan is the client certificate validation of the Gateways shared by the
+// routes of many Ingresses
+type FrontendTLSPlan struct {
+	// Gateways contains the frontend TLS configuration of each Gateway, by namespace/name,
+	// referenced by routes that require client certificates
+	Gateways map[string]*gatewayv1.FrontendTLSConfig `json:"gateways"`
+	// Conflicts are the routes requiring a validation different from the one of their
+	// Gateway, that must be attached to another Gateway or port
+	Conflicts []string `json:"conflicts,omitempty"`
+	// Affected are the routes that do not require client certificates on ingress-nginx,
+	// and are attached to a Gateway that validates them
+	Affected []string `json:"affected,omitempty"`
+}
+
+// translateClientCertificate converts the auth-tls annotations to the frontend TLS
+// validation of the Gateways. The CA certificates are read from a ConfigMap with the
+// name of the secret, as Secrets are not a core CA certificate reference of Gateway API
+func (c *converter) translateClientCertificate(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(authTLSSecretAnnotation); !ok {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, err := authtls.ParseWithConfig(c.ing, c.config)
+	if !config.Enabled() {
+		c.untranslatedAuthTLS(fmt.Sprintf("auth-tls-secret is not valid: %s", err))
+		return routes
+	}
+
+	var mode gatewayv1.FrontendValidationModeType
+	switch config.VerifyClient {
+	case authtls.VerifyClientOff:
+		c.untranslatedAuthTLS("client certificates are not requested when auth-tls-verify-client is off")
+		return routes
+	case authtls.VerifyClientOn:
+		mode = gatewayv1.AllowValidOnly
+	case authtls.VerifyClientOptional:
+		mode = gatewayv1.AllowInsecureFallback
+		c.warn("ingress-nginx rejects invalid client certificates when auth-tls-verify-client is optional, while AllowInsecureFallback accepts the connection")
+	case authtls.VerifyClientOptionalNoCA:
+		mode = gatewayv1.AllowInsecureFallback
+		c.warn("ingress-nginx accepts client certificates not signed by the CA when auth-tls-verify-client is optional_no_ca, and the handling of these certificates by AllowInsecureFallback is implementation specific")
+	}
+
+	for name, reason := range map[string]string{
+		authTLSVerifyDepthAnnotation:        "FrontendTLSValidation does not configure the verification depth",
+		authTLSErrorPageAnnotation:          "Gateway API rejects the TLS handshake, and does not redirect failed validations to an error page",
+		authTLSPassCertToUpstreamAnnotation: "Gateway API does not send the client certificate to the backends",
+		authTLSMatchCNAnnotation:            "Gateway API does not match the subject of the client certificates, that must be checked by the backends",
+	} {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+
+	names := make([]string, 0, len(routes))
+	for _, route := range routes {
+		names = append(names, route.Name)
+	}
+	c.result.ClientCertificate = &ClientCertificate{
+		Routes: names,
+		FrontendTLS: gatewayv1.FrontendTLSConfig{
+			Default: gatewayv1.TLSConfig{
+				Validation: &gatewayv1.FrontendTLSValidation{
+					CACertificateRefs: []gatewayv1.ObjectReference{{
+						Group:     "",
+						Kind:      "ConfigMap",
+						Name:      gatewayv1.ObjectName(config.Secret.Name),
+						Namespace: ptr.To(gatewayv1.Namespace(config.Secret.Namespace)),
+					}},
+					Mode: mode,
+				},
+			},
+		},
+		Listener: []string{
+			fmt.Sprintf("client certificates signed by the CA of secret %s are validated for every route of the Gateway, or of the port with perPort, and not only for routes %v", config.Secret, names),
+			fmt.Sprintf("mode %s applies to every route of the Gateway, or of the port with perPort", mode),
+		},
+	}
+	c.warn("frontend TLS validation references the CA certificates of ConfigMap %s/%s, that must be created with the ca.crt of secret %s", c.ing.Namespace, config.Secret.Name, config.Secret)
+	for _, ref := range c.opts.ParentRefs {
+		if ref.Namespace != nil && string(*ref.Namespace) != c.ing.Namespace {
+			c.warn("Gateway %s/%s needs a ReferenceGrant to read ConfigMap %s/%s", *ref.Namespace, ref.Name, c.ing.Namespace, config.Secret.Name)
+		}
+	}
+	c.translated(authTLSSecretAnnotation)
+	c.translated(authTLSVerifyClientAnnotation)
+	return routes
+}
+
+// untranslatedAuthTLS reports all the auth-tls annotations set on the Ingress as untranslated
+func (c *converter) untranslatedAuthTLS(reason string) {
+	for _, name := range authTLSAnnotations {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+}
+
+// PlanFrontendTLS merges the client certificate validation of the conversions by
+// Gateway. The first validation required on a Gateway is used, and the routes that
+// require a different one are returned as conflicts. The routes of the Gateway that
+// do not require client certificates are returned as affected, except the ssl
+// redirect routes, that are attached to plain HTTP listeners
+func PlanFrontendTLS(conversions ...*Conversion) *FrontendTLSPlan {
+	plan := &FrontendTLSPlan{Gateways: make(map[string]*gatewayv1.FrontendTLSConfig)}
+	// required contains the namespaced name of the routes that require client certificates
+	required := make(map[types.NamespacedName]bool)
+
+	for _, conversion := range conversions {
+		if conversion == nil || conversion.ClientCertificate == nil {
+			continue
+		}
+		for _, route := range conversion.HTTPRoutes {
+			if !slices.Contains(conversion.ClientCertificate.Routes, route.Name) {
+				continue
+			}
+			required[types.NamespacedName{Namespace: route.Namespace, Name: route.Name}] = true
+			for _, gateway := range routeGateways(route) {
+				frontend, ok := plan.Gateways[gateway.String()]
+				if !ok {
+					plan.Gateways[gateway.String()] = conversion.ClientCertificate.FrontendTLS.DeepCopy()
+					continue
+				}
+				if !reflect.DeepEqual(frontend.Default, conversion.ClientCertificate.FrontendTLS.Default) {
+					plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("route %s/%s requires a client certificate validation different from the one of Gateway %s", route.Namespace, route.Name, gateway))
+				}
+			}
+		}
+	}
+
+	for _, conversion := range conversions {
+		if conversion == nil {
+			continue
+		}
+		for _, route := range conversion.HTTPRoutes {
+			if required[types.NamespacedName{Namespace: route.Namespace, Name: route.Name}] || isSSLRedirect(route) {
+				continue
+			}
+			for _, gateway := range routeGateways(route) {
+				if frontend, ok := plan.Gateways[gateway.String()]; ok {
+					plan.Affected = append(plan.Affected, fmt.Sprintf("route %s/%s does not require client certificates, and Gateway %s validates them with mode %s", route.Namespace, route.Name, gateway, frontend.Default.Validation.Mode))
+				}
+			}
+		}
+	}
+	return plan
+}
+
+// routeGateways returns the Gateways the route is attached to
+func routeGateways(route gatewayv1.HTTPRoute) []types.NamespacedName {
+	gateways := make([]types.NamespacedName, 0)
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		gateway := types.NamespacedName{Namespace: route.Namespace, Name: string(ref.Name)}
+		if ref.Namespace != nil {
+			gateway.Namespace = string(*ref.Namespace)
+		}
+		if !slices.Contains(gateways, gateway) {
+			gateways = append(gateways, gateway)
+		}
+	}
+	return gateways
+}
+
+// isSSLRedirect returns if the route only redirects the requests to https
+func isSSLRedirect(route gatewayv1.HTTPRoute) bool {
+	if len(route.Spec.Rules) != 1 || len(route.Spec.Rules[0].BackendRefs) != 0 {
+		return false
+	}
+	for _, filter := range route.Spec.Rules[0].Filters {
+		if filter.RequestRedirect != nil && ptr.Deref(filter.RequestRedirect.Scheme, "") == "https" {
+			return true
+		}
+	}
+	return false
+}
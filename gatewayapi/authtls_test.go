@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConvertClientCertificate(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expected     *gatewayv1.FrontendTLSValidation
+		untranslated []string
+	}{
+		{
+			name: "required certificate",
+			annotations: map[string]string{
+				"auth-tls-secret":                       "default/client-ca",
+				"auth-tls-verify-client":                "on",
+				"auth-tls-verify-depth":                 "2",
+				"auth-tls-pass-certificate-to-upstream": "true",
+				"auth-tls-match-cn":                     "CN=payments",
+			},
+			expected: &gatewayv1.FrontendTLSValidation{
+				CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "client-ca", Namespace: ptr.To[gatewayv1.Namespace]("default")}},
+				Mode:              gatewayv1.AllowValidOnly,
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-tls-match-cn",
+				"nginx.ingress.kubernetes.io/auth-tls-pass-certificate-to-upstream",
+				"nginx.ingress.kubernetes.io/auth-tls-verify-depth",
+			},
+		},
+		{
+			name: "optional certificate",
+			annotations: map[string]string{
+				"auth-tls-secret":        "default/client-ca",
+				"auth-tls-verify-client": "optional",
+			},
+			expected: &gatewayv1.FrontendTLSValidation{
+				CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "client-ca", Namespace: ptr.To[gatewayv1.Namespace]("default")}},
+				Mode:              gatewayv1.AllowInsecureFallback,
+			},
+			untranslated: []string{},
+		},
+		{
+			name: "verification disabled",
+			annotations: map[string]string{
+				"auth-tls-secret":        "default/client-ca",
+				"auth-tls-verify-client": "off",
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-tls-secret",
+				"nginx.ingress.kubernetes.io/auth-tls-verify-client",
+			},
+		},
+		{
+			name: "cross namespace secret",
+			annotations: map[string]string{
+				"auth-tls-secret": "other/client-ca",
+			},
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-tls-secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var validation *gatewayv1.FrontendTLSValidation
+			if conversion.ClientCertificate != nil {
+				validation = conversion.ClientCertificate.FrontendTLS.Default.Validation
+				if !reflect.DeepEqual(conversion.ClientCertificate.Routes, []string{"app-app-example-com"}) {
+					t.Errorf("expected the route of the host, got %v", conversion.ClientCertificate.Routes)
+				}
+			}
+			if !reflect.DeepEqual(validation, tt.expected) {
+				t.Errorf("expected validation %+v, got %+v", tt.expected, validation)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
+
+func TestPlanFrontendTLS(t *testing.T) {
+	opts := Options{ParentRefs: []gatewayv1.ParentReference{{Name: "public", Namespace: ptr.To[gatewayv1.Namespace]("gateways")}}}
+	convert := func(name string, annotations map[string]string) *Conversion {
+		ing := newAppIngress(annotations)
+		ing.Name = name
+		ing.Spec.Rules[0].Host = name + ".example.com"
+		conversion, err := Convert(ing, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return conversion
+	}
+
+	payments := convert("payments", map[string]string{
+		"auth-tls-secret": "default/client-ca",
+		"ssl-redirect":    "true",
+	})
+	billing := convert("billing", map[string]string{
+		"auth-tls-secret":        "default/client-ca",
+		"auth-tls-verify-client": "optional",
+	})
+	shop := convert("shop", map[string]string{})
+
+	plan := PlanFrontendTLS(payments, billing, shop)
+	frontend, ok := plan.Gateways["gateways/public"]
+	if !ok || frontend.Default.Validation.Mode != gatewayv1.AllowValidOnly {
+		t.Fatalf("expected Gateway gateways/public to require valid certificates, got %+v", plan.Gateways)
+	}
+	expectedConflicts := []string{"route default/billing-billing-example-com requires a client certificate validation different from the one of Gateway gateways/public"}
+	if !reflect.DeepEqual(plan.Conflicts, expectedConflicts) {
+		t.Errorf("expected conflicts %v, got %v", expectedConflicts, plan.Conflicts)
+	}
+	expectedAffected := []string{"route default/shop-shop-example-com does not require client certificates, and Gateway gateways/public validates them with mode AllowValidOnly"}
+	if !reflect.DeepEqual(plan.Affected, expectedAffected) {
+		t.Errorf("expected affected %v, got %v", expectedAffected, plan.Affected)
+	}
+}
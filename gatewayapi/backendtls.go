@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/proxyssl"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	backendProtocolAnnotation     = "backend-protocol"
+	proxySSLSecretAnnotation      = "proxy-ssl-secret"
+	proxySSLCiphersAnnotation     = "proxy-ssl-ciphers"
+	proxySSLProtocolsAnnotation   = "proxy-ssl-protocols"
+	proxySSLNameAnnotation        = "proxy-ssl-name"
+	proxySSLVerifyAnnotation      = "proxy-ssl-verify"
+	proxySSLVerifyDepthAnnotation = "proxy-ssl-verify-depth"
+	proxySSLServerNameAnnotation  = "proxy-ssl-server-name"
+)
+
+var proxySSLAnnotations = []string{
+	proxySSLSecretAnnotation,
+	proxySSLCiphersAnnotation,
+	proxySSLProtocolsAnnotation,
+	proxySSLNameAnnotation,
+	proxySSLVerifyAnnotation,
+	proxySSLVerifyDepthAnnotation,
+	proxySSLServerNameAnnotation,
+}
+
+// translateBackendTLS converts the proxy-ssl annotations to a BackendTLSPolicy for each
+// Service used by the Ingress, when proxy-ssl-verify is on. The CA certificates are read
+// from a ConfigMap with the name of the secret, as Secrets are not a core CA certificate
+// reference of Gateway API
+func (c *converter) translateBackendTLS(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(proxySSLSecretAnnotation); !ok {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, err := proxyssl.ParseWithConfig(c.ing, c.config)
+	if !config.Enabled() {
+		c.untranslatedProxySSL(fmt.Sprintf("proxy-ssl-secret is not valid: %s", err))
+		return routes
+	}
+	protocol := strings.ToUpper(strings.TrimSpace(c.values[backendProtocolAnnotation]))
+	if protocol != "HTTPS" && protocol != "GRPCS" {
+		c.untranslatedProxySSL("ingress-nginx only uses TLS to the backends when backend-protocol is HTTPS or GRPCS")
+		return routes
+	}
+
+	if !config.Verify {
+		// A BackendTLSPolicy would reject backends with certificates ingress-nginx accepts
+		c.untranslatedProxySSL("ingress-nginx does not verify the certificate of the backends unless proxy-ssl-verify is on, while BackendTLSPolicy always verifies it, so no BackendTLSPolicy is generated")
+		return routes
+	}
+	c.translated(proxySSLVerifyAnnotation)
+
+	for name, reason := range map[string]string{
+		proxySSLCiphersAnnotation:     "BackendTLSPolicy does not configure the ciphers",
+		proxySSLProtocolsAnnotation:   "BackendTLSPolicy does not configure the TLS versions",
+		proxySSLVerifyDepthAnnotation: "BackendTLSPolicy does not configure the verification depth",
+	} {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+	if config.ServerName {
+		c.translated(proxySSLServerNameAnnotation)
+	} else {
+		c.untranslatedProxySSL("BackendTLSPolicy always sends the hostname as SNI", proxySSLServerNameAnnotation)
+	}
+	c.warnClientCertificate(config.Secret)
+	c.warn("BackendTLSPolicy references the CA certificates of ConfigMap %s/%s, that must be created with the ca.crt of secret %s", c.ing.Namespace, config.Secret.Name, config.Secret)
+
+	for _, service := range c.backendServices() {
+		hostname := config.Name
+		if hostname == "" {
+			hostname = fmt.Sprintf("%s.%s.svc.cluster.local", service, c.ing.Namespace)
+			c.warn("proxy-ssl-name is not set, the certificate of Service %s is verified with the hostname %s", service, hostname)
+		}
+		c.result.BackendTLSPolicies = append(c.result.BackendTLSPolicies, gatewayv1.BackendTLSPolicy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       "BackendTLSPolicy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", c.ing.Name, service),
+				Namespace: c.ing.Namespace,
+			},
+			Spec: gatewayv1.BackendTLSPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: "",
+						Kind:  "Service",
+						Name:  gatewayv1.ObjectName(service),
+					},
+				}},
+				Validation: gatewayv1.BackendTLSPolicyValidation{
+					CACertificateRefs: []gatewayv1.LocalObjectReference{{
+						Group: "",
+						Kind:  "ConfigMap",
+						Name:  gatewayv1.ObjectName(config.Secret.Name),
+					}},
+					Hostname: gatewayv1.PreciseHostname(hostname),
+				},
+			},
+		})
+	}
+	c.translated(proxySSLSecretAnnotation)
+	c.translated(proxySSLNameAnnotation)
+	if protocol == "HTTPS" {
+		// GRPCS backends also need a GRPCRoute, that is not generated
+		c.translated(backendProtocolAnnotation)
+	}
+	return routes
+}
+
+// warnClientCertificate warns that the client certificate of the Secret is not sent to
+// the backends. When the Secret cannot be read, the warning is conditional
+func (c *converter) warnClientCertificate(name types.NamespacedName) {
+	if c.opts.Secrets != nil {
+		if secret, err := c.opts.Secrets.GetSecret(name.Namespace, name.Name); err == nil {
+			if _, ok := secret.Data[corev1.TLSCertKey]; ok {
+				c.warn("ingress-nginx sends tls.crt of secret %s as client certificate, that BackendTLSPolicy cannot configure", name)
+			}
+			return
+		}
+	}
+	c.warn("if secret %s contains a tls.crt, ingress-nginx sends it as client certificate, that BackendTLSPolicy cannot configure", name)
+}
+
+// untranslatedProxySSL reports proxy-ssl annotations set on the Ingress as untranslated.
+// When names is empty, all the proxy-ssl annotations are reported
+func (c *converter) untranslatedProxySSL(reason string, names ...string) {
+	if len(names) == 0 {
+		names = proxySSLAnnotations
+	}
+	for _, name := range names {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+}
+
+// backendServices returns the sorted names of the Services used by the Ingress
+func (c *converter) backendServices() []string {
+	services := make([]string, 0)
+	add := func(backend *networking.IngressBackend) {
+		if backend != nil && backend.Service != nil && !slices.Contains(services, backend.Service.Name) {
+			services = append(services, backend.Service.Name)
+		}
+	}
+	add(c.ing.Spec.DefaultBackend)
+	for _, rule := range c.ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			add(&path.Backend)
+		}
+	}
+	slices.Sort(services)
+	return services
+}
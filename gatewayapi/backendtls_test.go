@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/references"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConvertBackendTLS(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expected     []gatewayv1.BackendTLSPolicySpec
+		untranslated []string
+	}{
+		{
+			name: "verified backend",
+			annotations: map[string]string{
+				"backend-protocol":       "HTTPS",
+				"proxy-ssl-secret":       "default/backend-tls",
+				"proxy-ssl-name":         "api.internal",
+				"proxy-ssl-verify":       "on",
+				"proxy-ssl-server-name":  "on",
+				"proxy-ssl-verify-depth": "2",
+				"proxy-ssl-ciphers":      "HIGH",
+			},
+			expected: []gatewayv1.BackendTLSPolicySpec{{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Kind: "Service", Name: "api"},
+				}},
+				Validation: gatewayv1.BackendTLSPolicyValidation{
+					CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "backend-tls"}},
+					Hostname:          "api.internal",
+				},
+			}},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/proxy-ssl-ciphers",
+				"nginx.ingress.kubernetes.io/proxy-ssl-verify-depth",
+			},
+		},
+		{
+			name: "verified backend uses the service hostname",
+			annotations: map[string]string{
+				"backend-protocol": "https",
+				"proxy-ssl-secret": "default/backend-tls",
+				"proxy-ssl-verify": "on",
+			},
+			expected: []gatewayv1.BackendTLSPolicySpec{{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Kind: "Service", Name: "api"},
+				}},
+				Validation: gatewayv1.BackendTLSPolicyValidation{
+					CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "backend-tls"}},
+					Hostname:          "api.default.svc.cluster.local",
+				},
+			}},
+			untranslated: []string{},
+		},
+		{
+			name: "unverified backend",
+			annotations: map[string]string{
+				"backend-protocol": "HTTPS",
+				"proxy-ssl-secret": "default/backend-tls",
+				"proxy-ssl-name":   "api.internal",
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/backend-protocol",
+				"nginx.ingress.kubernetes.io/proxy-ssl-name",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret",
+			},
+		},
+		{
+			name: "verification explicitly disabled",
+			annotations: map[string]string{
+				"backend-protocol": "HTTPS",
+				"proxy-ssl-secret": "default/backend-tls",
+				"proxy-ssl-verify": "off",
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/backend-protocol",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret",
+				"nginx.ingress.kubernetes.io/proxy-ssl-verify",
+			},
+		},
+		{
+			name: "plain text backend",
+			annotations: map[string]string{
+				"proxy-ssl-secret": "default/backend-tls",
+			},
+			untranslated: []string{"nginx.ingress.kubernetes.io/proxy-ssl-secret"},
+		},
+		{
+			name: "cross namespace secret",
+			annotations: map[string]string{
+				"backend-protocol": "HTTPS",
+				"proxy-ssl-secret": "other/backend-tls",
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/backend-protocol",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			specs := make([]gatewayv1.BackendTLSPolicySpec, 0)
+			for _, policy := range conversion.BackendTLSPolicies {
+				specs = append(specs, policy.Spec)
+			}
+			if len(tt.expected) == 0 {
+				tt.expected = []gatewayv1.BackendTLSPolicySpec{}
+			}
+			if !reflect.DeepEqual(specs, tt.expected) {
+				t.Errorf("expected policies %+v, got %+v", tt.expected, specs)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
+
+func TestConvertBackendTLSClientCertificate(t *testing.T) {
+	annotations := map[string]string{
+		"backend-protocol": "HTTPS",
+		"proxy-ssl-secret": "default/backend-tls",
+		"proxy-ssl-verify": "on",
+	}
+	tests := []struct {
+		name     string
+		secrets  SecretGetter
+		expected string
+	}{
+		{
+			name:     "secret cannot be read",
+			expected: "if secret default/backend-tls contains a tls.crt, ingress-nginx sends it as client certificate, that BackendTLSPolicy cannot configure",
+		},
+		{
+			name: "secret with client certificate",
+			secrets: references.NewFake(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend-tls", Namespace: "default"},
+				Data:       map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("cert"), "tls.key": []byte("key")},
+			}),
+			expected: "ingress-nginx sends tls.crt of secret default/backend-tls as client certificate, that BackendTLSPolicy cannot configure",
+		},
+		{
+			name: "secret with only the CA certificates",
+			secrets: references.NewFake(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend-tls", Namespace: "default"},
+				Data:       map[string][]byte{"ca.crt": []byte("ca")},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(annotations), Options{Secrets: tt.secrets})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			warnings := slices.DeleteFunc(slices.Clone(conversion.Warnings), func(warning string) bool {
+				return !strings.Contains(warning, "client certificate")
+			})
+			expected := []string{}
+			if tt.expected != "" {
+				expected = []string{tt.expected}
+			}
+			if !reflect.DeepEqual(warnings, expected) {
+				t.Errorf("expected warnings %q, got %q", expected, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/auth"
+	"github.com/rikatz/ingress-nginx-annotations/gatewayapi/envoygateway"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	authTypeAnnotation       = "auth-type"
+	authSecretAnnotation     = "auth-secret"
+	authSecretTypeAnnotation = "auth-secret-type"
+	authRealmAnnotation      = "auth-realm"
+
+	// htpasswdKey is the key of the htpasswd file on the Envoy Gateway basic auth Secrets
+	htpasswdKey = ".htpasswd"
+)
+
+var basicAuthAnnotations = []string{
+	authTypeAnnotation,
+	authSecretAnnotation,
+	authSecretTypeAnnotation,
+	authRealmAnnotation,
+}
+
+// translateBasicAuth converts basic authentication to the basicAuth of the Envoy Gateway
+// SecurityPolicy of the Ingress, with a Secret containing the users of auth-secret
+// in the htpasswd format of Envoy Gateway. Only {SHA} and {PLAIN} passwords can be
+// converted, the other users must reset their passwords. Digest authentication cannot
+// be migrated, as Gateway API and Envoy Gateway do not support it
+func (c *converter) translateBasicAuth(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(authTypeAnnotation); !ok {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, err := auth.ParseWithConfig(c.ing, c.config)
+	switch {
+	case !config.Enabled():
+		c.untranslatedBasicAuth(fmt.Sprintf("auth-type and auth-secret are not valid: %v", err))
+		return routes
+	case config.Type == auth.TypeDigest:
+		c.untranslatedBasicAuth("digest authentication cannot be migrated, as it is not supported by Gateway API implementations, and the digest hashes cannot be converted to basic authentication passwords")
+		return routes
+	case !c.opts.EnvoyGateway:
+		c.untranslatedBasicAuth("Gateway API does not define basic authentication, that requires an implementation specific policy like the Envoy Gateway SecurityPolicy")
+		return routes
+	case c.opts.Secrets == nil:
+		c.untranslatedBasicAuth("Secrets cannot be read by the converter")
+		return routes
+	}
+
+	secret, err := c.opts.Secrets.GetSecret(config.Secret.Namespace, config.Secret.Name)
+	if err != nil {
+		c.untranslatedBasicAuth(fmt.Sprintf("error reading Secret %s: %s", config.Secret, err))
+		return routes
+	}
+	users, err := config.ParseCredentials(secret)
+	if err != nil {
+		c.warn("entries of Secret %s were not converted: %s", config.Secret, err)
+	}
+	lines := make([]string, 0, len(users))
+	for _, user := range users {
+		hash, ok := user.SHA()
+		if !ok {
+			c.warn("password of user %s uses the %s format, that Envoy Gateway does not support, and must be reset with a {SHA} hash", user.Name, user.Format)
+			continue
+		}
+		if user.Format == auth.HashPlain {
+			c.warn("plain text password of user %s is converted to a {SHA} hash", user.Name)
+		}
+		lines = append(lines, user.Name+":"+hash)
+	}
+	if len(lines) == 0 {
+		c.untranslatedBasicAuth(fmt.Sprintf("Secret %s does not contain any user with a password supported by Envoy Gateway", config.Secret))
+		return routes
+	}
+
+	name := fmt.Sprintf("%s-basic-auth", c.ing.Name)
+	c.result.Secrets = append(c.result.Secrets, corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.ing.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			htpasswdKey: []byte(strings.Join(lines, "\n") + "\n"),
+		},
+	})
+	c.securityPolicy(routes).Spec.BasicAuth = &envoygateway.BasicAuth{
+		Users: gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(name)},
+	}
+	if _, ok := c.value(authRealmAnnotation); ok {
+		c.untranslated(authRealmAnnotation, "Envoy Gateway does not configure the realm of basic authentication")
+	}
+	c.translated(authTypeAnnotation)
+	c.translated(authSecretAnnotation)
+	c.translated(authSecretTypeAnnotation)
+	return routes
+}
+
+// untranslatedBasicAuth reports all the authentication annotations set on the Ingress
+// as untranslated. The routes are not authenticated anymore
+func (c *converter) untranslatedBasicAuth(reason string) {
+	for _, name := range basicAuthAnnotations {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+	c.warn("authentication was not converted, and the routes of Ingress %s/%s are not authenticated", c.ing.Namespace, c.ing.Name)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/references"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertBasicAuth(t *testing.T) {
+	secrets := references.NewFake(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "default"},
+			Data: map[string][]byte{
+				"auth": []byte("bob:{PLAIN}secret\nalice:{SHA}0DPiKuNIrrVmD8IUCuw1hQxNqZc=\ncarol:$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/\n"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bcrypt", Namespace: "default"},
+			Data: map[string][]byte{
+				"auth": []byte("carol:$2y$05$c4WoMPo3SXsafkva.HHa6uXQZWr7oboPiC2bT/r7q1BB8I2s0BRqC\n"),
+			},
+		},
+	)
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		opts         Options
+		htpasswd     string
+		untranslated []string
+	}{
+		{
+			name: "basic auth file",
+			annotations: map[string]string{
+				"auth-type":   "basic",
+				"auth-secret": "users",
+				"auth-realm":  "Authentication Required",
+			},
+			opts:         Options{EnvoyGateway: true, Secrets: secrets},
+			htpasswd:     "alice:{SHA}0DPiKuNIrrVmD8IUCuw1hQxNqZc=\nbob:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=\n",
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-realm"},
+		},
+		{
+			name: "no supported passwords",
+			annotations: map[string]string{
+				"auth-type":   "basic",
+				"auth-secret": "bcrypt",
+			},
+			opts: Options{EnvoyGateway: true, Secrets: secrets},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/auth-type",
+			},
+		},
+		{
+			name: "digest",
+			annotations: map[string]string{
+				"auth-type":   "digest",
+				"auth-secret": "users",
+			},
+			opts: Options{EnvoyGateway: true, Secrets: secrets},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/auth-type",
+			},
+		},
+		{
+			name: "without Envoy Gateway",
+			annotations: map[string]string{
+				"auth-type":   "basic",
+				"auth-secret": "users",
+			},
+			opts: Options{Secrets: secrets},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/auth-type",
+			},
+		},
+		{
+			name: "missing secret",
+			annotations: map[string]string{
+				"auth-type":   "basic",
+				"auth-secret": "missing",
+			},
+			opts: Options{EnvoyGateway: true, Secrets: secrets},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/auth-type",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.htpasswd == "" {
+				if len(conversion.Secrets) != 0 || len(conversion.SecurityPolicies) != 0 {
+					t.Errorf("expected no Secrets and policies, got %+v and %+v", conversion.Secrets, conversion.SecurityPolicies)
+				}
+			} else {
+				if len(conversion.Secrets) != 1 || len(conversion.SecurityPolicies) != 1 {
+					t.Fatalf("expected one Secret and one policy, got %+v and %+v", conversion.Secrets, conversion.SecurityPolicies)
+				}
+				secret := conversion.Secrets[0]
+				if secret.Name != "app-basic-auth" || secret.Type != corev1.SecretTypeOpaque || string(secret.Data[".htpasswd"]) != tt.htpasswd {
+					t.Errorf("unexpected Secret %s %s %q", secret.Name, secret.Type, secret.Data[".htpasswd"])
+				}
+				basicAuth := conversion.SecurityPolicies[0].Spec.BasicAuth
+				if basicAuth == nil || basicAuth.Users.Name != "app-basic-auth" {
+					t.Errorf("expected basicAuth to reference the Secret, got %+v", basicAuth)
+				}
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
+
+func TestConvertBasicAndExternalAuth(t *testing.T) {
+	conversion, err := Convert(newAppIngress(map[string]string{
+		"auth-type":   "basic",
+		"auth-secret": "users",
+		"auth-url":    "http://auth.default.svc/verify",
+	}), Options{EnvoyGateway: true, Secrets: references.NewFake(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "default"},
+		Data:       map[string][]byte{"auth": []byte("bob:{PLAIN}secret")},
+	})})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.SecurityPolicies) != 1 {
+		t.Fatalf("expected a single SecurityPolicy, got %d", len(conversion.SecurityPolicies))
+	}
+	spec := conversion.SecurityPolicies[0].Spec
+	if spec.BasicAuth == nil || spec.ExtAuth == nil {
+		t.Errorf("expected basicAuth and extAuth on the same policy, got %+v", spec)
+	}
+}
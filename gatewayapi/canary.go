@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/canary"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	canaryAnnotation = "canary"
+
+	// canaryAlways and canaryNever are the header and cookie values that always, or
+	// never, route the request to the canary
+	canaryAlways = "always"
+	canaryNever  = "never"
+)
+
+// CanaryConversion contains the HTTPRoute rules equivalent to a canary traffic split
+type CanaryConversion struct {
+	// Rules replace the rule of the primary path, in the order they must be declared
+	Rules []gatewayv1.HTTPRouteRule `json:"rules"`
+	// Warnings are translations that do not keep exactly the same behavior of ingress-nginx
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ConvertCanary converts a canary traffic split to the rules replacing the rule of the
+// primary path. The header and cookie conditions become rules with header matches,
+// routed to the canary or, for the never value, to the primary backend. Gateway API
+// evaluates rules with more header matches first, and rules with the same number of
+// matches in the order they are declared, that keeps the precedence of ingress-nginx.
+// The remaining requests use the primary rule, with weighted backends when the canary
+// has a weight. Matches, filters and timeouts of the primary rule are kept on all the
+// rules, as ingress-nginx uses the configuration of the primary location for the canary
+func ConvertCanary(split canary.TrafficSplit, primary gatewayv1.HTTPRouteRule) (*CanaryConversion, error) {
+	if split.CanaryBackend.Service == nil {
+		return nil, fmt.Errorf("backend of canary %s is not a Service", split.Canary)
+	}
+	conversion := &CanaryConversion{Rules: make([]gatewayv1.HTTPRouteRule, 0)}
+	warn := func(format string, args ...any) {
+		conversion.Warnings = append(conversion.Warnings, fmt.Sprintf(format, args...))
+	}
+	canaryRef := serviceBackendRef(split.CanaryBackend.Service)
+	if split.CanaryBackend.Service.Port.Number == 0 {
+		warn("canary service %s uses the named port %s, that must be replaced by its number", split.CanaryBackend.Service.Name, split.CanaryBackend.Service.Port.Name)
+	}
+
+	// rule returns a copy of the primary rule matching the header, routed to the backend
+	rule := func(header gatewayv1.HTTPHeaderMatch, backends []gatewayv1.HTTPBackendRef) gatewayv1.HTTPRouteRule {
+		r := *primary.DeepCopy()
+		r.Name = nil
+		r.BackendRefs = backends
+		if len(r.Matches) == 0 {
+			r.Matches = []gatewayv1.HTTPRouteMatch{{}}
+		}
+		for i := range r.Matches {
+			r.Matches[i].Headers = append(r.Matches[i].Headers, header)
+		}
+		return r
+	}
+	toCanary := []gatewayv1.HTTPBackendRef{canaryRef}
+	toPrimary := slices.Clone(primary.BackendRefs)
+
+	final := *primary.DeepCopy()
+	for _, canaryRule := range split.Rules {
+		switch canaryRule.Type {
+		case canary.RuleHeaderValue:
+			conversion.Rules = append(conversion.Rules, rule(exactHeader(canaryRule.Name, canaryRule.Value), toCanary))
+		case canary.RuleHeaderPattern:
+			if _, err := regexp.Compile(canaryRule.Value); err != nil {
+				warn("canary-by-header-pattern %s is not a valid RE2 expression and must be rewritten: %s", canaryRule.Value, err)
+			} else {
+				warn("canary-by-header-pattern %s is a PCRE expression, and the regular expression syntax of Gateway API is implementation specific", canaryRule.Value)
+			}
+			conversion.Rules = append(conversion.Rules, rule(gatewayv1.HTTPHeaderMatch{
+				Type:  ptr.To(gatewayv1.HeaderMatchRegularExpression),
+				Name:  gatewayv1.HTTPHeaderName(canaryRule.Name),
+				Value: canaryRule.Value,
+			}, toCanary))
+		case canary.RuleHeader:
+			warn("canary-by-header %s only honors the values %s and %s, converted to exact matches. Requests with %s skip the cookie and weight only because its rule is declared first",
+				canaryRule.Name, canaryAlways, canaryNever, canaryNever)
+			conversion.Rules = append(conversion.Rules,
+				rule(exactHeader(canaryRule.Name, canaryAlways), toCanary),
+				rule(exactHeader(canaryRule.Name, canaryNever), toPrimary),
+			)
+		case canary.RuleCookie:
+			warn("canary-by-cookie %s is approximated by a regular expression on the Cookie header, that does not match cookies sent on more than one Cookie header", canaryRule.Name)
+			conversion.Rules = append(conversion.Rules,
+				rule(cookieHeader(canaryRule.Name, canaryAlways), toCanary),
+				rule(cookieHeader(canaryRule.Name, canaryNever), toPrimary),
+			)
+		case canary.RuleWeight:
+			if len(primary.BackendRefs) != 1 {
+				return nil, fmt.Errorf("primary rule of %s must have a single backend to be weighted", split.Primary)
+			}
+			primaryRef := *primary.BackendRefs[0].DeepCopy()
+			primaryRef.Weight = ptr.To(int32(canaryRule.WeightTotal - canaryRule.Weight)) //#nosec G115
+			weightedCanary := *canaryRef.DeepCopy()
+			weightedCanary.Weight = ptr.To(int32(canaryRule.Weight)) //#nosec G115
+			final.BackendRefs = []gatewayv1.HTTPBackendRef{primaryRef, weightedCanary}
+		}
+	}
+	conversion.Rules = append(conversion.Rules, final)
+	return conversion, nil
+}
+
+// translateCanary reports the annotations of canary Ingresses, that can only be converted
+// together with the primary Ingress
+func (c *converter) translateCanary(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	value, ok := c.value(canaryAnnotation)
+	if !ok {
+		return routes
+	}
+	if !strings.EqualFold(strings.TrimSpace(value), "true") {
+		c.translated(canaryAnnotation)
+		return routes
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.values)) {
+		if ann, ok := c.registry.Lookup(name); ok && ann.Group == canary.CanaryAnnotations.Group {
+			c.untranslated(name, "canary Ingresses are converted together with the primary Ingress, using canary.Pair and Conversion.ApplyCanary")
+		}
+	}
+	c.warn("Ingress %s/%s is a canary, and its routes must not be used", c.ing.Namespace, c.ing.Name)
+	return routes
+}
+
+// ApplyCanary replaces the rule of the primary path of the split, on the routes of the
+// conversion of the primary Ingress, by the rules of the canary
+func (c *Conversion) ApplyCanary(split canary.TrafficSplit) error {
+	for i := range c.HTTPRoutes {
+		route := &c.HTTPRoutes[i]
+		if !routeServesHost(route, split.Host, split.DefaultBackend) {
+			continue
+		}
+		for j, rule := range route.Spec.Rules {
+			if !c.ruleServesPath(route.Name, j, rule, split) {
+				continue
+			}
+			conversion, err := ConvertCanary(split, rule)
+			if err != nil {
+				return err
+			}
+			route.Spec.Rules = slices.Replace(route.Spec.Rules, j, j+1, conversion.Rules...)
+			if paths, ok := c.sourcePaths[route.Name]; ok && j < len(paths) {
+				c.sourcePaths[route.Name] = slices.Replace(paths, j, j+1, slices.Repeat([]string{paths[j]}, len(conversion.Rules))...)
+			}
+			c.Warnings = append(c.Warnings, conversion.Warnings...)
+			return nil
+		}
+	}
+	if split.DefaultBackend {
+		return fmt.Errorf("default backend of %s not found on the converted routes", split.Primary)
+	}
+	return fmt.Errorf("host %s path %s of %s not found on the converted routes", split.Host, split.Path, split.Primary)
+}
+
+// routeServesHost returns if the route is the one generated for the Ingress host
+func routeServesHost(route *gatewayv1.HTTPRoute, host string, defaultBackend bool) bool {
+	if defaultBackend || host == "" {
+		return len(route.Spec.Hostnames) == 0
+	}
+	return len(route.Spec.Hostnames) > 0 && route.Spec.Hostnames[0] == gatewayv1.Hostname(host)
+}
+
+// ruleServesPath returns if the rule is the one generated for the primary path of the split.
+// Rules are matched by their Ingress path, as the path of the matches may be rewritten
+func (c *Conversion) ruleServesPath(route string, index int, rule gatewayv1.HTTPRouteRule, split canary.TrafficSplit) bool {
+	if split.DefaultBackend {
+		return len(rule.Matches) == 0
+	}
+	paths, ok := c.sourcePaths[route]
+	if !ok || index >= len(paths) || paths[index] != split.Path {
+		return false
+	}
+	for _, match := range rule.Matches {
+		if len(match.Headers) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func exactHeader(name, value string) gatewayv1.HTTPHeaderMatch {
+	return gatewayv1.HTTPHeaderMatch{
+		Type:  ptr.To(gatewayv1.HeaderMatchExact),
+		Name:  gatewayv1.HTTPHeaderName(name),
+		Value: value,
+	}
+}
+
+// cookieHeader matches the Cookie header containing the cookie with the value
+func cookieHeader(name, value string) gatewayv1.HTTPHeaderMatch {
+	return gatewayv1.HTTPHeaderMatch{
+		Type:  ptr.To(gatewayv1.HeaderMatchRegularExpression),
+		Name:  "Cookie",
+		Value: fmt.Sprintf(`(^|;\s*)%s=%s(;|$)`, regexp.QuoteMeta(name), regexp.QuoteMeta(value)),
+	}
+}
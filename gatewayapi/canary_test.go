@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/canary"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func newCanaryIngress(annotations map[string]string) *networking.Ingress {
+	ing := newAppIngress(annotations)
+	ing.Name = "app-canary"
+	ing.Annotations["nginx.ingress.kubernetes.io/canary"] = "true"
+	ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name = "api-canary"
+	return ing
+}
+
+func TestApplyCanary(t *testing.T) {
+	pathMatch := &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/api")}
+	primaryRef := gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+		Name: "api", Port: ptr.To(gatewayv1.PortNumber(8080)),
+	}}}
+	canaryRef := gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+		Name: "api-canary", Port: ptr.To(gatewayv1.PortNumber(8080)),
+	}}}
+	weighted := func(ref gatewayv1.HTTPBackendRef, weight int32) gatewayv1.HTTPBackendRef {
+		ref.Weight = ptr.To(weight)
+		return ref
+	}
+	headerRule := func(header gatewayv1.HTTPHeaderMatch, backend gatewayv1.HTTPBackendRef) gatewayv1.HTTPRouteRule {
+		return gatewayv1.HTTPRouteRule{
+			Matches:     []gatewayv1.HTTPRouteMatch{{Path: pathMatch, Headers: []gatewayv1.HTTPHeaderMatch{header}}},
+			BackendRefs: []gatewayv1.HTTPBackendRef{backend},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []gatewayv1.HTTPRouteRule
+		warnings    []string
+	}{
+		{
+			name: "weight",
+			annotations: map[string]string{
+				"canary-weight":       "5",
+				"canary-weight-total": "50",
+			},
+			expected: []gatewayv1.HTTPRouteRule{{
+				Matches:     []gatewayv1.HTTPRouteMatch{{Path: pathMatch}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{weighted(primaryRef, 45), weighted(canaryRef, 5)},
+			}},
+		},
+		{
+			name: "header value and weight",
+			annotations: map[string]string{
+				"canary-by-header":       "X-Canary",
+				"canary-by-header-value": "yes",
+				"canary-weight":          "10",
+			},
+			expected: []gatewayv1.HTTPRouteRule{
+				headerRule(exactHeader("X-Canary", "yes"), canaryRef),
+				{
+					Matches:     []gatewayv1.HTTPRouteMatch{{Path: pathMatch}},
+					BackendRefs: []gatewayv1.HTTPBackendRef{weighted(primaryRef, 90), weighted(canaryRef, 10)},
+				},
+			},
+		},
+		{
+			name: "header and cookie with magic values",
+			annotations: map[string]string{
+				"canary-by-header": "X-Canary",
+				"canary-by-cookie": "use_canary",
+			},
+			expected: []gatewayv1.HTTPRouteRule{
+				headerRule(exactHeader("X-Canary", "always"), canaryRef),
+				headerRule(exactHeader("X-Canary", "never"), primaryRef),
+				headerRule(gatewayv1.HTTPHeaderMatch{
+					Type: ptr.To(gatewayv1.HeaderMatchRegularExpression), Name: "Cookie", Value: `(^|;\s*)use_canary=always(;|$)`,
+				}, canaryRef),
+				headerRule(gatewayv1.HTTPHeaderMatch{
+					Type: ptr.To(gatewayv1.HeaderMatchRegularExpression), Name: "Cookie", Value: `(^|;\s*)use_canary=never(;|$)`,
+				}, primaryRef),
+				{
+					Matches:     []gatewayv1.HTTPRouteMatch{{Path: pathMatch}},
+					BackendRefs: []gatewayv1.HTTPBackendRef{primaryRef},
+				},
+			},
+			warnings: []string{"only honors the values always and never", "approximated by a regular expression"},
+		},
+		{
+			name: "header pattern",
+			annotations: map[string]string{
+				"canary-by-header":         "X-Canary",
+				"canary-by-header-pattern": `^(beta|canary)$`,
+			},
+			expected: []gatewayv1.HTTPRouteRule{
+				headerRule(gatewayv1.HTTPHeaderMatch{
+					Type: ptr.To(gatewayv1.HeaderMatchRegularExpression), Name: "X-Canary", Value: `^(beta|canary)$`,
+				}, canaryRef),
+				{
+					Matches:     []gatewayv1.HTTPRouteMatch{{Path: pathMatch}},
+					BackendRefs: []gatewayv1.HTTPBackendRef{primaryRef},
+				},
+			},
+			warnings: []string{"regular expression syntax of Gateway API is implementation specific"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := newAppIngress(map[string]string{})
+			plan := canary.Pair([]*networking.Ingress{primary, newCanaryIngress(tt.annotations)})
+			if plan.HasErrors() || len(plan.Splits) != 1 {
+				t.Fatalf("unexpected pairing %+v", plan)
+			}
+
+			conversion, err := Convert(primary, Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := conversion.ApplyCanary(plan.Splits[0]); err != nil {
+				t.Fatalf("unexpected error applying canary: %s", err)
+			}
+			rules := conversion.HTTPRoutes[0].Spec.Rules
+			if !reflect.DeepEqual(rules, tt.expected) {
+				t.Errorf("expected rules %+v, got %+v", tt.expected, rules)
+			}
+			warnings := strings.Join(conversion.Warnings, "\n")
+			for _, expected := range tt.warnings {
+				if !strings.Contains(warnings, expected) {
+					t.Errorf("expected a warning containing %q, got:\n%s", expected, warnings)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertCanaryIngress(t *testing.T) {
+	conversion, err := Convert(newCanaryIngress(map[string]string{
+		"canary-weight": "10",
+	}), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.Untranslated) != 2 {
+		t.Fatalf("expected canary annotations to be untranslated, got %+v", conversion.Untranslated)
+	}
+	for _, ann := range conversion.Untranslated {
+		if !strings.Contains(ann.Reason, "ApplyCanary") {
+			t.Errorf("unexpected reason %s for %s", ann.Reason, ann.Annotation)
+		}
+	}
+}
+
+func TestApplyCanaryMissingPath(t *testing.T) {
+	primary := newAppIngress(map[string]string{})
+	conversion, err := Convert(primary, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = conversion.ApplyCanary(canary.TrafficSplit{
+		Host:          "app.example.com",
+		Path:          "/other",
+		CanaryBackend: networking.IngressBackend{Service: &networking.IngressServiceBackend{Name: "canary"}},
+	})
+	if err == nil {
+		t.Errorf("expected an error for a path that is not converted")
+	}
+}
+
+func TestApplyCanaryWithRewrite(t *testing.T) {
+	pathType := networking.PathTypeImplementationSpecific
+	primary := newAppIngress(map[string]string{"rewrite-target": "/$2"})
+	canaryIngress := newCanaryIngress(map[string]string{"canary-weight": "10"})
+	for _, ing := range []*networking.Ingress{primary, canaryIngress} {
+		ing.Spec.Rules[0].HTTP.Paths[0].Path = "/api(/|$)(.*)"
+		ing.Spec.Rules[0].HTTP.Paths[0].PathType = &pathType
+	}
+	plan := canary.Pair([]*networking.Ingress{primary, canaryIngress})
+	if plan.HasErrors() || len(plan.Splits) != 1 {
+		t.Fatalf("unexpected pairing %+v", plan)
+	}
+
+	conversion, err := Convert(primary, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := conversion.ApplyCanary(plan.Splits[0]); err != nil {
+		t.Fatalf("unexpected error applying canary: %s", err)
+	}
+	rules := conversion.HTTPRoutes[0].Spec.Rules
+	if len(rules) != 1 || len(rules[0].BackendRefs) != 2 {
+		t.Fatalf("expected a single rule with weighted backends, got %+v", rules)
+	}
+	if value := rules[0].Matches[0].Path.Value; value == nil || *value != "/api" {
+		t.Errorf("expected the rewritten prefix /api to be matched, got %v", value)
+	}
+	if len(rules[0].Filters) != 1 || rules[0].Filters[0].URLRewrite == nil {
+		t.Errorf("expected the rewrite filter to be kept, got %+v", rules[0].Filters)
+	}
+	if weight := rules[0].BackendRefs[1].Weight; rules[0].BackendRefs[1].Name != "api-canary" || weight == nil || *weight != 10 {
+		t.Errorf("expected the canary backend with weight 10, got %+v", rules[0].BackendRefs[1])
+	}
+}
@@ -0,0 +1,439 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gatewayapi converts Ingress objects and their annotations into
+// Gateway API resources
+package gatewayapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	annotations "github.com/rikatz/ingress-nginx-annotations"
+	"github.com/rikatz/ingress-nginx-annotations/gatewayapi/envoygateway"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ConfigMapGetter returns the ConfigMaps referenced by annotations, like custom-headers
+type ConfigMapGetter interface {
+	GetConfigMap(namespace, name string) (*corev1.ConfigMap, error)
+}
+
+// SecretGetter returns the Secrets referenced by annotations, like auth-secret
+type SecretGetter interface {
+	GetSecret(namespace, name string) (*corev1.Secret, error)
+}
+
+// Options defines how the Gateway API resources are generated
+type Options struct {
+	// ParentRefs are the Gateways (and listeners) the generated HTTPRoutes are attached to
+	ParentRefs []gatewayv1.ParentReference
+	// HTTPParentRefs are the HTTP listeners the generated ssl redirect HTTPRoutes are attached to.
+	// If empty, ParentRefs is used and a warning is returned, as the redirect route must
+	// not be attached to the HTTPS listeners
+	HTTPParentRefs []gatewayv1.ParentReference
+	// Registry contains the annotations and the configuration (like the prefix) used to
+	// read the Ingress. If nil, the default registry is used
+	Registry *parser.Registry
+	// ConfigMaps is used to read the ConfigMaps referenced by annotations. If nil,
+	// these annotations are reported as untranslated
+	ConfigMaps ConfigMapGetter
+	// Secrets is used to read the Secrets referenced by annotations. If nil, these
+	// annotations are reported as untranslated
+	Secrets SecretGetter
+	// EnvoyGateway generates Envoy Gateway policies for the annotations that do not have
+	// a Gateway API equivalent, like the rate limits
+	EnvoyGateway bool
+}
+
+// UntranslatedAnnotation is an annotation that could not be converted to Gateway API
+type UntranslatedAnnotation struct {
+	// Annotation is the full name of the annotation
+	Annotation string `json:"annotation"`
+	// Value is the value of the annotation
+	Value string `json:"value"`
+	// Reason explains why the annotation was not translated
+	Reason string `json:"reason"`
+}
+
+// Conversion is the result of converting an Ingress
+type Conversion struct {
+	// HTTPRoutes are the generated routes, one per Ingress host plus the ssl redirect routes
+	HTTPRoutes []gatewayv1.HTTPRoute `json:"httpRoutes"`
+	// BackendTLSPolicies configure TLS to the backend Services
+	BackendTLSPolicies []gatewayv1.BackendTLSPolicy `json:"backendTLSPolicies,omitempty"`
+	// ClientCertificate is the client certificate validation to be configured on the Gateways
+	ClientCertificate *ClientCertificate `json:"clientCertificate,omitempty"`
+	// BackendTrafficPolicies are the Envoy Gateway policies generated when Options.EnvoyGateway is set
+	BackendTrafficPolicies []envoygateway.BackendTrafficPolicy `json:"backendTrafficPolicies,omitempty"`
+	// SecurityPolicies are the Envoy Gateway policies generated when Options.EnvoyGateway is set
+	SecurityPolicies []envoygateway.SecurityPolicy `json:"securityPolicies,omitempty"`
+	// Secrets are the Secrets referenced by the generated policies, converted from the
+	// Secrets referenced by the annotations
+	Secrets []corev1.Secret `json:"secrets,omitempty"`
+	// Untranslated are the annotations that could not be converted
+	Untranslated []UntranslatedAnnotation `json:"untranslated,omitempty"`
+	// Warnings are translations that do not keep exactly the same behavior of ingress-nginx
+	Warnings []string `json:"warnings,omitempty"`
+
+	// sourcePaths contains the Ingress path of each rule of the routes, by route name. The
+	// path of the matches may differ, as rewrite-target replaces it by the rewritten prefix
+	sourcePaths map[string][]string
+}
+
+// converter holds the state of a single Ingress conversion
+type converter struct {
+	ing      *networking.Ingress
+	opts     Options
+	registry *parser.Registry
+	config   parser.Config
+
+	// values contains the annotations set on the Ingress, by canonical name
+	values map[string]string
+	// fullNames contains the annotation name used on the Ingress, by canonical name
+	fullNames map[string]string
+	// handled contains the canonical name of the annotations already translated or reported
+	handled map[string]bool
+
+	result *Conversion
+}
+
+// Convert generates the HTTPRoutes equivalent to the Ingress. The annotations with a
+// known Gateway API equivalent are translated into route fields and filters, and the
+// remaining annotations are returned as untranslated
+func Convert(ing *networking.Ingress, opts Options) (*Conversion, error) {
+	if ing == nil {
+		return nil, fmt.Errorf("ingress cannot be null")
+	}
+
+	c := newConverter(ing, opts)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	routes := c.routes()
+	for _, translate := range []func([]gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute{
+		c.translateCanary,
+		c.translateServerAlias,
+		c.translateTimeouts,
+		c.translateRedirect,
+		c.translateRewrite,
+		c.translateMirror,
+		c.translateCORS,
+		c.translateSessionAffinity,
+		c.translateCustomHeaders,
+		c.translateRateLimit,
+		c.translateBackendTLS,
+		c.translateClientCertificate,
+		c.translateExternalAuth,
+		c.translateBasicAuth,
+		c.translateSSLRedirect,
+		c.translateFromToWWW,
+	} {
+		routes = translate(routes)
+	}
+	c.result.HTTPRoutes = routes
+	c.reportUntranslated()
+
+	return c.result, nil
+}
+
+func newConverter(ing *networking.Ingress, opts Options) *converter {
+	registry := opts.Registry
+	if registry == nil {
+		registry = annotations.NewRegistry()
+	}
+	c := &converter{
+		ing:       ing,
+		opts:      opts,
+		registry:  registry,
+		config:    registry.Config(),
+		values:    make(map[string]string),
+		fullNames: make(map[string]string),
+		handled:   make(map[string]bool),
+		result:    &Conversion{sourcePaths: make(map[string][]string)},
+	}
+
+	for annotation, value := range ing.Annotations {
+		if !c.config.HasPrefix(annotation) {
+			continue
+		}
+		name := c.config.TrimAnnotationPrefix(annotation)
+		if ann, ok := registry.Lookup(name); ok {
+			name = ann.Canonical
+			// The canonical annotation has precedence over its aliases
+			if ann.IsAlias() && c.values[name] != "" {
+				continue
+			}
+		}
+		c.values[name] = value
+		c.fullNames[name] = annotation
+	}
+	return c
+}
+
+// validate reports the invalid annotations as untranslated, so they are not converted
+func (c *converter) validate() error {
+	result, err := c.registry.Validate(c.ing)
+	if err != nil {
+		return err
+	}
+	for _, finding := range result.Findings {
+		if finding.Type != parser.FindingInvalidValue {
+			continue
+		}
+		ann, ok := c.registry.Lookup(c.config.TrimAnnotationPrefix(finding.Annotation))
+		if !ok {
+			continue
+		}
+		c.untranslated(ann.Canonical, fmt.Sprintf("invalid value: %s", finding.Reason))
+	}
+	return nil
+}
+
+// value returns the value of an annotation that was not yet handled
+func (c *converter) value(name string) (string, bool) {
+	if c.handled[name] {
+		return "", false
+	}
+	value, ok := c.values[name]
+	return value, ok && value != ""
+}
+
+// translated marks the annotation as converted
+func (c *converter) translated(name string) {
+	c.handled[name] = true
+}
+
+// untranslated marks the annotation as not converted, with the reason
+func (c *converter) untranslated(name, reason string) {
+	if c.handled[name] {
+		return
+	}
+	c.handled[name] = true
+	c.result.Untranslated = append(c.result.Untranslated, UntranslatedAnnotation{
+		Annotation: c.fullNames[name],
+		Value:      c.values[name],
+		Reason:     reason,
+	})
+}
+
+func (c *converter) warn(format string, args ...any) {
+	c.result.Warnings = append(c.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+// reportUntranslated reports all the annotations that were not handled by any translation
+func (c *converter) reportUntranslated() {
+	for _, name := range slices.Sorted(maps.Keys(c.values)) {
+		ann, ok := c.registry.Lookup(name)
+		var reason string
+		switch {
+		case !ok:
+			reason = "annotation does not exist"
+		case ann.Config.GatewayAPICompatibility == parser.GatewayAPIIncompatible:
+			reason = "annotation does not have a Gateway API equivalent"
+		default:
+			reason = fmt.Sprintf("annotation is not translated by the converter: %s", ann.Config.GatewayAPI)
+		}
+		c.untranslated(name, reason)
+	}
+	slices.SortFunc(c.result.Untranslated, func(a, b UntranslatedAnnotation) int {
+		return strings.Compare(a.Annotation, b.Annotation)
+	})
+}
+
+// routes generates one HTTPRoute per Ingress host, without any annotation
+func (c *converter) routes() []gatewayv1.HTTPRoute {
+	routes := make([]gatewayv1.HTTPRoute, 0)
+	byHost := make(map[string]int)
+
+	for _, rule := range c.ing.Spec.Rules {
+		idx, ok := byHost[rule.Host]
+		if !ok {
+			route := c.newRoute(routeName(c.ing.Name, rule.Host))
+			if rule.Host != "" {
+				route.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(rule.Host)}
+			}
+			routes = append(routes, route)
+			idx = len(routes) - 1
+			byHost[rule.Host] = idx
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			routeRule := gatewayv1.HTTPRouteRule{
+				Matches: []gatewayv1.HTTPRouteMatch{{Path: c.pathMatch(path)}},
+			}
+			if backendRef, ok := c.backendRef(path.Backend); ok {
+				routeRule.BackendRefs = []gatewayv1.HTTPBackendRef{backendRef}
+			}
+			routes[idx].Spec.Rules = append(routes[idx].Spec.Rules, routeRule)
+			c.result.sourcePaths[routes[idx].Name] = append(c.result.sourcePaths[routes[idx].Name], path.Path)
+		}
+	}
+
+	if c.ing.Spec.DefaultBackend != nil {
+		route := c.newRoute(routeName(c.ing.Name, "default-backend"))
+		routeRule := gatewayv1.HTTPRouteRule{}
+		if backendRef, ok := c.backendRef(*c.ing.Spec.DefaultBackend); ok {
+			routeRule.BackendRefs = []gatewayv1.HTTPBackendRef{backendRef}
+		}
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{routeRule}
+		routes = append(routes, route)
+		c.result.sourcePaths[route.Name] = []string{""}
+	}
+
+	return routes
+}
+
+func (c *converter) newRoute(name string) gatewayv1.HTTPRoute {
+	return gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.ing.Namespace,
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: slices.Clone(c.opts.ParentRefs),
+			},
+		},
+	}
+}
+
+// pathMatch converts an Ingress path. ImplementationSpecific paths are regular
+// expressions when use-regex or rewrite-target are set, as ingress-nginx does
+func (c *converter) pathMatch(path networking.HTTPIngressPath) *gatewayv1.HTTPPathMatch {
+	value := path.Path
+	if value == "" {
+		value = "/"
+	}
+
+	matchType := gatewayv1.PathMatchPathPrefix
+	if path.PathType != nil {
+		switch *path.PathType {
+		case networking.PathTypeExact:
+			matchType = gatewayv1.PathMatchExact
+		case networking.PathTypeImplementationSpecific:
+			if c.useRegex() {
+				matchType = gatewayv1.PathMatchRegularExpression
+			}
+		}
+	}
+
+	return &gatewayv1.HTTPPathMatch{
+		Type:  ptr.To(matchType),
+		Value: ptr.To(value),
+	}
+}
+
+func (c *converter) useRegex() bool {
+	return strings.EqualFold(c.values["use-regex"], "true") || c.values["rewrite-target"] != ""
+}
+
+// backendRef converts an Ingress backend. Resource backends and named ports can not be converted
+func (c *converter) backendRef(backend networking.IngressBackend) (gatewayv1.HTTPBackendRef, bool) {
+	if backend.Service == nil {
+		c.warn("backend %v of Ingress %s/%s is not a Service and was not converted", backend.Resource, c.ing.Namespace, c.ing.Name)
+		return gatewayv1.HTTPBackendRef{}, false
+	}
+
+	if backend.Service.Port.Number == 0 && backend.Service.Port.Name != "" {
+		c.warn("service %s uses the named port %s, that must be replaced by its number", backend.Service.Name, backend.Service.Port.Name)
+	}
+	return serviceBackendRef(backend.Service), true
+}
+
+// serviceBackendRef converts an Ingress Service backend. Named ports are not converted
+func serviceBackendRef(service *networking.IngressServiceBackend) gatewayv1.HTTPBackendRef {
+	ref := gatewayv1.HTTPBackendRef{
+		BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(service.Name),
+			},
+		},
+	}
+	if service.Port.Number != 0 {
+		ref.Port = ptr.To(service.Port.Number)
+	}
+	return ref
+}
+
+// routeName returns a valid object name for the route of a host
+func routeName(ingress, host string) string {
+	if host == "" {
+		return ingress
+	}
+	host = strings.ReplaceAll(host, "*", "wildcard")
+	return fmt.Sprintf("%s-%s", ingress, strings.ReplaceAll(host, ".", "-"))
+}
+
+// securityPolicy returns the Envoy Gateway SecurityPolicy of the Ingress, targeting the
+// routes. A single policy is generated, as Envoy Gateway only applies one SecurityPolicy
+// to each route
+func (c *converter) securityPolicy(routes []gatewayv1.HTTPRoute) *envoygateway.SecurityPolicy {
+	if len(c.result.SecurityPolicies) == 0 {
+		c.result.SecurityPolicies = append(c.result.SecurityPolicies, envoygateway.SecurityPolicy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: envoygateway.APIVersion,
+				Kind:       "SecurityPolicy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-security", c.ing.Name),
+				Namespace: c.ing.Namespace,
+			},
+			Spec: envoygateway.SecurityPolicySpec{
+				TargetRefs: routeTargetRefs(routes),
+			},
+		})
+	}
+	return &c.result.SecurityPolicies[0]
+}
+
+// routeTargetRefs returns the policy target references of the routes
+func routeTargetRefs(routes []gatewayv1.HTTPRoute) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	refs := make([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, 0, len(routes))
+	for _, route := range routes {
+		refs = append(refs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  gatewayv1.ObjectName(route.Name),
+			},
+		})
+	}
+	return refs
+}
+
+// addFilter adds a filter to all the rules of the routes
+func addFilter(routes []gatewayv1.HTTPRoute, filter gatewayv1.HTTPRouteFilter) {
+	for i := range routes {
+		for j := range routes[i].Spec.Rules {
+			routes[i].Spec.Rules[j].Filters = append(routes[i].Spec.Rules[j].Filters, filter)
+		}
+	}
+}
@@ -0,0 +1,491 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/internal/ingresstest"
+	"github.com/rikatz/ingress-nginx-annotations/references"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// newConfigMap returns a ConfigMap of the default namespace
+func newConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       data,
+	}
+}
+
+// newAppIngress returns the Ingress app, routing app.example.com/api to the Service api
+func newAppIngress(annotations map[string]string) *networking.Ingress {
+	ing := ingresstest.WithPath(ingresstest.New(annotations), "app.example.com", "/api", networking.PathTypePrefix, "api", 8080)
+	ing.Name = "app"
+	return ing
+}
+
+func TestConvertRoutes(t *testing.T) {
+	parentRefs := []gatewayv1.ParentReference{{Name: "gateway"}}
+	conversion, err := Convert(newAppIngress(nil), Options{ParentRefs: parentRefs})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.HTTPRoutes) != 1 {
+		t.Fatalf("expected 1 route, got %d", len(conversion.HTTPRoutes))
+	}
+
+	route := conversion.HTTPRoutes[0]
+	if route.Name != "app-app-example-com" || route.Namespace != "default" {
+		t.Errorf("unexpected route %s/%s", route.Namespace, route.Name)
+	}
+	if !reflect.DeepEqual(route.Spec.ParentRefs, parentRefs) {
+		t.Errorf("expected parentRefs %v, got %v", parentRefs, route.Spec.ParentRefs)
+	}
+	if !reflect.DeepEqual(route.Spec.Hostnames, []gatewayv1.Hostname{"app.example.com"}) {
+		t.Errorf("unexpected hostnames %v", route.Spec.Hostnames)
+	}
+	expected := gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{
+			Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+			Value: ptr.To("/api"),
+		}}},
+		BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: "api",
+				Port: ptr.To(gatewayv1.PortNumber(8080)),
+			},
+		}}},
+	}
+	if !reflect.DeepEqual(route.Spec.Rules, []gatewayv1.HTTPRouteRule{expected}) {
+		t.Errorf("expected rules %+v, got %+v", expected, route.Spec.Rules)
+	}
+}
+
+func TestConvertFilters(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []gatewayv1.HTTPRouteFilter
+	}{
+		{
+			name: "permanent redirect",
+			annotations: map[string]string{
+				"permanent-redirect": "https://www.example.com/new",
+			},
+			expected: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Scheme:     ptr.To("https"),
+					Hostname:   ptr.To(gatewayv1.PreciseHostname("www.example.com")),
+					Path:       &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To("/new")},
+					StatusCode: ptr.To(301),
+				},
+			}},
+		},
+		{
+			name: "temporal redirect has precedence",
+			annotations: map[string]string{
+				"permanent-redirect": "https://permanent.example.com",
+				"temporal-redirect":  "http://temporal.example.com:8080",
+			},
+			expected: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Scheme:     ptr.To("http"),
+					Hostname:   ptr.To(gatewayv1.PreciseHostname("temporal.example.com")),
+					Port:       ptr.To(gatewayv1.PortNumber(8080)),
+					Path:       &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To("/")},
+					StatusCode: ptr.To(302),
+				},
+			}},
+		},
+		{
+			name: "rewrite target",
+			annotations: map[string]string{
+				"rewrite-target": "/",
+			},
+			expected: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterURLRewrite,
+				URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+					Path: &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To("/")},
+				},
+			}},
+		},
+		{
+			name: "mirror to a service",
+			annotations: map[string]string{
+				"mirror-target": "http://mirror.default.svc.cluster.local:8081$request_uri",
+			},
+			expected: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+					BackendRef: gatewayv1.BackendObjectReference{
+						Name: "mirror",
+						Port: ptr.To(gatewayv1.PortNumber(8081)),
+					},
+				},
+			}},
+		},
+		{
+			name: "custom headers",
+			annotations: map[string]string{
+				"custom-headers": "default/headers",
+			},
+			expected: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{{Name: "X-A", Value: "a"}, {Name: "X-B", Value: "b"}},
+				},
+			}},
+		},
+		{
+			name: "cors with defaults",
+			annotations: map[string]string{
+				"enable-cors":       "true",
+				"cors-allow-origin": "https://app.example.com, http://localhost:3000",
+			},
+			expected: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterCORS,
+				CORS: &gatewayv1.HTTPCORSFilter{
+					AllowOrigins:     []gatewayv1.CORSOrigin{"https://app.example.com", "http://localhost:3000"},
+					AllowCredentials: ptr.To(true),
+					AllowMethods:     []gatewayv1.HTTPMethodWithWildcard{"GET", "PUT", "POST", "DELETE", "PATCH", "OPTIONS"},
+					AllowHeaders: []gatewayv1.HTTPHeaderName{
+						"DNT", "Keep-Alive", "User-Agent", "X-Requested-With", "If-Modified-Since",
+						"Cache-Control", "Content-Type", "Range", "Authorization",
+					},
+					MaxAge: 1728000,
+				},
+			}},
+		},
+	}
+
+	configMaps := references.NewFake(newConfigMap("headers", map[string]string{"X-B": "b", "X-A": "a"}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), Options{ConfigMaps: configMaps})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			filters := conversion.HTTPRoutes[0].Spec.Rules[0].Filters
+			if !reflect.DeepEqual(filters, tt.expected) {
+				t.Errorf("expected filters %+v, got %+v", tt.expected, filters)
+			}
+		})
+	}
+}
+
+func TestConvertRouteFields(t *testing.T) {
+	ing := newAppIngress(map[string]string{
+		"server-alias":          "www.example.com, app.example.com",
+		"proxy-connect-timeout": "5",
+		"proxy-read-timeout":    "120",
+		"ssl-redirect":          "true",
+	})
+	ing.Spec.TLS = []networking.IngressTLS{{Hosts: []string{"app.example.com"}}}
+	conversion, err := Convert(ing, Options{
+		ParentRefs:     []gatewayv1.ParentReference{{Name: "gateway", SectionName: ptr.To(gatewayv1.SectionName("https"))}},
+		HTTPParentRefs: []gatewayv1.ParentReference{{Name: "gateway", SectionName: ptr.To(gatewayv1.SectionName("http"))}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.Untranslated) != 0 {
+		t.Errorf("expected all annotations to be translated, got %+v", conversion.Untranslated)
+	}
+	if len(conversion.HTTPRoutes) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(conversion.HTTPRoutes))
+	}
+
+	route := conversion.HTTPRoutes[0]
+	expectedHosts := []gatewayv1.Hostname{"app.example.com", "www.example.com"}
+	if !reflect.DeepEqual(route.Spec.Hostnames, expectedHosts) {
+		t.Errorf("expected hostnames %v, got %v", expectedHosts, route.Spec.Hostnames)
+	}
+	timeouts := route.Spec.Rules[0].Timeouts
+	if timeouts == nil || timeouts.BackendRequest == nil || *timeouts.BackendRequest != "2m0s" {
+		t.Errorf("expected backendRequest timeout of 2m0s, got %+v", timeouts)
+	}
+
+	redirect := conversion.HTTPRoutes[1]
+	if redirect.Name != "app-app-example-com-ssl-redirect" {
+		t.Errorf("unexpected redirect route name %s", redirect.Name)
+	}
+	if *redirect.Spec.ParentRefs[0].SectionName != "http" {
+		t.Errorf("expected redirect route to be attached to the http listener, got %v", redirect.Spec.ParentRefs)
+	}
+	if !reflect.DeepEqual(redirect.Spec.Hostnames, expectedHosts) {
+		t.Errorf("expected redirect hostnames %v, got %v", expectedHosts, redirect.Spec.Hostnames)
+	}
+	filter := redirect.Spec.Rules[0].Filters[0].RequestRedirect
+	if filter == nil || *filter.Scheme != "https" {
+		t.Errorf("expected https redirect, got %+v", filter)
+	}
+}
+
+func TestConvertSSLRedirect(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		tls          []networking.IngressTLS
+		hosts        []string
+		redirects    [][]gatewayv1.Hostname
+		untranslated []string
+	}{
+		{
+			name:        "host with tls",
+			annotations: map[string]string{"ssl-redirect": "true"},
+			tls:         []networking.IngressTLS{{Hosts: []string{"app.example.com"}}},
+			hosts:       []string{"app.example.com"},
+			redirects:   [][]gatewayv1.Hostname{{"app.example.com"}},
+		},
+		{
+			name:        "only hosts with tls are redirected",
+			annotations: map[string]string{"ssl-redirect": "true"},
+			tls:         []networking.IngressTLS{{Hosts: []string{"*.example.com"}}},
+			hosts:       []string{"app.example.com", "app.example.org"},
+			redirects:   [][]gatewayv1.Hostname{{"app.example.com"}},
+		},
+		{
+			name:      "host with tls is redirected by default",
+			tls:       []networking.IngressTLS{{Hosts: []string{"app.example.com"}}},
+			hosts:     []string{"app.example.com"},
+			redirects: [][]gatewayv1.Hostname{{"app.example.com"}},
+		},
+		{
+			name:        "redirect disabled",
+			annotations: map[string]string{"ssl-redirect": "false"},
+			tls:         []networking.IngressTLS{{Hosts: []string{"app.example.com"}}},
+			hosts:       []string{"app.example.com"},
+		},
+		{
+			name:  "host without tls is not redirected by default",
+			hosts: []string{"app.example.com"},
+		},
+		{
+			name:         "host without tls",
+			annotations:  map[string]string{"ssl-redirect": "true"},
+			hosts:        []string{"app.example.com"},
+			untranslated: []string{"nginx.ingress.kubernetes.io/ssl-redirect"},
+		},
+		{
+			name:        "forced without tls",
+			annotations: map[string]string{"force-ssl-redirect": "true"},
+			hosts:       []string{"app.example.com"},
+			redirects:   [][]gatewayv1.Hostname{{"app.example.com"}},
+		},
+		{
+			name:        "routes without hostnames are not redirected",
+			annotations: map[string]string{"force-ssl-redirect": "true"},
+			hosts:       []string{"app.example.com", ""},
+			redirects:   [][]gatewayv1.Hostname{{"app.example.com"}},
+		},
+		{
+			name:         "forced without hosts",
+			annotations:  map[string]string{"force-ssl-redirect": "true"},
+			hosts:        []string{""},
+			untranslated: []string{"nginx.ingress.kubernetes.io/force-ssl-redirect"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := newAppIngress(tt.annotations)
+			rule := ing.Spec.Rules[0]
+			ing.Spec.Rules = nil
+			for _, host := range tt.hosts {
+				rule.Host = host
+				ing.Spec.Rules = append(ing.Spec.Rules, rule)
+			}
+			ing.Spec.TLS = tt.tls
+			conversion, err := Convert(ing, Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			redirects := make([][]gatewayv1.Hostname, 0)
+			for _, route := range conversion.HTTPRoutes {
+				if strings.HasSuffix(route.Name, "-ssl-redirect") {
+					redirects = append(redirects, route.Spec.Hostnames)
+				}
+			}
+			if tt.redirects == nil {
+				tt.redirects = [][]gatewayv1.Hostname{}
+			}
+			if !reflect.DeepEqual(redirects, tt.redirects) {
+				t.Errorf("expected redirects of %v, got %v", tt.redirects, redirects)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if tt.untranslated == nil {
+				tt.untranslated = []string{}
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
+
+func TestConvertUntranslated(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		options     Options
+		expected    []string
+	}{
+		{
+			name: "no gateway api equivalent",
+			annotations: map[string]string{
+				"server-snippet": "return 200;",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/server-snippet"},
+		},
+		{
+			name: "rewrite with capture groups",
+			annotations: map[string]string{
+				"rewrite-target": "/$2",
+				"use-regex":      "true",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/rewrite-target"},
+		},
+		{
+			name: "mirror outside of the cluster",
+			annotations: map[string]string{
+				"mirror-target": "https://mirror.example.com",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/mirror-target"},
+		},
+		{
+			name: "custom headers without configmap getter",
+			annotations: map[string]string{
+				"custom-headers": "headers",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/custom-headers"},
+		},
+		{
+			name: "custom headers injecting headers",
+			annotations: map[string]string{
+				"custom-headers": "injection",
+			},
+			options: Options{ConfigMaps: references.NewFake(
+				newConfigMap("injection", map[string]string{"X-A": "a\r\nSet-Cookie: session=evil", "X-B": "b"}),
+			)},
+			expected: []string{"nginx.ingress.kubernetes.io/custom-headers"},
+		},
+		{
+			name: "custom headers with hop-by-hop header",
+			annotations: map[string]string{
+				"custom-headers": "hop",
+			},
+			options:  Options{ConfigMaps: references.NewFake(newConfigMap("hop", map[string]string{"Connection": "close"}))},
+			expected: []string{"nginx.ingress.kubernetes.io/custom-headers"},
+		},
+		{
+			name: "unsupported redirect code",
+			annotations: map[string]string{
+				"permanent-redirect":      "https://www.example.com",
+				"permanent-redirect-code": "308",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/permanent-redirect-code"},
+		},
+		{
+			name: "cors not enabled",
+			annotations: map[string]string{
+				"enable-cors":       "false",
+				"cors-allow-origin": "https://app.example.com",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/cors-allow-origin"},
+		},
+		{
+			name: "cors allowing only the null origin",
+			annotations: map[string]string{
+				"enable-cors":       "true",
+				"cors-allow-origin": "null",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/cors-allow-origin", "nginx.ingress.kubernetes.io/enable-cors"},
+		},
+		{
+			name: "regex server alias",
+			annotations: map[string]string{
+				"server-alias": "~^www\\d+\\.example\\.com$",
+			},
+			expected: []string{"nginx.ingress.kubernetes.io/server-alias"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := newAppIngress(tt.annotations)
+			// annotations without the ingress-nginx prefix are ignored
+			ing.Annotations["other.io/annotation"] = "ignored"
+			conversion, err := Convert(ing, tt.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				if ann.Reason == "" {
+					t.Errorf("annotation %s was reported without a reason", ann.Annotation)
+				}
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.expected) {
+				t.Errorf("expected untranslated %v, got %v", tt.expected, untranslated)
+			}
+		})
+	}
+}
+
+func TestConvertRewritePrefix(t *testing.T) {
+	ing := newAppIngress(map[string]string{
+		"rewrite-target": "/$2",
+	})
+	pathType := networking.PathTypeImplementationSpecific
+	ing.Spec.Rules[0].HTTP.Paths[0].Path = "/api(/|$)(.*)"
+	ing.Spec.Rules[0].HTTP.Paths[0].PathType = &pathType
+
+	conversion, err := Convert(ing, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.Untranslated) != 0 {
+		t.Errorf("expected all the annotations translated, got %+v", conversion.Untranslated)
+	}
+	rule := conversion.HTTPRoutes[0].Spec.Rules[0]
+	expectedMatch := &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/api")}
+	if !reflect.DeepEqual(rule.Matches[0].Path, expectedMatch) {
+		t.Errorf("expected path match %+v, got %+v", expectedMatch, rule.Matches[0].Path)
+	}
+	expectedFilters := []gatewayv1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+			Path: &gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: ptr.To("/")},
+		},
+	}}
+	if !reflect.DeepEqual(rule.Filters, expectedFilters) {
+		t.Errorf("expected filters %+v, got %+v", expectedFilters, rule.Filters)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/cors"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	corsEnableAnnotation           = "enable-cors"
+	corsAllowOriginAnnotation      = "cors-allow-origin"
+	corsAllowHeadersAnnotation     = "cors-allow-headers"
+	corsAllowMethodsAnnotation     = "cors-allow-methods"
+	corsAllowCredentialsAnnotation = "cors-allow-credentials" //#nosec G101
+	corsExposeHeadersAnnotation    = "cors-expose-headers"
+	corsMaxAgeAnnotation           = "cors-max-age"
+)
+
+var corsAnnotations = []string{
+	corsEnableAnnotation,
+	corsAllowOriginAnnotation,
+	corsAllowHeadersAnnotation,
+	corsAllowMethodsAnnotation,
+	corsAllowCredentialsAnnotation,
+	corsExposeHeadersAnnotation,
+	corsMaxAgeAnnotation,
+}
+
+// corsMethods are the methods accepted by the CORS filter
+var corsMethods = []gatewayv1.HTTPMethodWithWildcard{
+	"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH", "*",
+}
+
+// translateCORS converts the CORS policy to a CORS filter. The defaults of ingress-nginx
+// are always set on the filter, as they differ from the Gateway API defaults
+func (c *converter) translateCORS(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	found := false
+	for _, name := range corsAnnotations {
+		if _, ok := c.value(name); ok {
+			found = true
+		}
+	}
+	if !found {
+		return routes
+	}
+
+	// Invalid annotations were already reported, and the policy uses the defaults instead
+	policy, _ := cors.ParseWithConfig(c.ing, c.config)
+	if !policy.Enabled {
+		c.translated(corsEnableAnnotation)
+		c.untranslatedCORS("CORS annotations are ignored when enable-cors is not true")
+		return routes
+	}
+
+	filter := &gatewayv1.HTTPCORSFilter{
+		AllowCredentials: ptr.To(policy.AllowCredentials),
+	}
+
+	if len(policy.IgnoredOrigins) > 0 {
+		c.warn("origins %s are not valid and are ignored, as on ingress-nginx", strings.Join(policy.IgnoredOrigins, ", "))
+	}
+	for _, origin := range policy.AllowOrigin {
+		if origin == "null" {
+			c.warn("origin null cannot be allowed by Gateway API and was removed")
+			continue
+		}
+		if origin != "*" {
+			scheme, host, _ := strings.Cut(origin, "://")
+			if scheme != "http" && scheme != "https" {
+				c.warn("origin %s uses the scheme %s, and Gateway API only supports http and https", origin, scheme)
+			}
+			if strings.HasPrefix(host, "*.") {
+				c.warn("origin %s matches any number of subdomain levels on Gateway API, while ingress-nginx matches a single level", origin)
+			}
+		}
+		filter.AllowOrigins = append(filter.AllowOrigins, gatewayv1.CORSOrigin(origin))
+	}
+	if len(filter.AllowOrigins) == 0 {
+		c.untranslatedCORS("cors-allow-origin does not contain any origin supported by Gateway API")
+		return routes
+	}
+	if policy.AllowsAnyOrigin() && policy.AllowCredentials {
+		c.warn("allowing credentials from any origin returns the origin of the request on Gateway API, while ingress-nginx returns *, that is rejected by browsers")
+	}
+
+	for _, method := range policy.AllowMethods {
+		method := gatewayv1.HTTPMethodWithWildcard(strings.ToUpper(method))
+		if !slices.Contains(corsMethods, method) {
+			c.warn("method %s is not supported by the Gateway API CORS filter and was removed", method)
+			continue
+		}
+		if !slices.Contains(filter.AllowMethods, method) {
+			filter.AllowMethods = append(filter.AllowMethods, method)
+		}
+	}
+	for _, header := range policy.AllowHeaders {
+		filter.AllowHeaders = append(filter.AllowHeaders, gatewayv1.HTTPHeaderName(header))
+	}
+	for _, header := range policy.ExposeHeaders {
+		filter.ExposeHeaders = append(filter.ExposeHeaders, gatewayv1.HTTPHeaderName(header))
+	}
+
+	maxAge := policy.MaxAge
+	if maxAge > math.MaxInt32 {
+		c.warn("cors-max-age %d is higher than the maximum of Gateway API, %d is used", maxAge, math.MaxInt32)
+		maxAge = math.MaxInt32
+	}
+	filter.MaxAge = int32(max(maxAge, 0)) //#nosec G115
+
+	addFilter(routes, gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterCORS,
+		CORS: filter,
+	})
+	for _, name := range corsAnnotations {
+		c.translated(name)
+	}
+	return routes
+}
+
+// untranslatedCORS reports all the CORS annotations set on the Ingress as untranslated
+func (c *converter) untranslatedCORS(reason string) {
+	for _, name := range corsAnnotations {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+}
+
+// CORSFilterAllowsOrigin returns if a CORS filter allows the value of the Origin header
+// of a request, following the Gateway API semantics: the default port of the scheme is
+// assumed when it is not set, and a wildcard matches any number of subdomain levels.
+// It can be compared with cors.Config.AllowsOrigin to verify that a migrated route
+// answers the same preflight requests
+func CORSFilterAllowsOrigin(filter *gatewayv1.HTTPCORSFilter, origin string) bool {
+	if filter == nil || origin == "" {
+		return false
+	}
+	request, ok := parseOrigin(origin)
+	if !ok {
+		return false
+	}
+	for _, allowed := range filter.AllowOrigins {
+		if allowed == "*" {
+			return true
+		}
+		candidate, ok := parseOrigin(string(allowed))
+		if !ok || candidate.scheme != request.scheme || candidate.port != request.port {
+			continue
+		}
+		if suffix, wildcard := strings.CutPrefix(candidate.host, "*"); wildcard {
+			if len(request.host) > len(suffix) && strings.HasSuffix(request.host, suffix) {
+				return true
+			}
+			continue
+		}
+		if candidate.host == request.host {
+			return true
+		}
+	}
+	return false
+}
+
+type corsOrigin struct {
+	scheme, host, port string
+}
+
+// parseOrigin splits an origin, using the default port of http and https when it is not set
+func parseOrigin(origin string) (corsOrigin, bool) {
+	u, err := url.Parse(strings.ToLower(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return corsOrigin{}, false
+	}
+	parsed := corsOrigin{scheme: u.Scheme, host: u.Hostname(), port: u.Port()}
+	if parsed.port == "" {
+		switch parsed.scheme {
+		case "http":
+			parsed.port = "80"
+		case "https":
+			parsed.port = "443"
+		}
+	}
+	return parsed, true
+}
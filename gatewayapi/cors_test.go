@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/cors"
+)
+
+// TestConvertCORSPreflight verifies that the converted CORS filter allows the same
+// origins of the ingress-nginx policy, except for the documented differences
+func TestConvertCORSPreflight(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowOrigin string
+		origin      string
+		// differs is true when Gateway API and ingress-nginx are known to answer differently
+		differs bool
+	}{
+		{name: "any origin", allowOrigin: "*", origin: "https://anything.com"},
+		{name: "exact origin", allowOrigin: "https://app.example.com", origin: "https://app.example.com"},
+		{name: "other origin", allowOrigin: "https://app.example.com", origin: "https://other.example.com"},
+		{name: "other scheme", allowOrigin: "https://app.example.com", origin: "http://app.example.com"},
+		{name: "case insensitive", allowOrigin: "https://app.example.com", origin: "https://APP.example.com"},
+		{name: "port", allowOrigin: "http://localhost:3000", origin: "http://localhost:3000"},
+		{name: "other port", allowOrigin: "http://localhost:3000", origin: "http://localhost:3001"},
+		{name: "single level wildcard", allowOrigin: "https://*.example.com", origin: "https://app.example.com"},
+		{name: "wildcard does not match the domain", allowOrigin: "https://*.example.com", origin: "https://example.com"},
+		{name: "multi level wildcard", allowOrigin: "https://*.example.com", origin: "https://a.b.example.com", differs: true},
+		{name: "explicit default port", allowOrigin: "https://app.example.com", origin: "https://app.example.com:443", differs: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := newAppIngress(map[string]string{
+				"enable-cors":       "true",
+				"cors-allow-origin": tt.allowOrigin,
+			})
+			policy, err := cors.Parse(ing)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			conversion, err := Convert(ing, Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(conversion.Untranslated) != 0 {
+				t.Fatalf("expected all annotations to be translated, got %+v", conversion.Untranslated)
+			}
+			filter := conversion.HTTPRoutes[0].Spec.Rules[0].Filters[0].CORS
+
+			nginx, gateway := policy.AllowsOrigin(tt.origin), CORSFilterAllowsOrigin(filter, tt.origin)
+			if (nginx != gateway) != tt.differs {
+				t.Errorf("origin %s: ingress-nginx allows %t, Gateway API allows %t", tt.origin, nginx, gateway)
+			}
+		})
+	}
+}
+
+func TestConvertCORSWarnings(t *testing.T) {
+	conversion, err := Convert(newAppIngress(map[string]string{
+		"enable-cors":        "true",
+		"cors-allow-origin":  "https://*.example.com, null, invalid.com",
+		"cors-allow-methods": "GET, PURGE",
+	}), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	warnings := strings.Join(conversion.Warnings, "\n")
+	for _, expected := range []string{"invalid.com", "origin null", "single level", "PURGE"} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("expected a warning containing %q, got:\n%s", expected, warnings)
+		}
+	}
+}
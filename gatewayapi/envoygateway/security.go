@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envoygateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// SecurityPolicy configures the authentication of the targeted routes
+type SecurityPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecurityPolicySpec `json:"spec"`
+}
+
+// SecurityPolicySpec is the specification of a SecurityPolicy
+type SecurityPolicySpec struct {
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+	ExtAuth    *ExtAuth                                              `json:"extAuth,omitempty"`
+	BasicAuth  *BasicAuth                                            `json:"basicAuth,omitempty"`
+}
+
+// BasicAuth authenticates the requests with the users of an htpasswd Secret
+type BasicAuth struct {
+	// Users is a Secret with the .htpasswd key. Only {SHA} hashes are supported
+	Users gatewayv1.SecretObjectReference `json:"users"`
+}
+
+// ExtAuth sends the requests to an external authorization service
+type ExtAuth struct {
+	HTTP *HTTPExtAuthService `json:"http,omitempty"`
+	// HeadersToExtAuth are the headers of the request sent to the service. All the
+	// headers are sent when empty
+	HeadersToExtAuth []string `json:"headersToExtAuth,omitempty"`
+	// FailOpen allows the requests when the service cannot be reached
+	FailOpen *bool `json:"failOpen,omitempty"`
+}
+
+// HTTPExtAuthService is an external authorization service using HTTP
+type HTTPExtAuthService struct {
+	BackendRefs []gatewayv1.BackendRef `json:"backendRefs"`
+	// Path is the prefix added to the path of the request sent to the service
+	Path string `json:"path,omitempty"`
+	// HeadersToBackend are the headers of the authorization response sent to the backend
+	HeadersToBackend []string `json:"headersToBackend,omitempty"`
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envoygateway contains the subset of the Envoy Gateway policies generated by
+// the converter, for the annotations that do not have a Gateway API equivalent. Only
+// the fields used by the converter are declared, with the same JSON names of the
+// gateway.envoyproxy.io/v1alpha1 API, so the module does not depend on Envoy Gateway
+package envoygateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// APIVersion is the API version of the Envoy Gateway policies
+const APIVersion = "gateway.envoyproxy.io/v1alpha1"
+
+// BackendTrafficPolicy configures how Envoy handles the traffic of the targeted routes
+type BackendTrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackendTrafficPolicySpec `json:"spec"`
+}
+
+// BackendTrafficPolicySpec is the specification of a BackendTrafficPolicy
+type BackendTrafficPolicySpec struct {
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+	RateLimit  *RateLimitSpec                                        `json:"rateLimit,omitempty"`
+}
+
+// RateLimitType is where the rate limits are counted
+type RateLimitType string
+
+const (
+	// GlobalRateLimitType counts the requests on the rate limit service, shared by all the proxies
+	GlobalRateLimitType RateLimitType = "Global"
+	// LocalRateLimitType counts the requests on each proxy
+	LocalRateLimitType RateLimitType = "Local"
+)
+
+// RateLimitSpec defines the rate limits of the traffic
+type RateLimitSpec struct {
+	Type   RateLimitType    `json:"type"`
+	Global *GlobalRateLimit `json:"global,omitempty"`
+	Local  *LocalRateLimit  `json:"local,omitempty"`
+}
+
+// GlobalRateLimit contains the rules of a global rate limit
+type GlobalRateLimit struct {
+	Rules []RateLimitRule `json:"rules"`
+}
+
+// LocalRateLimit contains the rules of a local rate limit
+type LocalRateLimit struct {
+	Rules []RateLimitRule `json:"rules,omitempty"`
+}
+
+// RateLimitRule limits the requests matching all the client selectors
+type RateLimitRule struct {
+	ClientSelectors []RateLimitSelectCondition `json:"clientSelectors,omitempty"`
+	Limit           RateLimitValue             `json:"limit"`
+}
+
+// RateLimitSelectCondition selects the requests a rule applies to
+type RateLimitSelectCondition struct {
+	SourceCIDR *SourceMatch `json:"sourceCIDR,omitempty"`
+}
+
+// SourceMatchType defines how the client addresses matching a CIDR share the limit
+type SourceMatchType string
+
+const (
+	// SourceMatchExact shares the limit between all the addresses of the CIDR
+	SourceMatchExact SourceMatchType = "Exact"
+	// SourceMatchDistinct gives each address of the CIDR its own limit
+	SourceMatchDistinct SourceMatchType = "Distinct"
+)
+
+// SourceMatch matches the client address
+type SourceMatch struct {
+	Type  *SourceMatchType `json:"type,omitempty"`
+	Value string           `json:"value"`
+}
+
+// RateLimitUnit is the period of a rate limit
+type RateLimitUnit string
+
+const (
+	RateLimitUnitSecond RateLimitUnit = "Second"
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+	RateLimitUnitHour   RateLimitUnit = "Hour"
+	RateLimitUnitDay    RateLimitUnit = "Day"
+)
+
+// RateLimitValue is the number of requests allowed on each period
+type RateLimitValue struct {
+	Requests uint          `json:"requests"`
+	Unit     RateLimitUnit `json:"unit"`
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/authreq"
+	"github.com/rikatz/ingress-nginx-annotations/gatewayapi/envoygateway"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	authURLAnnotation                 = "auth-url"
+	authMethodAnnotation              = "auth-method"
+	authSigninAnnotation              = "auth-signin"
+	authSigninRedirectParamAnnotation = "auth-signin-redirect-param"
+	authSnippetAnnotation             = "auth-snippet"
+	authCacheKeyAnnotation            = "auth-cache-key"
+	authCacheDurationAnnotation       = "auth-cache-duration"
+	authKeepaliveAnnotation           = "auth-keepalive"
+	authKeepaliveShareVarsAnnotation  = "auth-keepalive-share-vars"
+	authKeepaliveRequestsAnnotation   = "auth-keepalive-requests"
+	authKeepaliveTimeoutAnnotation    = "auth-keepalive-timeout"
+	authResponseHeadersAnnotation     = "auth-response-headers"
+	authProxySetHeadersAnnotation     = "auth-proxy-set-headers"
+	authRequestRedirectAnnotation     = "auth-request-redirect"
+	authAlwaysSetCookieAnnotation     = "auth-always-set-cookie"
+)
+
+var externalAuthAnnotations = []string{
+	authURLAnnotation,
+	authMethodAnnotation,
+	authSigninAnnotation,
+	authSigninRedirectParamAnnotation,
+	authSnippetAnnotation,
+	authCacheKeyAnnotation,
+	authCacheDurationAnnotation,
+	authKeepaliveAnnotation,
+	authKeepaliveShareVarsAnnotation,
+	authKeepaliveRequestsAnnotation,
+	authKeepaliveTimeoutAnnotation,
+	authResponseHeadersAnnotation,
+	authProxySetHeadersAnnotation,
+	authRequestRedirectAnnotation,
+	authAlwaysSetCookieAnnotation,
+}
+
+// translateExternalAuth converts auth-url to the ExternalAuth filter of the rules, or to
+// the extAuth of an Envoy Gateway SecurityPolicy when Options.EnvoyGateway is set.
+// The authentication service must be a Service of the cluster, referenced by auth-url
+// with its cluster DNS name
+func (c *converter) translateExternalAuth(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(authURLAnnotation); !ok {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, err := authreq.ParseWithConfig(c.ing, c.config)
+	if !config.Enabled() {
+		c.untranslatedExternalAuth(fmt.Sprintf("auth-url is not valid: %s", err))
+		return routes
+	}
+	if config.Endpoint.HostVariables {
+		c.untranslatedExternalAuth(fmt.Sprintf("the host of auth-url %s contains nginx variables, and the authentication service is only known for each request", config.URL))
+		return routes
+	}
+	ref, ok := serviceReference(config.Endpoint, c.ing.Namespace)
+	if !ok {
+		c.untranslatedExternalAuth(fmt.Sprintf("auth-url %s is not a Service of the cluster, like name.namespace.svc, and must be exposed by a Service", config.URL))
+		return routes
+	}
+
+	for name, reason := range map[string]string{
+		authMethodAnnotation:              "the method of the authentication requests is implementation specific",
+		authSigninAnnotation:              "Gateway API does not redirect unauthenticated requests to a sign in page",
+		authSigninRedirectParamAnnotation: "Gateway API does not redirect unauthenticated requests to a sign in page",
+		authSnippetAnnotation:             "nginx snippets cannot be converted",
+		authCacheKeyAnnotation:            "the authentication responses are not cached",
+		authCacheDurationAnnotation:       "the authentication responses are not cached",
+		authKeepaliveAnnotation:           "the connections to the authentication service are implementation specific",
+		authKeepaliveShareVarsAnnotation:  "the connections to the authentication service are implementation specific",
+		authKeepaliveRequestsAnnotation:   "the connections to the authentication service are implementation specific",
+		authKeepaliveTimeoutAnnotation:    "the connections to the authentication service are implementation specific",
+		authRequestRedirectAnnotation:     "the headers of the authentication requests cannot be set",
+		authAlwaysSetCookieAnnotation:     "the headers of the authentication responses are not sent to the client",
+	} {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+	c.translateAuthProxySetHeaders(routes, config)
+	if config.SignIn != "" {
+		c.warn("unauthenticated requests are rejected instead of being redirected to %s, sign in flows like oauth2-proxy need the OIDC support of the implementation", config.SignIn)
+	}
+	if config.Endpoint.Scheme == "https" {
+		c.warn("auth-url %s uses https, and Service %s needs a BackendTLSPolicy", config.URL, ref.Name)
+	}
+	if config.Endpoint.RawQuery != "" {
+		c.warn("the query string %s of auth-url is not sent to the authentication service", config.Endpoint.RawQuery)
+	}
+	if ref.Namespace != nil {
+		c.warn("the authentication Service %s/%s needs a ReferenceGrant for the routes of namespace %s", *ref.Namespace, ref.Name, c.ing.Namespace)
+	}
+	c.warn("ingress-nginx sends the authentication requests to %s, with the original URI on X-Original-URI, while the path of the request is appended to %s after the migration",
+		config.Endpoint.Path, config.Endpoint.Path)
+
+	if c.opts.EnvoyGateway {
+		c.securityPolicy(routes).Spec.ExtAuth = &envoygateway.ExtAuth{
+			HTTP: &envoygateway.HTTPExtAuthService{
+				BackendRefs:      []gatewayv1.BackendRef{{BackendObjectReference: ref}},
+				Path:             config.Endpoint.Path,
+				HeadersToBackend: config.ResponseHeaders,
+			},
+		}
+		c.warn("ingress-nginx accepts the requests when the authentication service returns any 2xx status code, while Envoy Gateway only accepts 200")
+	} else {
+		if len(config.ResponseHeaders) == 0 {
+			c.warn("auth-response-headers is not set, and all the headers of the authentication response are sent to the backends, while ingress-nginx does not send any")
+		}
+		addFilter(routes, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterExternalAuth,
+			ExternalAuth: &gatewayv1.HTTPExternalAuthFilter{
+				ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+				BackendRef:           ref,
+				HTTPAuthConfig: &gatewayv1.HTTPAuthConfig{
+					Path:                   config.Endpoint.Path,
+					AllowedResponseHeaders: config.ResponseHeaders,
+				},
+			},
+		})
+		c.warn("ingress-nginx accepts the requests when the authentication service returns any 2xx status code, while the ExternalAuth filter only accepts 200")
+	}
+	c.translated(authURLAnnotation)
+	c.translated(authResponseHeadersAnnotation)
+	return routes
+}
+
+// translateAuthProxySetHeaders converts the headers of the auth-proxy-set-headers
+// ConfigMap to a RequestHeaderModifier filter. ingress-nginx only sends them to the
+// authentication service, while the filter also sends them to the backends
+func (c *converter) translateAuthProxySetHeaders(routes []gatewayv1.HTTPRoute, config *authreq.Config) {
+	if _, ok := c.value(authProxySetHeadersAnnotation); !ok {
+		return
+	}
+	switch {
+	case config.ProxySetHeaders.Name == "":
+		c.untranslated(authProxySetHeadersAnnotation, "auth-proxy-set-headers is not valid")
+		return
+	case c.opts.ConfigMaps == nil:
+		c.untranslated(authProxySetHeadersAnnotation, "ConfigMaps cannot be read by the converter")
+		return
+	}
+	headers, err := c.loadHeaders(config.ProxySetHeaders)
+	if err != nil {
+		c.untranslated(authProxySetHeadersAnnotation, err.Error())
+		return
+	}
+
+	if len(headers) > 0 {
+		addFilter(routes, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+				Set: headers,
+			},
+		})
+		c.warn("the headers of configmap %s are also sent to the backends, and implementations may only set them after the authentication request", config.ProxySetHeaders)
+	}
+	c.translated(authProxySetHeadersAnnotation)
+}
+
+// untranslatedExternalAuth reports all the external authentication annotations set on
+// the Ingress as untranslated. The routes are not authenticated anymore
+func (c *converter) untranslatedExternalAuth(reason string) {
+	for _, name := range externalAuthAnnotations {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+	c.warn("external authentication was not converted, and the routes of Ingress %s/%s are not authenticated", c.ing.Namespace, c.ing.Name)
+}
+
+// serviceReference returns the reference to the Service of an endpoint using the cluster
+// DNS, like name.namespace.svc or name.namespace.svc.cluster.local. Shorter names are
+// resolved with the search domains of the controller, and are not converted. The
+// namespace is only set when the Service is in another namespace
+func serviceReference(endpoint *authreq.Endpoint, namespace string) (gatewayv1.BackendObjectReference, bool) {
+	host := strings.TrimSuffix(strings.ToLower(endpoint.Host), ".")
+	parts := strings.Split(strings.TrimSuffix(host, ".cluster.local"), ".")
+	if len(parts) != 3 || parts[2] != "svc" {
+		return gatewayv1.BackendObjectReference{}, false
+	}
+
+	ref := gatewayv1.BackendObjectReference{
+		Name: gatewayv1.ObjectName(parts[0]),
+		Port: ptr.To(gatewayv1.PortNumber(endpoint.DefaultPort())), //#nosec G115
+	}
+	if parts[1] != namespace {
+		ref.Namespace = ptr.To(gatewayv1.Namespace(parts[1]))
+	}
+	return ref, true
+}
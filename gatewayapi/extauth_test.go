@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/gatewayapi/envoygateway"
+	"github.com/rikatz/ingress-nginx-annotations/references"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConvertExternalAuth(t *testing.T) {
+	oauth2Proxy := gatewayv1.BackendObjectReference{
+		Name:      "oauth2-proxy",
+		Namespace: ptr.To[gatewayv1.Namespace]("auth"),
+		Port:      ptr.To[gatewayv1.PortNumber](4180),
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expected     *gatewayv1.HTTPExternalAuthFilter
+		untranslated []string
+	}{
+		{
+			name: "oauth2-proxy",
+			annotations: map[string]string{
+				"auth-url":              "http://oauth2-proxy.auth.svc.cluster.local:4180/oauth2/auth",
+				"auth-signin":           "https://$host/oauth2/start?rd=$escaped_request_uri",
+				"auth-response-headers": "X-Auth-Request-User,X-Auth-Request-Email",
+			},
+			expected: &gatewayv1.HTTPExternalAuthFilter{
+				ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+				BackendRef:           oauth2Proxy,
+				HTTPAuthConfig: &gatewayv1.HTTPAuthConfig{
+					Path:                   "/oauth2/auth",
+					AllowedResponseHeaders: []string{"X-Auth-Request-User", "X-Auth-Request-Email"},
+				},
+			},
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-signin"},
+		},
+		{
+			name: "service of the namespace",
+			annotations: map[string]string{
+				"auth-url":       "https://auth.default.svc/verify",
+				"auth-cache-key": "$remote_user",
+			},
+			expected: &gatewayv1.HTTPExternalAuthFilter{
+				ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+				BackendRef:           gatewayv1.BackendObjectReference{Name: "auth", Port: ptr.To[gatewayv1.PortNumber](443)},
+				HTTPAuthConfig:       &gatewayv1.HTTPAuthConfig{Path: "/verify"},
+			},
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-cache-key"},
+		},
+		{
+			name: "external service",
+			annotations: map[string]string{
+				"auth-url":              "https://auth.example.com/verify",
+				"auth-response-headers": "X-User",
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/auth-response-headers",
+				"nginx.ingress.kubernetes.io/auth-url",
+			},
+		},
+		{
+			name: "variables in the host",
+			annotations: map[string]string{
+				"auth-url": "http://$host/auth",
+			},
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-url"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var filter *gatewayv1.HTTPExternalAuthFilter
+			for _, f := range conversion.HTTPRoutes[0].Spec.Rules[0].Filters {
+				if f.Type == gatewayv1.HTTPRouteFilterExternalAuth {
+					filter = f.ExternalAuth
+				}
+			}
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("expected filter %+v, got %+v", tt.expected, filter)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
+
+func TestConvertExternalAuthEnvoyGateway(t *testing.T) {
+	conversion, err := Convert(newAppIngress(map[string]string{
+		"auth-url":              "http://oauth2-proxy.auth.svc:4180/oauth2/auth",
+		"auth-response-headers": "X-Auth-Request-User",
+	}), Options{EnvoyGateway: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, rule := range conversion.HTTPRoutes[0].Spec.Rules {
+		if len(rule.Filters) != 0 {
+			t.Errorf("expected no filters, got %+v", rule.Filters)
+		}
+	}
+	if len(conversion.SecurityPolicies) != 1 {
+		t.Fatalf("expected one SecurityPolicy, got %d", len(conversion.SecurityPolicies))
+	}
+	policy := conversion.SecurityPolicies[0]
+	if policy.Name != "app-security" || policy.Kind != "SecurityPolicy" {
+		t.Errorf("unexpected policy %s %s", policy.Kind, policy.Name)
+	}
+	expected := &envoygateway.ExtAuth{
+		HTTP: &envoygateway.HTTPExtAuthService{
+			BackendRefs: []gatewayv1.BackendRef{{BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name:      "oauth2-proxy",
+				Namespace: ptr.To[gatewayv1.Namespace]("auth"),
+				Port:      ptr.To[gatewayv1.PortNumber](4180),
+			}}},
+			Path:             "/oauth2/auth",
+			HeadersToBackend: []string{"X-Auth-Request-User"},
+		},
+	}
+	if !reflect.DeepEqual(policy.Spec.ExtAuth, expected) {
+		t.Errorf("expected extAuth %+v, got %+v", expected, policy.Spec.ExtAuth)
+	}
+	if !reflect.DeepEqual(policy.Spec.TargetRefs, routeTargetRefs(conversion.HTTPRoutes)) {
+		t.Errorf("expected the policy to target the routes, got %+v", policy.Spec.TargetRefs)
+	}
+}
+
+func TestConvertAuthProxySetHeaders(t *testing.T) {
+	configMaps := references.NewFake(
+		newConfigMap("auth-headers", map[string]string{"X-Tenant": "a", "X-Auth-Source": "ingress"}),
+		newConfigMap("injection", map[string]string{"X-Tenant": "a\nX-Admin: true"}),
+	)
+
+	tests := []struct {
+		name         string
+		configMap    string
+		options      Options
+		expected     *gatewayv1.HTTPHeaderFilter
+		untranslated []string
+	}{
+		{
+			name:      "headers",
+			configMap: "auth-headers",
+			options:   Options{ConfigMaps: configMaps},
+			expected: &gatewayv1.HTTPHeaderFilter{
+				Set: []gatewayv1.HTTPHeader{{Name: "X-Auth-Source", Value: "ingress"}, {Name: "X-Tenant", Value: "a"}},
+			},
+			untranslated: []string{},
+		},
+		{
+			name:         "injected header",
+			configMap:    "default/injection",
+			options:      Options{ConfigMaps: configMaps},
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-proxy-set-headers"},
+		},
+		{
+			name:         "without configmap getter",
+			configMap:    "auth-headers",
+			untranslated: []string{"nginx.ingress.kubernetes.io/auth-proxy-set-headers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(map[string]string{
+				"auth-url":               "http://auth.default.svc/verify",
+				"auth-proxy-set-headers": tt.configMap,
+			}), tt.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var filter *gatewayv1.HTTPHeaderFilter
+			for _, f := range conversion.HTTPRoutes[0].Spec.Rules[0].Filters {
+				if f.Type == gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+					filter = f.RequestHeaderModifier
+				}
+			}
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("expected filter %+v, got %+v", tt.expected, filter)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/customheaders"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/rewrite"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	serverAliasAnnotation         = "server-alias"
+	proxyConnectTimeoutAnnotation = "proxy-connect-timeout"
+	proxySendTimeoutAnnotation    = "proxy-send-timeout"
+	proxyReadTimeoutAnnotation    = "proxy-read-timeout"
+	rewriteTargetAnnotation       = "rewrite-target"
+	useRegexAnnotation            = "use-regex"
+	mirrorTargetAnnotation        = "mirror-target"
+	customHeadersAnnotation       = "custom-headers"
+	sslRedirectAnnotation         = "ssl-redirect"
+	forceSSLRedirectAnnotation    = "force-ssl-redirect"
+)
+
+// captureGroupRegex matches the references to regex capture groups, like $1
+var captureGroupRegex = regexp.MustCompile(`\$[0-9]+`)
+
+// mirrorServiceRegex matches the cluster local address of a Service, with an optional port
+var mirrorServiceRegex = regexp.MustCompile(`^([a-z0-9-]+)\.([a-z0-9-]+)\.svc(\.cluster\.local)?$`)
+
+// translateServerAlias adds the server aliases as hostnames of the routes
+func (c *converter) translateServerAlias(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	value, ok := c.value(serverAliasAnnotation)
+	if !ok {
+		return routes
+	}
+
+	aliases := make([]gatewayv1.Hostname, 0)
+	for _, alias := range strings.Split(value, ",") {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+		if strings.HasPrefix(alias, "~") {
+			c.untranslated(serverAliasAnnotation, fmt.Sprintf("regular expression alias %s cannot be used as a Gateway API hostname", alias))
+			return routes
+		}
+		aliases = append(aliases, gatewayv1.Hostname(alias))
+	}
+
+	for i := range routes {
+		// Routes without hostnames already match any host
+		if len(routes[i].Spec.Hostnames) == 0 {
+			continue
+		}
+		for _, alias := range aliases {
+			if !slices.Contains(routes[i].Spec.Hostnames, alias) {
+				routes[i].Spec.Hostnames = append(routes[i].Spec.Hostnames, alias)
+			}
+		}
+	}
+	c.translated(serverAliasAnnotation)
+	return routes
+}
+
+// translateTimeouts converts the proxy timeouts to the backend request timeout. Gateway API
+// has a single timeout for the whole backend request, so the highest timeout is used
+func (c *converter) translateTimeouts(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	var timeout time.Duration
+	var found []string
+	for _, name := range []string{proxyConnectTimeoutAnnotation, proxySendTimeoutAnnotation, proxyReadTimeoutAnnotation} {
+		value, ok := c.value(name)
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || seconds < 0 {
+			c.untranslated(name, fmt.Sprintf("timeout %s is not a number of seconds", value))
+			continue
+		}
+		timeout = max(timeout, time.Duration(seconds)*time.Second)
+		found = append(found, name)
+		c.translated(name)
+	}
+	if len(found) == 0 {
+		return routes
+	}
+
+	c.warn("%s converted to a single backendRequest timeout of %s, as Gateway API does not configure each phase of the request",
+		strings.Join(found, ", "), timeout)
+	duration := gatewayv1.Duration(timeout.String())
+	for i := range routes {
+		for j := range routes[i].Spec.Rules {
+			if routes[i].Spec.Rules[j].Timeouts == nil {
+				routes[i].Spec.Rules[j].Timeouts = &gatewayv1.HTTPRouteTimeouts{}
+			}
+			routes[i].Spec.Rules[j].Timeouts.BackendRequest = ptr.To(duration)
+		}
+	}
+	return routes
+}
+
+// translateRewrite converts rewrite-target using the analysis of each path: rewrites
+// without capture groups replace the full path, and the prefix rewrites documented by
+// ingress-nginx, like /app(/|$)(.*) to /$2, replace the prefix of a path prefix match.
+// Other rewrites need regular expression substitutions, and are not translated
+func (c *converter) translateRewrite(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(useRegexAnnotation); ok {
+		// use-regex only changes how paths are matched, that is done when generating the routes
+		c.translated(useRegexAnnotation)
+	}
+
+	target, ok := c.value(rewriteTargetAnnotation)
+	if !ok {
+		return routes
+	}
+	analysis := rewrite.AnalyzeWithConfig(c.ing, c.config)
+
+	// rewrites contains the rewrite of each rule, by route and rule index
+	rewrites := make(map[[2]int]rewrite.Rewrite)
+	for i, route := range routes {
+		host := ""
+		if len(route.Spec.Hostnames) > 0 {
+			host = string(route.Spec.Hostnames[0])
+		}
+		for j, rule := range route.Spec.Rules {
+			r := rewrite.Rewrite{Type: rewrite.RewriteFullPath, Replacement: target}
+			path := ""
+			if len(rule.Matches) > 0 && rule.Matches[0].Path != nil && rule.Matches[0].Path.Value != nil {
+				path = *rule.Matches[0].Path.Value
+				if p, ok := analysis.Path(host, path); ok {
+					r = p.Rewrite
+				}
+			}
+			if r.Type == rewrite.RewriteFullPath && captureGroupRegex.MatchString(r.Replacement) {
+				reason := fmt.Sprintf("rewrite-target %s references capture groups, but the default backend does not have a regex path", target)
+				if path != "" {
+					reason = fmt.Sprintf("rewrite-target %s references capture groups, but path %s is not a regex path with capture groups", target, path)
+				}
+				r = rewrite.Rewrite{Type: rewrite.RewriteRegex, Reason: reason}
+			}
+			if r.Type == rewrite.RewriteRegex {
+				c.untranslated(rewriteTargetAnnotation, r.Reason)
+				return routes
+			}
+			rewrites[[2]int{i, j}] = r
+		}
+	}
+
+	for key, r := range rewrites {
+		rule := &routes[key[0]].Spec.Rules[key[1]]
+		modifier := &gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: ptr.To(r.Replacement),
+		}
+		if r.Type == rewrite.RewritePrefixMatch {
+			modifier = &gatewayv1.HTTPPathModifier{
+				Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: ptr.To(r.Replacement),
+			}
+			for k := range rule.Matches {
+				rule.Matches[k].Path = &gatewayv1.HTTPPathMatch{
+					Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+					Value: ptr.To(r.Prefix),
+				}
+			}
+		}
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+			Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Path: modifier},
+		})
+	}
+	for _, p := range analysis.Paths {
+		for _, warning := range p.Rewrite.Warnings {
+			c.warn("%s", warning)
+		}
+	}
+	c.translated(rewriteTargetAnnotation)
+	return routes
+}
+
+// translateMirror converts mirror-target when it points to a Service of the cluster,
+// as Gateway API can only mirror requests to backends
+func (c *converter) translateMirror(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	target, ok := c.value(mirrorTargetAnnotation)
+	if !ok {
+		return routes
+	}
+
+	backendRef, err := mirrorBackendRef(target)
+	if err != nil {
+		c.untranslated(mirrorTargetAnnotation, err.Error())
+		return routes
+	}
+	if backendRef.Namespace != nil && string(*backendRef.Namespace) == c.ing.Namespace {
+		backendRef.Namespace = nil
+	} else {
+		c.warn("mirror target %s is on another namespace and requires a ReferenceGrant", target)
+	}
+
+	addFilter(routes, gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: backendRef,
+		},
+	})
+	c.translated(mirrorTargetAnnotation)
+	return routes
+}
+
+// mirrorBackendRef converts a mirror URL like http://svc.namespace.svc.cluster.local:8080
+// to a backend reference
+func mirrorBackendRef(target string) (gatewayv1.BackendObjectReference, error) {
+	// ingress-nginx mirrors to the same URI when the target ends with $request_uri
+	u, err := url.Parse(strings.TrimSuffix(target, "$request_uri"))
+	if err != nil || u.Host == "" {
+		return gatewayv1.BackendObjectReference{}, fmt.Errorf("mirror target %s is not a valid URL", target)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return gatewayv1.BackendObjectReference{}, fmt.Errorf("mirror target %s changes the request path, that is not supported by Gateway API", target)
+	}
+
+	host, port := u.Host, ""
+	if h, p, err := net.SplitHostPort(u.Host); err == nil {
+		host, port = h, p
+	}
+	matches := mirrorServiceRegex.FindStringSubmatch(host)
+	if matches == nil {
+		return gatewayv1.BackendObjectReference{}, fmt.Errorf("mirror target %s is not a Service of the cluster", target)
+	}
+
+	number := 80
+	if u.Scheme == "https" {
+		number = 443
+	}
+	if port != "" {
+		if number, err = strconv.Atoi(port); err != nil {
+			return gatewayv1.BackendObjectReference{}, fmt.Errorf("mirror target %s contains an invalid port", target)
+		}
+	}
+
+	return gatewayv1.BackendObjectReference{
+		Name:      gatewayv1.ObjectName(matches[1]),
+		Namespace: ptr.To(gatewayv1.Namespace(matches[2])),
+		Port:      ptr.To(gatewayv1.PortNumber(number)),
+	}, nil
+}
+
+// translateCustomHeaders converts the headers of the custom-headers ConfigMap to
+// a ResponseHeaderModifier filter. As ingress-nginx, the headers are not set when any
+// of them is not valid
+func (c *converter) translateCustomHeaders(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(customHeadersAnnotation); !ok {
+		return routes
+	}
+	if c.opts.ConfigMaps == nil {
+		c.untranslated(customHeadersAnnotation, "ConfigMaps cannot be read by the converter")
+		return routes
+	}
+	config, err := customheaders.ParseWithConfig(c.ing, c.config)
+	if !config.Enabled() {
+		c.untranslated(customHeadersAnnotation, fmt.Sprintf("custom-headers is not valid: %s", err))
+		return routes
+	}
+	headers, err := c.loadHeaders(config.ConfigMap)
+	if err != nil {
+		c.untranslated(customHeadersAnnotation, err.Error())
+		return routes
+	}
+
+	if len(headers) > 0 {
+		addFilter(routes, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+				Set: headers,
+			},
+		})
+	}
+	c.translated(customHeadersAnnotation)
+	return routes
+}
+
+// loadHeaders returns the validated headers of a ConfigMap as Gateway API headers. nginx
+// variables on the values are not expanded by Gateway API, and are reported as warnings
+func (c *converter) loadHeaders(ref types.NamespacedName) ([]gatewayv1.HTTPHeader, error) {
+	loaded, err := customheaders.LoadHeaders(c.opts.ConfigMaps, ref)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]gatewayv1.HTTPHeader, 0, len(loaded))
+	for _, header := range loaded {
+		if strings.Contains(header.Value, "$") {
+			c.warn("value of header %s of configmap %s is sent literally, as nginx variables are not supported: %s", header.Name, ref, header.Value)
+		}
+		headers = append(headers, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(header.Name),
+			Value: header.Value,
+		})
+	}
+	return headers, nil
+}
+
+// translateSSLRedirect generates a route redirecting HTTP requests to HTTPS for each
+// route whose host has TLS configured in spec.tls, or for each route with hostnames
+// when force-ssl-redirect is set. As on ingress-nginx, ssl-redirect defaults to true,
+// so hosts with TLS are redirected unless it is set to false. Routes without hostnames,
+// like the default backend, are not redirected, as the redirect would apply to any host
+// of the listener
+func (c *converter) translateSSLRedirect(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	enabled := make([]string, 0)
+	sslRedirect, force := true, false
+	for _, name := range []string{sslRedirectAnnotation, forceSSLRedirectAnnotation} {
+		value, ok := c.value(name)
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(value), "true") {
+			c.translated(name)
+			sslRedirect = sslRedirect && name != sslRedirectAnnotation
+			continue
+		}
+		enabled = append(enabled, name)
+		force = force || name == forceSSLRedirectAnnotation
+	}
+	if !sslRedirect && !force {
+		return routes
+	}
+
+	parentRefs := c.opts.HTTPParentRefs
+	if len(parentRefs) == 0 {
+		parentRefs = c.opts.ParentRefs
+	}
+
+	redirects := make([]gatewayv1.HTTPRoute, 0)
+	for _, route := range routes {
+		if len(route.Spec.Hostnames) == 0 {
+			if len(enabled) > 0 {
+				c.warn("route %s does not have hostnames, and its requests are not redirected to HTTPS", route.Name)
+			}
+			continue
+		}
+		// The first hostname is the host of the Ingress rule, followed by the aliases
+		if !force && !c.hasTLS(string(route.Spec.Hostnames[0])) {
+			continue
+		}
+		redirect := c.newRoute(route.Name + "-ssl-redirect")
+		redirect.Spec.ParentRefs = slices.Clone(parentRefs)
+		redirect.Spec.Hostnames = slices.Clone(route.Spec.Hostnames)
+		redirect.Spec.Rules = []gatewayv1.HTTPRouteRule{{
+			Filters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Scheme:     ptr.To("https"),
+					StatusCode: ptr.To(http.StatusMovedPermanently),
+				},
+			}},
+		}}
+		redirects = append(redirects, redirect)
+	}
+
+	if len(redirects) == 0 {
+		reason := "no host of the Ingress has TLS configured in spec.tls, and ingress-nginx does not redirect any request to HTTPS"
+		if force {
+			reason = "the Ingress does not have hosts, and a redirect route without hostnames would redirect any host of the listener"
+		}
+		for _, name := range enabled {
+			c.untranslated(name, reason)
+		}
+		return routes
+	}
+	for _, name := range enabled {
+		c.translated(name)
+	}
+	if len(enabled) == 0 {
+		c.warn("ingress-nginx redirects the hosts with TLS to HTTPS by default, set ssl-redirect to false to disable it")
+	}
+	if len(c.opts.HTTPParentRefs) == 0 {
+		c.warn("ssl redirect routes are attached to the same parents of the other routes, and must be attached only to HTTP listeners")
+	}
+	c.warn("ssl redirect uses the status code 301, while ingress-nginx uses 308 by default")
+	return append(routes, redirects...)
+}
+
+// hasTLS returns if the host is listed in the spec.tls of the Ingress, directly or by a
+// wildcard like *.example.com
+func (c *converter) hasTLS(host string) bool {
+	for _, tls := range c.ing.Spec.TLS {
+		for _, tlsHost := range tls.Hosts {
+			if strings.EqualFold(tlsHost, host) {
+				return true
+			}
+			if suffix, ok := strings.CutPrefix(tlsHost, "*."); ok {
+				if _, domain, found := strings.Cut(host, "."); found && strings.EqualFold(domain, suffix) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/ratelimit"
+	"github.com/rikatz/ingress-nginx-annotations/gatewayapi/envoygateway"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	limitRPSAnnotation             = "limit-rps"
+	limitRPMAnnotation             = "limit-rpm"
+	limitConnectionsAnnotation     = "limit-connections"
+	limitBurstMultiplierAnnotation = "limit-burst-multiplier"
+	limitAllowlistAnnotation       = "limit-allowlist"
+	limitRateAnnotation            = "limit-rate"
+	limitRateAfterAnnotation       = "limit-rate-after"
+)
+
+// allAddresses are the CIDRs matching any IPv4 and IPv6 client
+var allAddresses = []string{"0.0.0.0/0", "::/0"}
+
+// translateRateLimit converts the request rate limits to the global rate limit of an
+// Envoy Gateway BackendTrafficPolicy. Each client address has its own limit, as the
+// zones of ingress-nginx are keyed by the client address
+func (c *converter) translateRateLimit(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if !c.opts.EnvoyGateway || len(routes) == 0 {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, _ := ratelimit.ParseWithConfig(c.ing, c.config)
+	if !config.Enabled() {
+		return routes
+	}
+
+	for name, reason := range map[string]string{
+		limitConnectionsAnnotation: "Envoy Gateway does not limit the concurrent connections of each client",
+		limitAllowlistAnnotation:   "Envoy Gateway rate limits cannot exempt client addresses",
+		limitRateAnnotation:        "Envoy Gateway does not limit the bandwidth of responses",
+		limitRateAfterAnnotation:   "Envoy Gateway does not limit the bandwidth of responses",
+	} {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+	if !config.RPS.Enabled() && !config.RPM.Enabled() {
+		return routes
+	}
+
+	rules := make([]envoygateway.RateLimitRule, 0)
+	for _, zone := range []struct {
+		annotation string
+		zone       ratelimit.Zone
+		unit       envoygateway.RateLimitUnit
+		period     time.Duration
+	}{
+		{annotation: limitRPSAnnotation, zone: config.RPS, unit: envoygateway.RateLimitUnitSecond, period: time.Second},
+		{annotation: limitRPMAnnotation, zone: config.RPM, unit: envoygateway.RateLimitUnitMinute, period: time.Minute},
+	} {
+		if !zone.zone.Enabled() {
+			continue
+		}
+		for _, cidr := range allAddresses {
+			rules = append(rules, envoygateway.RateLimitRule{
+				ClientSelectors: []envoygateway.RateLimitSelectCondition{{
+					SourceCIDR: &envoygateway.SourceMatch{Type: ptr.To(envoygateway.SourceMatchDistinct), Value: cidr},
+				}},
+				Limit: envoygateway.RateLimitValue{Requests: uint(zone.zone.Limit), Unit: zone.unit}, //#nosec G115
+			})
+		}
+		c.warn("%s %d is enforced by nginx as one request every %s, with a burst of %d requests, while Envoy Gateway accepts all the requests of each %s window at once",
+			zone.annotation, zone.zone.Limit, zone.period/time.Duration(zone.zone.Limit), zone.zone.Burst, zone.unit)
+		c.translated(zone.annotation)
+	}
+	c.translated(limitBurstMultiplierAnnotation)
+	c.warn("ingress-nginx limits each client to %s on each controller replica, while Envoy Gateway global rate limits are shared by all the proxies and require the rate limit service. Multiply the limits by the number of replicas to keep the same capacity",
+		config.Limits())
+
+	c.result.BackendTrafficPolicies = append(c.result.BackendTrafficPolicies, envoygateway.BackendTrafficPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: envoygateway.APIVersion,
+			Kind:       "BackendTrafficPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-ratelimit", c.ing.Name),
+			Namespace: c.ing.Namespace,
+		},
+		Spec: envoygateway.BackendTrafficPolicySpec{
+			TargetRefs: routeTargetRefs(routes),
+			RateLimit: &envoygateway.RateLimitSpec{
+				Type:   envoygateway.GlobalRateLimitType,
+				Global: &envoygateway.GlobalRateLimit{Rules: rules},
+			},
+		},
+	})
+	return routes
+}
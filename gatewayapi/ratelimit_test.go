@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/gatewayapi/envoygateway"
+	"k8s.io/utils/ptr"
+)
+
+func TestConvertRateLimit(t *testing.T) {
+	annotations := map[string]string{
+		"limit-rps":         "10",
+		"limit-connections": "5",
+		"limit-allowlist":   "10.0.0.0/8",
+	}
+
+	conversion, err := Convert(newAppIngress(annotations), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.BackendTrafficPolicies) != 0 {
+		t.Errorf("expected no policies without EnvoyGateway, got %+v", conversion.BackendTrafficPolicies)
+	}
+	if len(conversion.Untranslated) != 3 {
+		t.Errorf("expected all the annotations untranslated, got %+v", conversion.Untranslated)
+	}
+
+	conversion, err = Convert(newAppIngress(annotations), Options{EnvoyGateway: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.BackendTrafficPolicies) != 1 {
+		t.Fatalf("expected a single policy, got %+v", conversion.BackendTrafficPolicies)
+	}
+	policy := conversion.BackendTrafficPolicies[0]
+	if len(policy.Spec.TargetRefs) != len(conversion.HTTPRoutes) || string(policy.Spec.TargetRefs[0].Name) != conversion.HTTPRoutes[0].Name {
+		t.Errorf("expected the policy to target the routes, got %+v", policy.Spec.TargetRefs)
+	}
+	expected := &envoygateway.RateLimitSpec{
+		Type: envoygateway.GlobalRateLimitType,
+		Global: &envoygateway.GlobalRateLimit{Rules: []envoygateway.RateLimitRule{
+			{
+				ClientSelectors: []envoygateway.RateLimitSelectCondition{{SourceCIDR: &envoygateway.SourceMatch{Type: ptr.To(envoygateway.SourceMatchDistinct), Value: "0.0.0.0/0"}}},
+				Limit:           envoygateway.RateLimitValue{Requests: 10, Unit: envoygateway.RateLimitUnitSecond},
+			},
+			{
+				ClientSelectors: []envoygateway.RateLimitSelectCondition{{SourceCIDR: &envoygateway.SourceMatch{Type: ptr.To(envoygateway.SourceMatchDistinct), Value: "::/0"}}},
+				Limit:           envoygateway.RateLimitValue{Requests: 10, Unit: envoygateway.RateLimitUnitSecond},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(policy.Spec.RateLimit, expected) {
+		t.Errorf("expected rate limit %+v, got %+v", expected, policy.Spec.RateLimit)
+	}
+
+	untranslated := make([]string, 0)
+	for _, ann := range conversion.Untranslated {
+		untranslated = append(untranslated, ann.Annotation)
+	}
+	expectedUntranslated := []string{
+		"nginx.ingress.kubernetes.io/limit-allowlist",
+		"nginx.ingress.kubernetes.io/limit-connections",
+	}
+	if !reflect.DeepEqual(untranslated, expectedUntranslated) {
+		t.Errorf("expected untranslated %v, got %v", expectedUntranslated, untranslated)
+	}
+	if len(conversion.Warnings) != 2 {
+		t.Errorf("expected the burst and replicas warnings, got %v", conversion.Warnings)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/redirect"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	permanentRedirectAnnotation  = "permanent-redirect"
+	permanentRedirectCode        = "permanent-redirect-code"
+	temporalRedirectAnnotation   = "temporal-redirect"
+	temporalRedirectCode         = "temporal-redirect-code"
+	fromToWWWRedirectAnnotation  = "from-to-www-redirect"
+	relativeRedirectsAnnotation  = "relative-redirects"
+	usePortInRedirectsAnnotation = "use-port-in-redirects"
+)
+
+// translateRedirect converts permanent and temporal redirects to a request redirect
+// replacing the backends. As on ingress-nginx, the temporal redirect has precedence
+// over the permanent redirect. nginx returns the URL as is, so the path of the request
+// is always replaced, by / when the URL does not contain a path
+func (c *converter) translateRedirect(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	for _, name := range []string{relativeRedirectsAnnotation, usePortInRedirectsAnnotation} {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, "Gateway API does not define the Location of the redirects issued by the implementation")
+		}
+	}
+
+	_, temporal := c.value(temporalRedirectAnnotation)
+	_, permanent := c.value(permanentRedirectAnnotation)
+	if !temporal && !permanent {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, _ := redirect.ParseWithConfig(c.ing, c.config)
+
+	annotation, codeAnnotation, defaultCode := temporalRedirectAnnotation, temporalRedirectCode, http.StatusFound
+	if temporal {
+		for _, name := range []string{permanentRedirectAnnotation, permanentRedirectCode} {
+			if _, ok := c.value(name); ok {
+				c.untranslated(name, "temporal-redirect has precedence over permanent-redirect")
+			}
+		}
+	} else {
+		annotation, codeAnnotation, defaultCode = permanentRedirectAnnotation, permanentRedirectCode, http.StatusMovedPermanently
+	}
+	if !config.Enabled() {
+		c.untranslated(annotation, "redirect is not a valid http or https URL, and ingress-nginx does not redirect the requests")
+		return routes
+	}
+
+	code := config.Code
+	if value, ok := c.value(codeAnnotation); ok {
+		switch {
+		case strconv.Itoa(code) != strings.TrimSpace(value):
+			c.untranslated(codeAnnotation, fmt.Sprintf("%s is not a valid redirect status code, ingress-nginx uses %d", value, code))
+		case code != http.StatusMovedPermanently && code != http.StatusFound:
+			c.untranslated(codeAnnotation, fmt.Sprintf("status code %d is not supported by Gateway API, %d is used", code, defaultCode))
+			code = defaultCode
+		default:
+			c.translated(codeAnnotation)
+		}
+	}
+
+	filter, err := requestRedirect(config.Target, code)
+	if err != nil {
+		c.untranslated(annotation, err.Error())
+		return routes
+	}
+
+	for i := range routes {
+		for j := range routes[i].Spec.Rules {
+			// The redirect replaces the backend, as on ingress-nginx
+			routes[i].Spec.Rules[j].BackendRefs = nil
+			routes[i].Spec.Rules[j].Filters = append(routes[i].Spec.Rules[j].Filters, gatewayv1.HTTPRouteFilter{
+				Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: filter.DeepCopy(),
+			})
+		}
+	}
+	c.translated(annotation)
+	return routes
+}
+
+// requestRedirect converts the target of a redirect to a request redirect filter
+func requestRedirect(target *redirect.Target, code int) (*gatewayv1.HTTPRequestRedirectFilter, error) {
+	if target.RawQuery != "" || target.Fragment != "" {
+		return nil, fmt.Errorf("redirect contains a query or fragment, that is not supported by Gateway API")
+	}
+	path := target.Path
+	if path == "" {
+		path = "/"
+	}
+	filter := &gatewayv1.HTTPRequestRedirectFilter{
+		Scheme:   ptr.To(target.Scheme),
+		Hostname: ptr.To(gatewayv1.PreciseHostname(target.Host)),
+		Path: &gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: ptr.To(path),
+		},
+		StatusCode: ptr.To(code),
+	}
+	if target.Port != 0 {
+		filter.Port = ptr.To(gatewayv1.PortNumber(target.Port)) //#nosec G115
+	}
+	return filter, nil
+}
+
+// translateFromToWWW generates, for each host of the Ingress, a route redirecting the
+// www variant of the host to the host, or the host without www when the host starts
+// with www. The path and the query of the request are kept, as on ingress-nginx
+func (c *converter) translateFromToWWW(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(fromToWWWRedirectAnnotation); !ok {
+		return routes
+	}
+	c.translated(fromToWWWRedirectAnnotation)
+	config, _ := redirect.ParseWithConfig(c.ing, c.config)
+	if !config.FromToWWW {
+		return routes
+	}
+
+	hosts := make([]string, 0)
+	for _, rule := range c.ing.Spec.Rules {
+		if !slices.Contains(hosts, rule.Host) {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	redirects := make([]gatewayv1.HTTPRoute, 0)
+	for _, host := range hosts {
+		from, ok := redirect.FromToWWWHost(host)
+		if !ok {
+			continue
+		}
+		if slices.Contains(hosts, from) {
+			c.warn("host %s is defined by the Ingress, and is not redirected to %s", from, host)
+			continue
+		}
+		route := c.newRoute(routeName(c.ing.Name, from))
+		route.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(from)}
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{{
+			Filters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Hostname:   ptr.To(gatewayv1.PreciseHostname(host)),
+					StatusCode: ptr.To(http.StatusMovedPermanently),
+				},
+			}},
+		}}
+		redirects = append(redirects, route)
+	}
+	if len(redirects) > 0 {
+		c.warn("from-to-www-redirect uses the status code 301, while ingress-nginx uses %d by default", redirect.DefaultFromToWWWCode)
+	}
+	return append(routes, redirects...)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConvertRedirectCodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		code         int
+		untranslated []string
+	}{
+		{
+			name: "supported code",
+			annotations: map[string]string{
+				"temporal-redirect":      "https://temporal.example.com/",
+				"temporal-redirect-code": "301",
+			},
+			code:         301,
+			untranslated: []string{},
+		},
+		{
+			name: "code outside of the range of ingress-nginx",
+			annotations: map[string]string{
+				"temporal-redirect":      "https://temporal.example.com/",
+				"temporal-redirect-code": "308",
+			},
+			code:         302,
+			untranslated: []string{"nginx.ingress.kubernetes.io/temporal-redirect-code"},
+		},
+		{
+			name: "absolute redirects are implementation specific",
+			annotations: map[string]string{
+				"permanent-redirect":    "https://permanent.example.com/",
+				"relative-redirects":    "true",
+				"use-port-in-redirects": "true",
+			},
+			code: 301,
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/relative-redirects",
+				"nginx.ingress.kubernetes.io/use-port-in-redirects",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			filters := conversion.HTTPRoutes[0].Spec.Rules[0].Filters
+			if len(filters) != 1 || filters[0].RequestRedirect == nil || *filters[0].RequestRedirect.StatusCode != tt.code {
+				t.Errorf("expected a redirect with code %d, got %+v", tt.code, filters)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
+
+func TestConvertFromToWWW(t *testing.T) {
+	ing := newAppIngress(map[string]string{
+		"from-to-www-redirect": "true",
+	})
+	parentRefs := []gatewayv1.ParentReference{{Name: "gateway"}}
+	conversion, err := Convert(ing, Options{ParentRefs: parentRefs})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conversion.HTTPRoutes) != 2 {
+		t.Fatalf("expected the route of the host and the www redirect, got %d routes", len(conversion.HTTPRoutes))
+	}
+	route := conversion.HTTPRoutes[1]
+	if route.Name != "app-www-app-example-com" || !reflect.DeepEqual(route.Spec.ParentRefs, parentRefs) {
+		t.Errorf("unexpected redirect route %s with parents %+v", route.Name, route.Spec.ParentRefs)
+	}
+	if !reflect.DeepEqual(route.Spec.Hostnames, []gatewayv1.Hostname{"www.app.example.com"}) {
+		t.Errorf("expected the www host, got %v", route.Spec.Hostnames)
+	}
+	expected := []gatewayv1.HTTPRouteRule{{
+		Filters: []gatewayv1.HTTPRouteFilter{{
+			Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Hostname:   ptr.To(gatewayv1.PreciseHostname("app.example.com")),
+				StatusCode: ptr.To(301),
+			},
+		}},
+	}}
+	if !reflect.DeepEqual(route.Spec.Rules, expected) {
+		t.Errorf("expected rules %+v, got %+v", expected, route.Spec.Rules)
+	}
+	if len(conversion.Untranslated) != 0 || len(conversion.Warnings) != 1 {
+		t.Errorf("expected only the status code warning, got %+v %v", conversion.Untranslated, conversion.Warnings)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/sessionaffinity"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	affinityAnnotation                             = "affinity"
+	affinityModeAnnotation                         = "affinity-mode"
+	affinityCanaryBehaviorAnnotation               = "affinity-canary-behavior"
+	sessionCookieNameAnnotation                    = "session-cookie-name"
+	sessionCookieSecureAnnotation                  = "session-cookie-secure"
+	sessionCookieExpiresAnnotation                 = "session-cookie-expires"
+	sessionCookieMaxAgeAnnotation                  = "session-cookie-max-age"
+	sessionCookiePathAnnotation                    = "session-cookie-path"
+	sessionCookieDomainAnnotation                  = "session-cookie-domain"
+	sessionCookieSameSiteAnnotation                = "session-cookie-samesite"
+	sessionCookieConditionalSameSiteNoneAnnotation = "session-cookie-conditional-samesite-none"
+	sessionCookieChangeOnFailureAnnotation         = "session-cookie-change-on-failure"
+)
+
+// translateSessionAffinity converts cookie affinity to the session persistence of the rules.
+// The cookie name is always set, as applications and clients may depend on it. The
+// attributes of the cookie that Gateway API does not configure are reported as untranslated
+func (c *converter) translateSessionAffinity(routes []gatewayv1.HTTPRoute) []gatewayv1.HTTPRoute {
+	if _, ok := c.value(affinityAnnotation); !ok {
+		return routes
+	}
+	// Invalid annotations were already reported, and the configuration uses the defaults instead
+	config, _ := sessionaffinity.ParseWithConfig(c.ing, c.config)
+	if !config.Enabled() {
+		return routes
+	}
+
+	persistence := &gatewayv1.SessionPersistence{
+		SessionName: ptr.To(config.Cookie.Name),
+		Type:        ptr.To(gatewayv1.CookieBasedSessionPersistence),
+		CookieConfig: &gatewayv1.CookieConfig{
+			LifetimeType: ptr.To(gatewayv1.SessionCookieLifetimeType),
+		},
+	}
+	c.warn("the format of the session cookie %s is implementation specific, and existing sessions are not kept after the migration", config.Cookie.Name)
+
+	if lifetime := config.Cookie.Lifetime(); lifetime > 0 {
+		persistence.AbsoluteTimeout = ptr.To(gatewayv1.Duration(lifetime.String()))
+		persistence.CookieConfig.LifetimeType = ptr.To(gatewayv1.PermanentCookieLifetimeType)
+		if config.Cookie.MaxAge > 0 && config.Cookie.Expires > 0 && config.Cookie.MaxAge != config.Cookie.Expires {
+			c.warn("session cookie uses the max age of %s, and the expires of %s is ignored", config.Cookie.MaxAge, config.Cookie.Expires)
+		}
+		c.translated(sessionCookieMaxAgeAnnotation)
+		c.translated(sessionCookieExpiresAnnotation)
+	} else if lifetime < 0 {
+		for _, name := range []string{sessionCookieMaxAgeAnnotation, sessionCookieExpiresAnnotation} {
+			c.untranslated(name, fmt.Sprintf("negative cookie lifetime %s cannot be converted", lifetime))
+		}
+	}
+
+	for name, reason := range map[string]string{
+		affinityModeAnnotation:                         "Gateway API does not define how sessions are redistributed when backends change",
+		affinityCanaryBehaviorAnnotation:               "Gateway API does not define how session persistence interacts with weighted backends",
+		sessionCookieSecureAnnotation:                  "the Secure attribute of the session cookie is implementation specific",
+		sessionCookiePathAnnotation:                    "the Path attribute of the session cookie is implementation specific",
+		sessionCookieDomainAnnotation:                  "the Domain attribute of the session cookie is implementation specific",
+		sessionCookieSameSiteAnnotation:                "the SameSite attribute of the session cookie is implementation specific",
+		sessionCookieConditionalSameSiteNoneAnnotation: "the SameSite attribute of the session cookie is implementation specific",
+		sessionCookieChangeOnFailureAnnotation:         "Gateway API does not define if the session changes when the backend fails",
+	} {
+		if _, ok := c.value(name); ok {
+			c.untranslated(name, reason)
+		}
+	}
+
+	for i := range routes {
+		for j := range routes[i].Spec.Rules {
+			routes[i].Spec.Rules[j].SessionPersistence = persistence.DeepCopy()
+		}
+	}
+	c.translated(affinityAnnotation)
+	c.translated(sessionCookieNameAnnotation)
+	return routes
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConvertSessionAffinity(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expected     *gatewayv1.SessionPersistence
+		untranslated []string
+	}{
+		{
+			name: "session cookie with the default name",
+			annotations: map[string]string{
+				"affinity": "cookie",
+			},
+			expected: &gatewayv1.SessionPersistence{
+				SessionName:  ptr.To("INGRESSCOOKIE"),
+				Type:         ptr.To(gatewayv1.CookieBasedSessionPersistence),
+				CookieConfig: &gatewayv1.CookieConfig{LifetimeType: ptr.To(gatewayv1.SessionCookieLifetimeType)},
+			},
+			untranslated: []string{},
+		},
+		{
+			name: "permanent cookie with attributes",
+			annotations: map[string]string{
+				"affinity":                "cookie",
+				"affinity-mode":           "persistent",
+				"session-cookie-name":     "route",
+				"session-cookie-max-age":  "172800",
+				"session-cookie-expires":  "172800",
+				"session-cookie-samesite": "Strict",
+				"session-cookie-path":     "/",
+			},
+			expected: &gatewayv1.SessionPersistence{
+				SessionName:     ptr.To("route"),
+				AbsoluteTimeout: ptr.To(gatewayv1.Duration("48h0m0s")),
+				Type:            ptr.To(gatewayv1.CookieBasedSessionPersistence),
+				CookieConfig:    &gatewayv1.CookieConfig{LifetimeType: ptr.To(gatewayv1.PermanentCookieLifetimeType)},
+			},
+			untranslated: []string{
+				"nginx.ingress.kubernetes.io/affinity-mode",
+				"nginx.ingress.kubernetes.io/session-cookie-path",
+				"nginx.ingress.kubernetes.io/session-cookie-samesite",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := Convert(newAppIngress(tt.annotations), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			persistence := conversion.HTTPRoutes[0].Spec.Rules[0].SessionPersistence
+			if !reflect.DeepEqual(persistence, tt.expected) {
+				t.Errorf("expected session persistence %+v, got %+v", tt.expected, persistence)
+			}
+			untranslated := make([]string, 0)
+			for _, ann := range conversion.Untranslated {
+				untranslated = append(untranslated, ann.Annotation)
+			}
+			if !reflect.DeepEqual(untranslated, tt.untranslated) {
+				t.Errorf("expected untranslated %v, got %v", tt.untranslated, untranslated)
+			}
+		})
+	}
+}
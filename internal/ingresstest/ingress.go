@@ -0,0 +1,55 @@
+/*
+Copyright 2015 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ingresstest builds the Ingresses used by the tests of the annotations
+package ingresstest
+
+import (
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// New returns an Ingress named foo in the default namespace, with the annotations.
+// The names of the annotations are prefixed by nginx.ingress.kubernetes.io/
+func New(annotations map[string]string) *networking.Ingress {
+	prefixed := make(map[string]string, len(annotations))
+	for name, value := range annotations {
+		prefixed[parser.GetAnnotationWithPrefix(name)] = value
+	}
+	return &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Annotations: prefixed},
+	}
+}
+
+// WithPath adds a rule to the Ingress, routing the path of the host to the port of
+// the Service. The rule has no backend when service is empty
+func WithPath(ing *networking.Ingress, host, path string, pathType networking.PathType, service string, port int32) *networking.Ingress {
+	httpPath := networking.HTTPIngressPath{Path: path, PathType: &pathType}
+	if service != "" {
+		httpPath.Backend.Service = &networking.IngressServiceBackend{
+			Name: service,
+			Port: networking.ServiceBackendPort{Number: port},
+		}
+	}
+	ing.Spec.Rules = append(ing.Spec.Rules, networking.IngressRule{
+		Host: host,
+		IngressRuleValue: networking.IngressRuleValue{
+			HTTP: &networking.HTTPIngressRuleValue{Paths: []networking.HTTPIngressPath{httpPath}},
+		},
+	})
+	return ing
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Config defines how the annotations of a single controller installation are
+// parsed and validated. Different configurations can be used concurrently, for
+// instance to validate Ingresses of controllers with different prefixes.
+// DefaultConfig should be used to create a new Config
+type Config struct {
+	// Prefix is the annotation prefix used by the controller (--annotations-prefix).
+	// If empty, DefaultAnnotationsPrefix is used
+	Prefix string
+	// EnableValidation defines if the annotation validators should be executed
+	EnableValidation bool
+	// MaxRisk is the maximum risk accepted. Annotations with a higher risk are reported
+	MaxRisk AnnotationRisk
+	// AllowedGroups contains the annotation groups that can be used. If empty,
+	// all groups are allowed
+	AllowedGroups []AnnotationGroup
+	// ReportUnknown defines if annotations using the prefix that do not exist should be reported
+	ReportUnknown bool
+}
+
+// DefaultConfig returns a Config based on the package level AnnotationsPrefix
+// and EnableAnnotationValidation, accepting any risk and any group
+func DefaultConfig() Config {
+	return Config{
+		Prefix:           AnnotationsPrefix,
+		EnableValidation: EnableAnnotationValidation,
+		MaxRisk:          AnnotationRiskCritical,
+	}
+}
+
+// AnnotationWithPrefix returns the full annotation name using the configured prefix
+func (c Config) AnnotationWithPrefix(suffix string) string {
+	return fmt.Sprintf("%v/%v", c.prefix(), suffix)
+}
+
+// TrimAnnotationPrefix removes the configured prefix from the annotation
+func (c Config) TrimAnnotationPrefix(annotation string) string {
+	return strings.TrimPrefix(annotation, c.prefix()+"/")
+}
+
+// HasPrefix returns if the annotation uses the configured prefix
+func (c Config) HasPrefix(annotation string) bool {
+	return strings.HasPrefix(annotation, c.prefix()+"/")
+}
+
+// IsGroupAllowed returns if annotations of the group can be used
+func (c Config) IsGroupAllowed(group AnnotationGroup) bool {
+	return len(c.AllowedGroups) == 0 || slices.Contains(c.AllowedGroups, group)
+}
+
+func (c Config) prefix() string {
+	if c.Prefix == "" {
+		return DefaultAnnotationsPrefix
+	}
+	return c.Prefix
+}
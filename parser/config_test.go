@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistryWithConfig(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	ing := &networking.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				"custom.example.com/limit-rps":            "not-a-number",
+				"custom.example.com/server-snippet":       "return 200;",
+				"custom.example.com/limit-rsp":            "10",
+				GetAnnotationWithPrefix("limit-rps"):      "10",
+				GetAnnotationWithPrefix("server-snippet"): "return 200;",
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		want   map[FindingType][]string
+	}{
+		{
+			name:   "default config should just validate the default prefix",
+			config: DefaultConfig(),
+			want:   map[FindingType][]string{},
+		},
+		{
+			name: "custom prefix should validate the custom annotations",
+			config: Config{
+				Prefix:           "custom.example.com",
+				EnableValidation: true,
+				MaxRisk:          AnnotationRiskCritical,
+			},
+			want: map[FindingType][]string{
+				FindingInvalidValue: {"custom.example.com/limit-rps"},
+			},
+		},
+		{
+			name: "disabled validation should not run the validators",
+			config: Config{
+				Prefix:  "custom.example.com",
+				MaxRisk: AnnotationRiskCritical,
+			},
+			want: map[FindingType][]string{},
+		},
+		{
+			name: "all checks enabled",
+			config: Config{
+				Prefix:           "custom.example.com",
+				EnableValidation: true,
+				MaxRisk:          AnnotationRiskHigh,
+				AllowedGroups:    []AnnotationGroup{"rate-limit"},
+				ReportUnknown:    true,
+			},
+			want: map[FindingType][]string{
+				FindingInvalidValue:      {"custom.example.com/limit-rps"},
+				FindingRiskyAnnotation:   {"custom.example.com/server-snippet"},
+				FindingDisallowedGroup:   {"custom.example.com/server-snippet"},
+				FindingUnknownAnnotation: {"custom.example.com/limit-rsp"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The registries with different configurations must be usable concurrently
+			t.Parallel()
+			result, err := registry.WithConfig(tt.config).Validate(ing)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := make(map[FindingType][]string)
+			for _, finding := range result.Findings {
+				got[finding.Type] = append(got[finding.Type], finding.Annotation)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %v", got, tt.want)
+			}
+			for findingType, annotations := range tt.want {
+				if len(got[findingType]) != len(annotations) || got[findingType][0] != annotations[0] {
+					t.Errorf("Validate() %s = %v, want %v", findingType, got[findingType], annotations)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckAnnotationWithConfig(t *testing.T) {
+	fields := AnnotationFields{
+		"limit-rps": {Validator: ValidateInt},
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				"custom.example.com/limit-rps": "10",
+			},
+		},
+	}
+	config := DefaultConfig()
+	config.Prefix = "custom.example.com"
+
+	got, err := CheckAnnotationWithConfig("limit-rps", ing, fields, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "custom.example.com/limit-rps" {
+		t.Errorf("CheckAnnotationWithConfig() = %s", got)
+	}
+	if got := GetAnnotationWithPrefix("limit-rps"); got != DefaultAnnotationsPrefix+"/limit-rps" {
+		t.Errorf("expected the package level prefix to be untouched, got %s", got)
+	}
+}
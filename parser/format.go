@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Formats of the values accepted by the validators, to be set on AnnotationConfig.Format
+const (
+	FormatBool       = "boolean: true or false"
+	FormatInt        = "integer"
+	FormatCIDRs      = "comma separated list of IPs or CIDRs"
+	FormatNull       = "any value, not validated"
+	FormatCommonName = commonNamePrefix + " followed by a regular expression"
+)
+
+// Formats built from the same values as their validators, so they cannot drift apart
+var (
+	FormatServerName        = fmt.Sprintf("server name matching %s", IsValidRegex.String())
+	FormatArrayOfServerName = fmt.Sprintf("list of server names matching %s, separated by %q", IsValidRegex.String(), serverNameSeparator)
+	FormatServiceName       = fmt.Sprintf("Service name, a DNS-1035 label of at most %d characters", utilvalidation.DNS1035LabelMaxLength)
+)
+
+// RegexFormat returns the format of the values accepted by ValidateRegex
+func RegexFormat(regex *regexp.Regexp, removeSpace bool) string {
+	if removeSpace {
+		return fmt.Sprintf("value matching %s, after removing spaces", regex.String())
+	}
+	return fmt.Sprintf("value matching %s", regex.String())
+}
+
+// OptionsFormat returns the format of the values accepted by ValidateOptions
+func OptionsFormat(options []string, caseSensitive bool) string {
+	format := fmt.Sprintf("one of %s", strings.Join(options, ", "))
+	if !caseSensitive {
+		format += " (case insensitive)"
+	}
+	return format
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRegexFormat(t *testing.T) {
+	regex := regexp.MustCompile(`^(on|off)$`)
+	if got := RegexFormat(regex, true); got != "value matching ^(on|off)$, after removing spaces" {
+		t.Errorf("unexpected format %q", got)
+	}
+	if got := RegexFormat(regex, false); got != "value matching ^(on|off)$" {
+		t.Errorf("unexpected format %q", got)
+	}
+}
+
+func TestOptionsFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       []string
+		caseSensitive bool
+		expected      string
+	}{
+		{
+			name:          "case sensitive",
+			options:       []string{"a", "b"},
+			caseSensitive: true,
+			expected:      "one of a, b",
+		},
+		{
+			name:     "case insensitive",
+			options:  []string{"a", "b"},
+			expected: "one of a, b (case insensitive)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OptionsFormat(tt.options, tt.caseSensitive); got != tt.expected {
+				t.Errorf("expected format %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
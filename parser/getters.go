@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+	networking "k8s.io/api/networking/v1"
+)
+
+// GetStringAnnotation returns the validated value of an annotation, or one of its aliases.
+// It returns ErrMissingAnnotations when the annotation is not set
+func GetStringAnnotation(name string, ing *networking.Ingress, fields AnnotationFields) (string, error) {
+	return GetStringAnnotationWithConfig(name, ing, fields, DefaultConfig())
+}
+
+// GetStringAnnotationWithConfig does the same of GetStringAnnotation, using the prefix and
+// validation settings of config
+func GetStringAnnotationWithConfig(name string, ing *networking.Ingress, fields AnnotationFields, config Config) (string, error) {
+	key, err := CheckAnnotationWithConfig(name, ing, fields, config)
+	if err != nil {
+		return "", err
+	}
+	value := ing.GetAnnotations()[key]
+	if value == "" {
+		return "", ing_errors.ErrMissingAnnotations
+	}
+	return value, nil
+}
+
+// ParseSize converts a size understood by NGINX, like 1000, 8k, 10m or 1g, to bytes.
+// Units are case insensitive
+func ParseSize(value string) (int64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !SizeRegex.MatchString(value) {
+		return 0, fmt.Errorf("%s is not a valid size", value)
+	}
+
+	multiplier := int64(1)
+	switch value[len(value)-1] {
+	case 'b':
+		value = value[:len(value)-1]
+	case 'k':
+		multiplier = 1 << 10
+		value = value[:len(value)-1]
+	case 'm':
+		multiplier = 1 << 20
+		value = value[:len(value)-1]
+	case 'g':
+		multiplier = 1 << 30
+		value = value[:len(value)-1]
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid size: %w", value, err)
+	}
+	if size > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("%s is not a valid size: value out of range", value)
+	}
+	return size * multiplier, nil
+}
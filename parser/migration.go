@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	networking "k8s.io/api/networking/v1"
+)
+
+// AnnotationCompatibility is the Gateway API compatibility of an annotation set on an Ingress
+type AnnotationCompatibility struct {
+	// Annotation is the full name of the annotation, as set on the Ingress
+	Annotation string `json:"annotation"`
+	// Feature is the feature that declared the annotation
+	Feature string `json:"feature"`
+	// Group is the group of the feature that declared the annotation
+	Group AnnotationGroup `json:"group"`
+	// Compatibility defines if the annotation can be represented by Gateway API
+	Compatibility GatewayAPICompatibility `json:"compatibility"`
+	// GatewayAPI describes the Gateway API replacement of the annotation
+	GatewayAPI string `json:"gatewayAPI,omitempty"`
+}
+
+// MigrationReadiness summarizes how ready an Ingress is to be migrated to Gateway API
+type MigrationReadiness struct {
+	// Compatible is the amount of annotations fully supported by Gateway API
+	Compatible int `json:"compatible"`
+	// Partial is the amount of annotations partially supported by Gateway API
+	Partial int `json:"partial"`
+	// Incompatible is the amount of annotations not supported by Gateway API
+	Incompatible int `json:"incompatible"`
+	// Unknown contains the annotations using the prefix that are not registered
+	Unknown []string `json:"unknown,omitempty"`
+	// Blocking contains the annotations that prevent the migration, as they are
+	// not supported by Gateway API
+	Blocking []string `json:"blocking,omitempty"`
+	// Annotations contains the compatibility of each known annotation, sorted by name
+	Annotations []AnnotationCompatibility `json:"annotations,omitempty"`
+}
+
+// Ready returns if no annotation prevents the migration
+func (m *MigrationReadiness) Ready() bool {
+	return len(m.Blocking) == 0
+}
+
+// Level returns the overall compatibility of the Ingress, that is the lowest
+// compatibility of its annotations
+func (m *MigrationReadiness) Level() GatewayAPICompatibility {
+	switch {
+	case m.Incompatible > 0:
+		return GatewayAPIIncompatible
+	case m.Partial > 0:
+		return GatewayAPIPartial
+	default:
+		return GatewayAPICompatible
+	}
+}
+
+// MigrationReadiness returns the Gateway API compatibility of the annotations of
+// the Ingress. Aliases are counted once, together with their canonical annotation
+func (r *Registry) MigrationReadiness(ingress *networking.Ingress) (*MigrationReadiness, error) {
+	if ingress == nil {
+		return nil, fmt.Errorf("ingress cannot be null")
+	}
+
+	readiness := &MigrationReadiness{}
+	seen := make(map[string]bool)
+	for _, annotation := range slices.Sorted(maps.Keys(ingress.Annotations)) {
+		if !r.config.HasPrefix(annotation) {
+			continue
+		}
+		ann, ok := r.Lookup(r.config.TrimAnnotationPrefix(annotation))
+		if !ok {
+			readiness.Unknown = append(readiness.Unknown, annotation)
+			continue
+		}
+		if seen[ann.Canonical] {
+			continue
+		}
+		seen[ann.Canonical] = true
+
+		compatibility := ann.Config.GatewayAPICompatibility
+		switch compatibility {
+		case GatewayAPICompatible:
+			readiness.Compatible++
+		case GatewayAPIPartial:
+			readiness.Partial++
+		default:
+			// Annotations without compatibility information are not assumed to be supported
+			compatibility = GatewayAPIIncompatible
+			readiness.Incompatible++
+			readiness.Blocking = append(readiness.Blocking, annotation)
+		}
+		readiness.Annotations = append(readiness.Annotations, AnnotationCompatibility{
+			Annotation:    annotation,
+			Feature:       ann.Feature,
+			Group:         ann.Group,
+			Compatibility: compatibility,
+			GatewayAPI:    ann.Config.GatewayAPI,
+		})
+	}
+	return readiness, nil
+}
+
+// ByCompatibility returns the canonical annotations with the provided Gateway API compatibility
+func (r *Registry) ByCompatibility(compatibility GatewayAPICompatibility) []RegisteredAnnotation {
+	return r.filter(func(ann RegisteredAnnotation) bool { return ann.Config.GatewayAPICompatibility == compatibility })
+}
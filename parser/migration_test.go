@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistryMigrationReadiness(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register("routing", Annotation{
+		Group: "routing",
+		Annotations: AnnotationFields{
+			"redirect": {Validator: ValidateNull, GatewayAPICompatibility: GatewayAPICompatible, AnnotationAliases: []string{"old-redirect"}},
+			"rewrite":  {Validator: ValidateNull, GatewayAPICompatibility: GatewayAPIPartial},
+			"snippet":  {Validator: ValidateNull, GatewayAPICompatibility: GatewayAPIIncompatible},
+			"legacy":   {Validator: ValidateNull},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error registering feature: %s", err)
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		counts       [3]int
+		blocking     []string
+		unknown      []string
+		level        GatewayAPICompatibility
+		expectsReady bool
+	}{
+		{
+			name:         "no annotations is compatible",
+			level:        GatewayAPICompatible,
+			expectsReady: true,
+		},
+		{
+			name: "alias is counted once",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/redirect":     "a",
+				"nginx.ingress.kubernetes.io/old-redirect": "b",
+				"other.io/snippet":                         "ignored",
+			},
+			counts:       [3]int{1, 0, 0},
+			level:        GatewayAPICompatible,
+			expectsReady: true,
+		},
+		{
+			name: "partial annotation is not blocking",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/redirect": "a",
+				"nginx.ingress.kubernetes.io/rewrite":  "b",
+				"nginx.ingress.kubernetes.io/missing":  "c",
+			},
+			counts:       [3]int{1, 1, 0},
+			unknown:      []string{"nginx.ingress.kubernetes.io/missing"},
+			level:        GatewayAPIPartial,
+			expectsReady: true,
+		},
+		{
+			name: "incompatible and undefined annotations are blocking",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite": "b",
+				"nginx.ingress.kubernetes.io/snippet": "c",
+				"nginx.ingress.kubernetes.io/legacy":  "d",
+			},
+			counts:   [3]int{0, 1, 2},
+			blocking: []string{"nginx.ingress.kubernetes.io/legacy", "nginx.ingress.kubernetes.io/snippet"},
+			level:    GatewayAPIIncompatible,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness, err := registry.MigrationReadiness(&networking.Ingress{ObjectMeta: v1.ObjectMeta{Annotations: tt.annotations}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			counts := [3]int{readiness.Compatible, readiness.Partial, readiness.Incompatible}
+			if counts != tt.counts {
+				t.Errorf("expected counts %v, got %v", tt.counts, counts)
+			}
+			if !reflect.DeepEqual(readiness.Blocking, tt.blocking) {
+				t.Errorf("expected blocking %v, got %v", tt.blocking, readiness.Blocking)
+			}
+			if !reflect.DeepEqual(readiness.Unknown, tt.unknown) {
+				t.Errorf("expected unknown %v, got %v", tt.unknown, readiness.Unknown)
+			}
+			if readiness.Level() != tt.level {
+				t.Errorf("expected level %s, got %s", tt.level, readiness.Level())
+			}
+			if readiness.Ready() != tt.expectsReady {
+				t.Errorf("expected ready to be %t", tt.expectsReady)
+			}
+			if len(readiness.Annotations) != tt.counts[0]+tt.counts[1]+tt.counts[2] {
+				t.Errorf("expected one entry per known annotation, got %+v", readiness.Annotations)
+			}
+		})
+	}
+
+	if _, err := registry.MigrationReadiness(nil); err == nil {
+		t.Error("expected error for null ingress")
+	}
+}
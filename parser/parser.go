@@ -17,7 +17,6 @@ limitations under the License.
 package parser
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
@@ -30,6 +29,8 @@ const (
 	DefaultEnableAnnotationValidation = true
 )
 
+// The package level attributes are used as the defaults of DefaultConfig. Code that
+// validates annotations for more than one controller installation should use a Config instead
 var (
 	// AnnotationsPrefix is the mutable attribute that the controller explicitly refers to
 	AnnotationsPrefix = DefaultAnnotationsPrefix
@@ -50,11 +51,15 @@ var (
 	AnnotationScopeIngress  AnnotationScope = "ingress"
 )
 
+// GatewayAPICompatibility defines how an annotation can be represented by Gateway API
 type GatewayAPICompatibility string
 
 var (
-	GatewayAPICompatible   GatewayAPICompatibility = "Compatible"
-	GatewayAPIPartial      GatewayAPICompatibility = "Partial"
+	// GatewayAPICompatible means Gateway API has a field or filter with the same behavior
+	GatewayAPICompatible GatewayAPICompatibility = "Compatible"
+	// GatewayAPIPartial means only part of the behavior can be represented by Gateway API
+	GatewayAPIPartial GatewayAPICompatibility = "Partial"
+	// GatewayAPIIncompatible means the annotation cannot be represented by Gateway API
 	GatewayAPIIncompatible GatewayAPICompatibility = "Incompatible"
 )
 
@@ -63,24 +68,92 @@ var (
 // on their ingress objects
 type AnnotationRisk int
 
+// AnnotationRiskUnknown is the risk of the findings of annotations that do not exist,
+// and cannot be used as the maximum risk
+const AnnotationRiskUnknown AnnotationRisk = -1
+
 type AnnotationFields map[string]AnnotationConfig
 
 // Validate will check all of the annotations of an Ingress resource against the fields
 func (a AnnotationFields) Validate(ingress *networking.Ingress) error {
+	result, err := a.ValidateResult(ingress)
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+// ValidateResult will check all of the annotations of an Ingress resource against the fields,
+// returning a finding for each invalid annotation value
+func (a AnnotationFields) ValidateResult(ingress *networking.Ingress) (*ValidationResult, error) {
+	return a.ValidateWithConfig(ingress, DefaultConfig())
+}
+
+// ValidateWithConfig will check all of the annotations of an Ingress resource against the fields,
+// using the prefix, risk and validation settings of config. As the fields do not know the
+// group of each annotation, AllowedGroups is not enforced
+func (a AnnotationFields) ValidateWithConfig(ingress *networking.Ingress, config Config) (*ValidationResult, error) {
 	if ingress == nil {
-		return fmt.Errorf("ingress cannot be null")
+		return nil, fmt.Errorf("ingress cannot be null")
+	}
+	return validateAnnotations(ingress.Annotations, config, nil, a.lookup, a.keys), nil
+}
+
+// lookup allows AnnotationFields to be used where a Registry lookup is expected.
+// As the fields do not know about features, just the annotation config is returned
+func (a AnnotationFields) lookup(name string) (RegisteredAnnotation, bool) {
+	config, ok := a[name]
+	return RegisteredAnnotation{Name: name, Canonical: name, Config: config}, ok
+}
+
+func (a AnnotationFields) keys() []string {
+	keys := make([]string, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// validateAnnotations runs all the checks enabled on config and the rules against the annotations
+func validateAnnotations(annotations map[string]string, config Config, rules []Rule, lookup func(string) (RegisteredAnnotation, bool), keys func() []string) *ValidationResult {
+	result := &ValidationResult{}
+	if config.EnableValidation {
+		result.Merge(validateValues(annotations, config, lookup))
 	}
-	var err error
-	for annotation, value := range ingress.Annotations {
-		annTrim := TrimAnnotationPrefix(annotation)
-		if field, ok := a[annTrim]; ok {
-			if errValidation := field.Validator(value); errValidation != nil {
-				err = errors.Join(err, fmt.Errorf("error validating %s: %w", annotation, errValidation))
+	result.Merge(checkRisk(annotations, config, lookup))
+	result.Merge(checkGroups(annotations, config, lookup))
+	result.Merge(checkRules(annotations, config, rules, lookup))
+	if config.ReportUnknown {
+		result.Merge(checkUnknown(annotations, config, keys(), lookup))
+	}
+	return result
+}
+
+// validateValues runs the validator of each known annotation against its value
+func validateValues(annotations map[string]string, config Config, lookup func(string) (RegisteredAnnotation, bool)) *ValidationResult {
+	result := &ValidationResult{}
+	for annotation, value := range annotations {
+		annTrim := config.TrimAnnotationPrefix(annotation)
+		if ann, ok := lookup(annTrim); ok && ann.Config.Validator != nil {
+			if errValidation := ann.Config.Validator(value); errValidation != nil {
+				result.Add(newInvalidValueFinding(annotation, value, ann, errValidation))
 			}
 		}
 	}
+	return result
+}
 
-	return err
+// checkGroups returns a finding for each known annotation whose group is not allowed.
+// Annotations without a known group are not checked
+func checkGroups(annotations map[string]string, config Config, lookup func(string) (RegisteredAnnotation, bool)) *ValidationResult {
+	result := &ValidationResult{}
+	for annotation, value := range annotations {
+		annTrim := config.TrimAnnotationPrefix(annotation)
+		if ann, ok := lookup(annTrim); ok && ann.Group != "" && !config.IsGroupAllowed(ann.Group) {
+			result.Add(newDisallowedGroupFinding(annotation, value, ann))
+		}
+	}
+	return result
 }
 
 // AnnotationConfig defines the configuration that a single annotation field
@@ -88,6 +161,9 @@ func (a AnnotationFields) Validate(ingress *networking.Ingress) error {
 type AnnotationConfig struct {
 	// Validator defines a function to validate the annotation value
 	Validator AnnotationValidator
+	// Format describes the values accepted by Validator, like FormatBool. This field
+	// will be used to auto generate documentations
+	Format string
 	// Documentation defines a user facing documentation for this annotation. This
 	// field will be used to auto generate documentations
 	Documentation string
@@ -108,6 +184,10 @@ type AnnotationConfig struct {
 
 	// GatewayAPIRef represents a link with the documentation of the field
 	GatewayAPIRef string
+
+	// GatewayAPICompatibility defines if the annotation can be fully, partially or
+	// not represented by Gateway API
+	GatewayAPICompatibility GatewayAPICompatibility
 }
 
 // Annotation defines an annotation feature an Ingress may have.
@@ -118,15 +198,19 @@ type Annotation struct {
 	Annotations AnnotationFields
 	// Group defines which annotation group this feature belongs to
 	Group AnnotationGroup
+	// Rules defines the constraints between the annotations of this feature and
+	// other annotations, like an annotation that requires another one to be set
+	Rules []Rule
 }
 
 // GetAnnotationWithPrefix returns the prefix of ingress annotations
 func GetAnnotationWithPrefix(suffix string) string {
-	return fmt.Sprintf("%v/%v", AnnotationsPrefix, suffix)
+	return DefaultConfig().AnnotationWithPrefix(suffix)
 }
 
+// TrimAnnotationPrefix removes the package level AnnotationsPrefix from the annotation
 func TrimAnnotationPrefix(annotation string) string {
-	return strings.TrimPrefix(annotation, AnnotationsPrefix+"/")
+	return DefaultConfig().TrimAnnotationPrefix(annotation)
 }
 
 func (a AnnotationRisk) ToString() string {
@@ -143,3 +227,32 @@ func (a AnnotationRisk) ToString() string {
 		return "Unknown"
 	}
 }
+
+// MarshalText allows the risk to be represented by its name on JSON and other formats
+func (a AnnotationRisk) MarshalText() ([]byte, error) {
+	return []byte(a.ToString()), nil
+}
+
+// UnmarshalText parses the name of a risk, like "Low" or "critical", or "Unknown"
+func (a *AnnotationRisk) UnmarshalText(text []byte) error {
+	if strings.EqualFold(string(text), AnnotationRiskUnknown.ToString()) {
+		*a = AnnotationRiskUnknown
+		return nil
+	}
+	risk, err := ParseAnnotationRisk(string(text))
+	if err != nil {
+		return err
+	}
+	*a = risk
+	return nil
+}
+
+// ParseAnnotationRisk returns the risk represented by name. The name is case insensitive
+func ParseAnnotationRisk(name string) (AnnotationRisk, error) {
+	for _, risk := range []AnnotationRisk{AnnotationRiskLow, AnnotationRiskMedium, AnnotationRiskHigh, AnnotationRiskCritical} {
+		if strings.EqualFold(name, risk.ToString()) {
+			return risk, nil
+		}
+	}
+	return AnnotationRiskLow, fmt.Errorf("invalid annotation risk %s", name)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+	networking "k8s.io/api/networking/v1"
+)
+
+// AnnotationReader reads typed values from the annotations of a feature. Each method
+// receives the annotation name, without prefix, and the default value returned when
+// the annotation is not set or is invalid. The reader keeps track of the annotations
+// explicitly set and of the invalid ones, so the typed configuration of a feature can
+// always be built, and the errors returned together
+type AnnotationReader struct {
+	ing    *networking.Ingress
+	fields AnnotationFields
+	config Config
+	set    map[string]bool
+	errs   []error
+}
+
+// NewAnnotationReader returns a reader of the annotations of the Ingress, validated
+// with the fields of a feature and using the prefix of config
+func NewAnnotationReader(ing *networking.Ingress, fields AnnotationFields, config Config) *AnnotationReader {
+	return &AnnotationReader{
+		ing:    ing,
+		fields: fields,
+		config: config,
+		set:    make(map[string]bool),
+	}
+}
+
+// Value returns the validated and trimmed value of the annotation, and if it is set
+func (r *AnnotationReader) Value(name string) (string, bool) {
+	value, err := GetStringAnnotationWithConfig(name, r.ing, r.fields, r.config)
+	if err != nil {
+		if !ing_errors.IsMissingAnnotations(err) {
+			r.errs = append(r.errs, err)
+		}
+		return "", false
+	}
+	r.set[name] = true
+	return strings.TrimSpace(value), true
+}
+
+// Invalid records that the value of the annotation cannot be used. The annotation is
+// not considered set anymore, as the default value is used
+func (r *AnnotationReader) Invalid(name, value string, reason error) {
+	delete(r.set, name)
+	r.errs = append(r.errs, ing_errors.NewAnnotationValidationError(r.config.AnnotationWithPrefix(name), value, reason))
+}
+
+// String returns the value of the annotation
+func (r *AnnotationReader) String(name, def string) string {
+	if value, ok := r.Value(name); ok {
+		return value
+	}
+	return def
+}
+
+// Int returns the value of an integer annotation
+func (r *AnnotationReader) Int(name string, def int) int {
+	value, ok := r.Value(name)
+	if !ok {
+		return def
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		r.Invalid(name, value, fmt.Errorf("%s is not an integer", value))
+		return def
+	}
+	return i
+}
+
+// Bool returns the value of a boolean annotation
+func (r *AnnotationReader) Bool(name string, def bool) bool {
+	value, ok := r.Value(name)
+	if !ok {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.Invalid(name, value, fmt.Errorf("%s is not a boolean", value))
+		return def
+	}
+	return b
+}
+
+// OnOff returns the value of an annotation that accepts "on" or "off"
+func (r *AnnotationReader) OnOff(name string, def bool) bool {
+	value, ok := r.Value(name)
+	if !ok {
+		return def
+	}
+	switch strings.ToLower(value) {
+	case "on":
+		return true
+	case "off":
+		return false
+	default:
+		r.Invalid(name, value, fmt.Errorf("value must be on or off"))
+		return def
+	}
+}
+
+// Seconds returns the value of an annotation containing a non negative number of seconds
+func (r *AnnotationReader) Seconds(name string, def time.Duration) time.Duration {
+	value, ok := r.Value(name)
+	if !ok {
+		return def
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		r.Invalid(name, value, fmt.Errorf("%s is not a number of seconds", value))
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Size returns the value in bytes of an annotation containing a size, like 8k
+func (r *AnnotationReader) Size(name string, def int64) int64 {
+	value, ok := r.Value(name)
+	if !ok {
+		return def
+	}
+	size, err := ParseSize(value)
+	if err != nil {
+		r.Invalid(name, value, err)
+		return def
+	}
+	return size
+}
+
+// IsSet returns if the annotation was read with a valid value
+func (r *AnnotationReader) IsSet(name string) bool {
+	return r.set[name]
+}
+
+// Set returns the annotations read with a valid value
+func (r *AnnotationReader) Set() map[string]bool {
+	return maps.Clone(r.set)
+}
+
+// Err returns all the errors found while reading the annotations
+func (r *AnnotationReader) Err() error {
+	return errors.Join(r.errs...)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+	"time"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+		wantErr  bool
+	}{
+		{value: "1000", expected: 1000},
+		{value: "10b", expected: 10},
+		{value: "8k", expected: 8 << 10},
+		{value: "8K", expected: 8 << 10},
+		{value: " 10m ", expected: 10 << 20},
+		{value: "1g", expected: 1 << 30},
+		{value: "0", expected: 0},
+		{value: "8589934591g", expected: 8589934591 << 30},
+		{value: "8589934592g", wantErr: true},
+		{value: "9223372036854775808", wantErr: true},
+		{value: "10t", wantErr: true},
+		{value: "k", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			size, err := ParseSize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if size != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, size)
+			}
+		})
+	}
+}
+
+func TestAnnotationReader(t *testing.T) {
+	fields := AnnotationFields{
+		"name":    {Validator: ValidateRegex(BasicCharsRegex, true)},
+		"enabled": {Validator: ValidateBool},
+		"count":   {Validator: ValidateInt},
+		"timeout": {Validator: ValidateInt, AnnotationAliases: []string{"old-timeout"}},
+		"size":    {Validator: ValidateRegex(SizeRegex, true)},
+		"mode":    {Validator: ValidateOptions([]string{"on", "off"}, true, true)},
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/name":        "backend",
+				"nginx.ingress.kubernetes.io/enabled":     "true",
+				"nginx.ingress.kubernetes.io/count":       "not a number",
+				"nginx.ingress.kubernetes.io/old-timeout": "30",
+				"nginx.ingress.kubernetes.io/size":        "8k",
+				"nginx.ingress.kubernetes.io/mode":        "off",
+			},
+		},
+	}
+
+	r := NewAnnotationReader(ing, fields, DefaultConfig())
+	if got := r.String("name", "default"); got != "backend" {
+		t.Errorf("expected backend, got %s", got)
+	}
+	if got := r.Bool("enabled", false); !got {
+		t.Errorf("expected enabled to be true")
+	}
+	if got := r.Int("count", 3); got != 3 {
+		t.Errorf("expected invalid count to keep the default, got %d", got)
+	}
+	if got := r.Seconds("timeout", time.Second); got != 30*time.Second {
+		t.Errorf("expected timeout to be read from the alias, got %s", got)
+	}
+	if got := r.Size("size", 0); got != 8<<10 {
+		t.Errorf("expected 8192 bytes, got %d", got)
+	}
+	if got := r.OnOff("mode", true); got {
+		t.Errorf("expected mode to be off")
+	}
+
+	for _, name := range []string{"name", "enabled", "timeout", "size", "mode"} {
+		if !r.IsSet(name) {
+			t.Errorf("expected %s to be set", name)
+		}
+	}
+	if r.IsSet("count") {
+		t.Errorf("expected invalid annotation to not be set")
+	}
+
+	err := r.Err()
+	if err == nil || !ing_errors.IsValidationError(err) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ParseSecretReference parses a reference to a Secret, in the form namespace/name, used
+// by an Ingress of the namespace. When requireNamespace is false, a reference without
+// namespace uses the namespace of the Ingress. As on ingress-nginx, Secrets of other
+// namespaces cannot be used
+func ParseSecretReference(value, namespace string, requireNamespace bool) (types.NamespacedName, error) {
+	return ParseObjectReference("secret", value, namespace, requireNamespace)
+}
+
+// ParseObjectReference parses a reference to an object of kind, like a secret or a
+// configmap, in the form namespace/name. It follows the same rules of ParseSecretReference
+func ParseObjectReference(kind, value, namespace string, requireNamespace bool) (types.NamespacedName, error) {
+	value = strings.TrimSpace(value)
+	parts := strings.Split(value, "/")
+	ref := types.NamespacedName{Namespace: namespace}
+	switch {
+	case len(parts) == 1 && !requireNamespace:
+		ref.Name = parts[0]
+	case len(parts) == 2:
+		ref.Namespace, ref.Name = parts[0], parts[1]
+	default:
+		return types.NamespacedName{}, fmt.Errorf("invalid format (namespace/name) found in %q", value)
+	}
+	if ref.Name == "" {
+		return types.NamespacedName{}, fmt.Errorf("%s name cannot be empty", kind)
+	}
+	if ref.Namespace != namespace {
+		return types.NamespacedName{}, fmt.Errorf("cross namespace %ss are not supported, %s is not in the namespace %s", kind, ref, namespace)
+	}
+	return ref, nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	tests := []struct {
+		value            string
+		requireNamespace bool
+		expected         types.NamespacedName
+		wantErr          bool
+	}{
+		{value: "default/secret", requireNamespace: true, expected: types.NamespacedName{Namespace: "default", Name: "secret"}},
+		{value: "secret", expected: types.NamespacedName{Namespace: "default", Name: "secret"}},
+		{value: "secret", requireNamespace: true, wantErr: true},
+		{value: "other/secret", wantErr: true},
+		{value: "default/", wantErr: true},
+		{value: "default/secret/extra", wantErr: true},
+	}
+	for _, tt := range tests {
+		ref, err := ParseSecretReference(tt.value, "default", tt.requireNamespace)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("expected error %t for %q, got %v", tt.wantErr, tt.value, err)
+		}
+		if ref != tt.expected {
+			t.Errorf("expected %s for %q, got %s", tt.expected, tt.value, ref)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+)
+
+// RegisteredAnnotation is a single annotation known by a Registry, together with
+// the feature and group it belongs to
+type RegisteredAnnotation struct {
+	// Name is the annotation name without the prefix. When the annotation was
+	// looked up by one of its aliases, Name contains the alias
+	Name string
+	// Canonical is the main name of the annotation. It is the same as Name,
+	// unless Name is an alias
+	Canonical string
+	// Feature is the name of the feature that declared this annotation, eg.: canary, proxy
+	Feature string
+	// Group is the group of the feature that declared this annotation
+	Group AnnotationGroup
+	// Config is the configuration of the annotation, with its validator and documentation
+	Config AnnotationConfig
+}
+
+// IsAlias returns if this annotation was registered as an alias of another annotation
+func (r RegisteredAnnotation) IsAlias() bool {
+	return r.Name != r.Canonical
+}
+
+// Registry holds the annotation features and allows querying the annotations
+// by name, feature, group, risk and scope without losing where each annotation
+// comes from
+type Registry struct {
+	features    map[string]Annotation
+	annotations map[string]RegisteredAnnotation
+	config      Config
+}
+
+// NewRegistry returns an empty Registry using DefaultConfig
+func NewRegistry() *Registry {
+	return &Registry{
+		features:    make(map[string]Annotation),
+		annotations: make(map[string]RegisteredAnnotation),
+		config:      DefaultConfig(),
+	}
+}
+
+// WithConfig returns a Registry sharing the registered features of r, but using
+// config to validate annotations. It allows validating Ingresses of different
+// controller installations concurrently, as long as no new feature is registered
+func (r *Registry) WithConfig(config Config) *Registry {
+	return &Registry{
+		features:    r.features,
+		annotations: r.annotations,
+		config:      config,
+	}
+}
+
+// Config returns the configuration used by the registry
+func (r *Registry) Config() Config {
+	return r.config
+}
+
+// Register adds a feature and all of its annotations and aliases to the registry.
+// It returns an error if the feature was already registered, or if any annotation
+// name or alias is already owned by another annotation
+func (r *Registry) Register(feature string, annotation Annotation) error {
+	if feature == "" {
+		return fmt.Errorf("feature name cannot be empty")
+	}
+	if _, ok := r.features[feature]; ok {
+		return fmt.Errorf("feature %s is already registered", feature)
+	}
+
+	for _, rule := range annotation.Rules {
+		if _, ok := annotation.Annotations[rule.Annotation]; !ok {
+			return fmt.Errorf("rule %q of feature %s refers to an annotation of another feature", rule, feature)
+		}
+	}
+
+	entries := make(map[string]RegisteredAnnotation)
+	for name, config := range annotation.Annotations {
+		for _, key := range append([]string{name}, config.AnnotationAliases...) {
+			if owner, ok := r.annotations[key]; ok {
+				return fmt.Errorf("annotation %s of feature %s is already registered by feature %s", key, feature, owner.Feature)
+			}
+			if owner, ok := entries[key]; ok && owner.Canonical != name {
+				return fmt.Errorf("annotation %s is declared more than once on feature %s", key, feature)
+			}
+			entries[key] = RegisteredAnnotation{
+				Name:      key,
+				Canonical: name,
+				Feature:   feature,
+				Group:     annotation.Group,
+				Config:    config,
+			}
+		}
+	}
+
+	r.features[feature] = annotation
+	for key, entry := range entries {
+		r.annotations[key] = entry
+	}
+	return nil
+}
+
+// Lookup returns the annotation registered with name. The name must not contain
+// the annotation prefix, and may be an alias
+func (r *Registry) Lookup(name string) (RegisteredAnnotation, bool) {
+	ann, ok := r.annotations[name]
+	return ann, ok
+}
+
+// Feature returns the annotation feature registered with name
+func (r *Registry) Feature(name string) (Annotation, bool) {
+	feature, ok := r.features[name]
+	return feature, ok
+}
+
+// Features returns the sorted names of all registered features
+func (r *Registry) Features() []string {
+	features := make([]string, 0, len(r.features))
+	for name := range r.features {
+		features = append(features, name)
+	}
+	slices.Sort(features)
+	return features
+}
+
+// Groups returns the sorted list of groups of the registered features
+func (r *Registry) Groups() []AnnotationGroup {
+	groups := make([]AnnotationGroup, 0)
+	for _, feature := range r.features {
+		if !slices.Contains(groups, feature.Group) {
+			groups = append(groups, feature.Group)
+		}
+	}
+	slices.Sort(groups)
+	return groups
+}
+
+// Annotations returns all the canonical annotations of the registry, sorted by name.
+// Aliases are not returned, and are available on each annotation configuration
+func (r *Registry) Annotations() []RegisteredAnnotation {
+	return r.filter(func(RegisteredAnnotation) bool { return true })
+}
+
+// ByFeature returns the canonical annotations declared by a feature
+func (r *Registry) ByFeature(feature string) []RegisteredAnnotation {
+	return r.filter(func(ann RegisteredAnnotation) bool { return ann.Feature == feature })
+}
+
+// ByGroup returns the canonical annotations that belong to a group
+func (r *Registry) ByGroup(group AnnotationGroup) []RegisteredAnnotation {
+	return r.filter(func(ann RegisteredAnnotation) bool { return ann.Group == group })
+}
+
+// ByRisk returns the canonical annotations with exactly the provided risk
+func (r *Registry) ByRisk(risk AnnotationRisk) []RegisteredAnnotation {
+	return r.filter(func(ann RegisteredAnnotation) bool { return ann.Config.Risk == risk })
+}
+
+// ByScope returns the canonical annotations that apply to a scope
+func (r *Registry) ByScope(scope AnnotationScope) []RegisteredAnnotation {
+	return r.filter(func(ann RegisteredAnnotation) bool { return ann.Config.Scope == scope })
+}
+
+// Fields returns the flattened AnnotationFields of the registry. Aliases are
+// added as keys containing the same configuration of their canonical annotation
+func (r *Registry) Fields() AnnotationFields {
+	fields := make(AnnotationFields, len(r.annotations))
+	for name, ann := range r.annotations {
+		fields[name] = ann.Config
+	}
+	return fields
+}
+
+// Validate checks the annotations of the Ingress using the registry configuration
+// and the rules of the registered features. Depending on the configuration, it returns
+// a finding containing the feature, group and risk of each invalid, too risky, not
+// allowed or unknown annotation, and of each violated rule
+func (r *Registry) Validate(ingress *networking.Ingress) (*ValidationResult, error) {
+	if ingress == nil {
+		return nil, fmt.Errorf("ingress cannot be null")
+	}
+	return validateAnnotations(ingress.Annotations, r.config, r.Rules(), r.Lookup, r.keys), nil
+}
+
+// Rules returns the rules declared by all the registered features
+func (r *Registry) Rules() []Rule {
+	rules := make([]Rule, 0)
+	for _, feature := range r.Features() {
+		rules = append(rules, r.features[feature].Rules...)
+	}
+	return rules
+}
+
+// ValidateRules checks that the rules of all the registered features only refer to
+// the canonical names of registered annotations. Register only checks the annotation of each rule, as the
+// others may be declared by features registered later, so it must be called once all
+// the features are registered
+func (r *Registry) ValidateRules() error {
+	errs := make([]error, 0)
+	for _, feature := range r.Features() {
+		for _, rule := range r.features[feature].Rules {
+			for _, other := range rule.Others {
+				ann, ok := r.annotations[other]
+				switch {
+				case !ok:
+					errs = append(errs, fmt.Errorf("rule %q of feature %s refers to unknown annotation %s", rule, feature, other))
+				case ann.IsAlias():
+					// Rules are evaluated against the canonical names, so an alias would never match
+					errs = append(errs, fmt.Errorf("rule %q of feature %s refers to alias %s instead of annotation %s", rule, feature, other, ann.Canonical))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckAnnotation does the same checks of CheckAnnotation, using the registry
+// annotations and configuration
+func (r *Registry) CheckAnnotation(name string, ing *networking.Ingress) (string, error) {
+	return CheckAnnotationWithConfig(name, ing, r.Fields(), r.config)
+}
+
+// CheckRisk returns a finding for each known annotation with a risk higher than maxRisk
+func (r *Registry) CheckRisk(annotations map[string]string, maxRisk AnnotationRisk) *ValidationResult {
+	config := r.config
+	config.MaxRisk = maxRisk
+	return checkRisk(annotations, config, r.Lookup)
+}
+
+// CheckUnknown returns a finding for each annotation using the configured prefix
+// that is not registered, with suggestions of similar annotations
+func (r *Registry) CheckUnknown(annotations map[string]string) *ValidationResult {
+	return checkUnknown(annotations, r.config, r.keys(), r.Lookup)
+}
+
+func (r *Registry) keys() []string {
+	keys := make([]string, 0, len(r.annotations))
+	for key := range r.annotations {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (r *Registry) filter(match func(RegisteredAnnotation) bool) []RegisteredAnnotation {
+	result := make([]RegisteredAnnotation, 0)
+	for _, ann := range r.annotations {
+		if !ann.IsAlias() && match(ann) {
+			result = append(result, ann)
+		}
+	}
+	slices.SortFunc(result, func(a, b RegisteredAnnotation) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
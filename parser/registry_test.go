@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry := NewRegistry()
+	if err := registry.Register("limits", Annotation{
+		Group: "rate-limit",
+		Annotations: AnnotationFields{
+			"limit-allowlist": {
+				Validator:         ValidateCIDRs,
+				Scope:             AnnotationScopeLocation,
+				Risk:              AnnotationRiskLow,
+				AnnotationAliases: []string{"limit-whitelist"},
+			},
+			"limit-rps": {
+				Validator: ValidateInt,
+				Scope:     AnnotationScopeLocation,
+				Risk:      AnnotationRiskLow,
+			},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error registering feature: %s", err)
+	}
+	if err := registry.Register("snippet", Annotation{
+		Group: "snippets",
+		Annotations: AnnotationFields{
+			"server-snippet": {
+				Validator: ValidateNull,
+				Scope:     AnnotationScopeIngress,
+				Risk:      AnnotationRiskCritical,
+			},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error registering feature: %s", err)
+	}
+	return registry
+}
+
+func TestRegistryRegister(t *testing.T) {
+	tests := []struct {
+		name       string
+		feature    string
+		annotation Annotation
+		wantErr    bool
+	}{
+		{
+			name:    "new feature should be registered",
+			feature: "other",
+			annotation: Annotation{
+				Annotations: AnnotationFields{"other-annotation": {Validator: ValidateBool}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty feature name should fail",
+			feature: "",
+			wantErr: true,
+		},
+		{
+			name:    "duplicated feature should fail",
+			feature: "limits",
+			wantErr: true,
+		},
+		{
+			name:    "duplicated annotation should fail",
+			feature: "other",
+			annotation: Annotation{
+				Annotations: AnnotationFields{"limit-rps": {Validator: ValidateInt}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "alias colliding with an existing annotation should fail",
+			feature: "other",
+			annotation: Annotation{
+				Annotations: AnnotationFields{"other-annotation": {
+					Validator:         ValidateBool,
+					AnnotationAliases: []string{"limit-whitelist"},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestRegistry(t)
+			if err := registry.Register(tt.feature, tt.annotation); (err != nil) != tt.wantErr {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryValidateRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []Rule
+		wantErr bool
+	}{
+		{
+			name:  "rule referring to another feature",
+			rules: []Rule{Requires("other-annotation", "limit-rps")},
+		},
+		{
+			name:    "rule referring to an alias",
+			rules:   []Rule{ConflictsWith("other-annotation", "limit-whitelist")},
+			wantErr: true,
+		},
+		{
+			name:    "rule referring to an unknown annotation",
+			rules:   []Rule{Requires("other-annotation", "limit-rps", "unknown-annotation")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestRegistry(t)
+			if err := registry.Register("other", Annotation{
+				Annotations: AnnotationFields{"other-annotation": {Validator: ValidateBool}},
+				Rules:       tt.rules,
+			}); err != nil {
+				t.Fatalf("unexpected error registering feature: %s", err)
+			}
+			if err := registry.ValidateRules(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	ann, ok := registry.Lookup("limit-whitelist")
+	if !ok {
+		t.Fatal("expected alias to be found")
+	}
+	if !ann.IsAlias() || ann.Canonical != "limit-allowlist" || ann.Feature != "limits" || ann.Group != "rate-limit" {
+		t.Errorf("unexpected alias lookup result: %+v", ann)
+	}
+
+	ann, ok = registry.Lookup("limit-allowlist")
+	if !ok || ann.IsAlias() {
+		t.Errorf("expected canonical annotation, got %+v", ann)
+	}
+
+	if _, ok := registry.Lookup("limit-something"); ok {
+		t.Error("expected unknown annotation to not be found")
+	}
+}
+
+func TestRegistryQueries(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	names := func(anns []RegisteredAnnotation) []string {
+		result := make([]string, 0, len(anns))
+		for _, ann := range anns {
+			result = append(result, ann.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{
+			name: "all annotations should not contain aliases",
+			got:  names(registry.Annotations()),
+			want: []string{"limit-allowlist", "limit-rps", "server-snippet"},
+		},
+		{
+			name: "by feature",
+			got:  names(registry.ByFeature("limits")),
+			want: []string{"limit-allowlist", "limit-rps"},
+		},
+		{
+			name: "by group",
+			got:  names(registry.ByGroup("snippets")),
+			want: []string{"server-snippet"},
+		},
+		{
+			name: "by risk",
+			got:  names(registry.ByRisk(AnnotationRiskLow)),
+			want: []string{"limit-allowlist", "limit-rps"},
+		},
+		{
+			name: "by scope",
+			got:  names(registry.ByScope(AnnotationScopeIngress)),
+			want: []string{"server-snippet"},
+		},
+		{
+			name: "features",
+			got:  registry.Features(),
+			want: []string{"limits", "snippet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+			for i := range tt.got {
+				if tt.got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", tt.got, tt.want)
+				}
+			}
+		})
+	}
+
+	fields := registry.Fields()
+	if len(fields) != 4 {
+		t.Errorf("expected fields to contain 4 entries including the alias, got %d", len(fields))
+	}
+}
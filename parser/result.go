@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+)
+
+// Severity defines how important a validation finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "Error"
+	SeverityWarning Severity = "Warning"
+)
+
+// FindingType defines which check produced a validation finding
+type FindingType string
+
+const (
+	// FindingInvalidValue is produced when the annotation validator rejects the value
+	FindingInvalidValue FindingType = "InvalidValue"
+	// FindingRiskyAnnotation is produced when the annotation risk is higher than the allowed
+	FindingRiskyAnnotation FindingType = "RiskyAnnotation"
+	// FindingUnknownAnnotation is produced when the annotation does not exist
+	FindingUnknownAnnotation FindingType = "UnknownAnnotation"
+	// FindingDisallowedGroup is produced when the annotation group is not allowed
+	FindingDisallowedGroup FindingType = "DisallowedGroup"
+	// FindingMissingRequirement is produced when the annotation requires another annotation that is not set
+	FindingMissingRequirement FindingType = "MissingRequirement"
+	// FindingConflict is produced when the annotation is set together with a conflicting annotation
+	FindingConflict FindingType = "Conflict"
+	// FindingIgnoredAnnotation is produced when the annotation is ignored because of another annotation
+	FindingIgnoredAnnotation FindingType = "IgnoredAnnotation"
+	// FindingInvalidCanary is produced when a canary Ingress is not valid relative to its primary Ingress
+	FindingInvalidCanary FindingType = "InvalidCanary"
+	// FindingInvalidCaptureGroup is produced when an annotation references a capture group that the path does not have
+	FindingInvalidCaptureGroup FindingType = "InvalidCaptureGroup"
+	// FindingUnresolvedReference is produced when the object referenced by an annotation does not exist or is not valid
+	FindingUnresolvedReference FindingType = "UnresolvedReference"
+	// FindingInvalidSnippet is produced when the configuration of a snippet annotation cannot be parsed
+	FindingInvalidSnippet FindingType = "InvalidSnippet"
+	// FindingDangerousSnippet is produced when a snippet annotation contains a dangerous directive, like include or a Lua block
+	FindingDangerousSnippet FindingType = "DangerousSnippet"
+)
+
+// FindingTypes describes each finding type, to be listed by the outputs like SARIF
+var FindingTypes = map[FindingType]string{
+	FindingInvalidValue:        "The annotation value is rejected by its validator",
+	FindingRiskyAnnotation:     "The annotation risk is higher than the allowed",
+	FindingUnknownAnnotation:   "The annotation does not exist",
+	FindingDisallowedGroup:     "The annotation group is not allowed",
+	FindingMissingRequirement:  "The annotation requires another annotation that is not set",
+	FindingConflict:            "The annotation conflicts with another annotation",
+	FindingIgnoredAnnotation:   "The annotation is ignored because of another annotation",
+	FindingInvalidCanary:       "The canary Ingress is not valid relative to its primary Ingress",
+	FindingInvalidCaptureGroup: "The annotation references a capture group that the path does not have",
+	FindingUnresolvedReference: "The object referenced by the annotation does not exist or is not valid",
+	FindingInvalidSnippet:      "The configuration of the snippet annotation cannot be parsed",
+	FindingDangerousSnippet:    "The snippet annotation contains a dangerous directive",
+}
+
+// Finding is a single, machine readable, result of a validation
+type Finding struct {
+	// Type is the check that produced this finding
+	Type FindingType `json:"type"`
+	// Severity defines if this finding is an error or just a warning
+	Severity Severity `json:"severity"`
+	// Annotation is the full name of the annotation, including the prefix
+	Annotation string `json:"annotation"`
+	// Value is the value of the annotation
+	Value string `json:"value,omitempty"`
+	// Feature is the feature that declares the annotation, when known
+	Feature string `json:"feature,omitempty"`
+	// Group is the group of the feature that declares the annotation, when known
+	Group AnnotationGroup `json:"group,omitempty"`
+	// Risk is the risk of the annotation
+	Risk AnnotationRisk `json:"risk"`
+	// Reason is the human readable reason of this finding, like the validator error
+	Reason string `json:"reason"`
+	// Suggestions contains similar annotations, when the annotation is unknown
+	Suggestions []string `json:"suggestions,omitempty"`
+
+	err error
+}
+
+// Err returns the finding as one of the errors package types, like ValidationError
+// or RiskyAnnotationError, so it can be inspected with errors.As
+func (f Finding) Err() error {
+	if f.err != nil {
+		return f.err
+	}
+	return errors.New(f.Reason)
+}
+
+// String returns the finding as a human readable message
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Severity, f.Err())
+}
+
+// ValidationResult is the structured result of validating the annotations of an Ingress
+type ValidationResult struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Add appends findings to the result, keeping them sorted by annotation
+func (r *ValidationResult) Add(findings ...Finding) {
+	r.Findings = append(r.Findings, findings...)
+	slices.SortStableFunc(r.Findings, func(a, b Finding) int {
+		return strings.Compare(a.Annotation, b.Annotation)
+	})
+}
+
+// Merge adds all the findings of other results to this result
+func (r *ValidationResult) Merge(others ...*ValidationResult) {
+	for _, other := range others {
+		if other != nil {
+			r.Add(other.Findings...)
+		}
+	}
+}
+
+// Filter returns the findings that match the provided severity
+func (r *ValidationResult) Filter(severity Severity) []Finding {
+	findings := make([]Finding, 0)
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// Errors returns the findings with Error severity
+func (r *ValidationResult) Errors() []Finding {
+	return r.Filter(SeverityError)
+}
+
+// Warnings returns the findings with Warning severity
+func (r *ValidationResult) Warnings() []Finding {
+	return r.Filter(SeverityWarning)
+}
+
+// HasErrors returns if the result contains any finding with Error severity
+func (r *ValidationResult) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Err joins all the findings with Error severity in a single error. It returns
+// nil if there are no errors
+func (r *ValidationResult) Err() error {
+	var err error
+	for _, finding := range r.Errors() {
+		err = errors.Join(err, finding.Err())
+	}
+	return err
+}
+
+// newInvalidValueFinding returns the finding of an annotation with a value rejected by its validator
+func newInvalidValueFinding(annotation, value string, ann RegisteredAnnotation, reason error) Finding {
+	return Finding{
+		Type:       FindingInvalidValue,
+		Severity:   SeverityError,
+		Annotation: annotation,
+		Value:      value,
+		Feature:    ann.Feature,
+		Group:      ann.Group,
+		Risk:       ann.Config.Risk,
+		Reason:     reason.Error(),
+		err: ing_errors.ValidationError{
+			Reason:     reason,
+			Annotation: annotation,
+			Value:      value,
+			Feature:    ann.Feature,
+			Group:      string(ann.Group),
+			Risk:       ann.Config.Risk.ToString(),
+		},
+	}
+}
+
+// newRiskyFinding returns the finding of an annotation with a risk higher than maxRisk
+func newRiskyFinding(annotation, value string, ann RegisteredAnnotation, maxRisk AnnotationRisk) Finding {
+	err := ing_errors.RiskyAnnotationError{
+		Annotation: annotation,
+		Feature:    ann.Feature,
+		Group:      string(ann.Group),
+		Risk:       ann.Config.Risk.ToString(),
+		MaxRisk:    maxRisk.ToString(),
+	}
+	return Finding{
+		Type:       FindingRiskyAnnotation,
+		Severity:   SeverityError,
+		Annotation: annotation,
+		Value:      value,
+		Feature:    ann.Feature,
+		Group:      ann.Group,
+		Risk:       ann.Config.Risk,
+		Reason:     fmt.Sprintf("risk %s is higher than the allowed %s", ann.Config.Risk.ToString(), maxRisk.ToString()),
+		err:        err,
+	}
+}
+
+// newDisallowedGroupFinding returns the finding of an annotation whose group is not allowed
+func newDisallowedGroupFinding(annotation, value string, ann RegisteredAnnotation) Finding {
+	err := ing_errors.RiskyAnnotationError{
+		Reason:     fmt.Errorf("annotation %s belongs to group %s, which is not allowed", annotation, ann.Group),
+		Annotation: annotation,
+		Feature:    ann.Feature,
+		Group:      string(ann.Group),
+		Risk:       ann.Config.Risk.ToString(),
+	}
+	return Finding{
+		Type:       FindingDisallowedGroup,
+		Severity:   SeverityError,
+		Annotation: annotation,
+		Value:      value,
+		Feature:    ann.Feature,
+		Group:      ann.Group,
+		Risk:       ann.Config.Risk,
+		Reason:     fmt.Sprintf("group %s is not allowed", ann.Group),
+		err:        err,
+	}
+}
+
+// newUnknownFinding returns the finding of an annotation that does not exist
+func newUnknownFinding(annotation, value string, suggestions []string) Finding {
+	err := ing_errors.UnknownAnnotationError{
+		Annotation:  annotation,
+		Suggestions: suggestions,
+	}
+	return Finding{
+		Type:        FindingUnknownAnnotation,
+		Severity:    SeverityError,
+		Annotation:  annotation,
+		Value:       value,
+		Risk:        AnnotationRiskUnknown,
+		Reason:      "annotation does not exist",
+		Suggestions: suggestions,
+		err:         err,
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistryValidate(t *testing.T) {
+	registry := newTestRegistry(t)
+	ing := &networking.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				GetAnnotationWithPrefix("limit-rps"):       "not-a-number",
+				GetAnnotationWithPrefix("limit-whitelist"): "10.0.0.0/8",
+				GetAnnotationWithPrefix("server-snippet"):  "return 200;",
+			},
+		},
+	}
+
+	if _, err := registry.Validate(nil); err == nil {
+		t.Error("expected null ingress to fail")
+	}
+
+	result, err := registry.Validate(ing)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected a single finding, got %+v", result.Findings)
+	}
+
+	finding := result.Findings[0]
+	if finding.Type != FindingInvalidValue || finding.Severity != SeverityError ||
+		finding.Annotation != GetAnnotationWithPrefix("limit-rps") || finding.Value != "not-a-number" ||
+		finding.Feature != "limits" || finding.Group != "rate-limit" || finding.Risk != AnnotationRiskLow {
+		t.Errorf("unexpected finding %+v", finding)
+	}
+
+	var validationErr ing_errors.ValidationError
+	if !errors.As(result.Err(), &validationErr) {
+		t.Fatalf("expected ValidationError, got %T", result.Err())
+	}
+	if validationErr.Feature != "limits" || validationErr.Value != "not-a-number" {
+		t.Errorf("unexpected validation error %+v", validationErr)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(result.Err(), &numErr) {
+		t.Error("expected the validator error to be wrapped")
+	}
+
+	risky := registry.CheckRisk(ing.Annotations, AnnotationRiskHigh)
+	if len(risky.Findings) != 1 || risky.Findings[0].Type != FindingRiskyAnnotation {
+		t.Fatalf("expected a single risky finding, got %+v", risky.Findings)
+	}
+	if !ing_errors.IsRiskyAnnotationError(risky.Err()) {
+		t.Errorf("expected RiskyAnnotationError, got %T", risky.Err())
+	}
+}
+
+func TestFindingJSON(t *testing.T) {
+	finding := newRiskyFinding(GetAnnotationWithPrefix("server-snippet"), "return 200;", RegisteredAnnotation{
+		Feature: "snippet",
+		Group:   "snippets",
+		Config:  AnnotationConfig{Risk: AnnotationRiskCritical},
+	}, AnnotationRiskHigh)
+
+	data, err := json.Marshal(finding)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded Finding
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded.Risk != AnnotationRiskCritical || decoded.Feature != "snippet" || decoded.Type != FindingRiskyAnnotation {
+		t.Errorf("unexpected decoded finding %+v from %s", decoded, data)
+	}
+
+	data, err = json.Marshal(newUnknownFinding(GetAnnotationWithPrefix("something"), "value", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(string(data), `"risk":"Unknown"`) {
+		t.Errorf("expected unknown risk on %s", data)
+	}
+	decoded = Finding{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded.Risk != AnnotationRiskUnknown {
+		t.Errorf("expected unknown risk, got %s", decoded.Risk.ToString())
+	}
+}
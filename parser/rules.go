@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+)
+
+// RuleType defines how an annotation relates to other annotations
+type RuleType string
+
+const (
+	// RuleRequires means the annotation only makes sense when one of the other annotations is set
+	RuleRequires RuleType = "Requires"
+	// RuleConflictsWith means the annotation cannot be used together with the other annotations
+	RuleConflictsWith RuleType = "ConflictsWith"
+	// RuleIgnoredWhen means the annotation is ignored by the controller when one of the other annotations is set
+	RuleIgnoredWhen RuleType = "IgnoredWhen"
+)
+
+// Rule is a constraint between annotations, that can't be checked by the validator
+// of a single annotation. Rules are declared by each feature on Annotation.Rules and
+// are only evaluated when Annotation is set
+type Rule struct {
+	// Type defines how Annotation relates to Others
+	Type RuleType
+	// Annotation is the annotation, without prefix, this rule applies to
+	Annotation string
+	// Others are the canonical annotations, without prefix, that Annotation depends or conflicts with.
+	// A Requires rule is satisfied when any of them is set, while ConflictsWith and IgnoredWhen
+	// rules are triggered when any of them is set
+	Others []string
+	// Values restricts the values of Others that satisfy or trigger the rule, compared
+	// without case. When empty, any non empty value is accepted
+	Values []string
+	// Severity defines how the violation of this rule is reported
+	Severity Severity
+}
+
+// Requires returns a rule that reports annotation when none of the others is set.
+// As the controller usually ignores the annotation in this case, it is a warning by default
+func Requires(annotation string, others ...string) Rule {
+	return Rule{
+		Type:       RuleRequires,
+		Annotation: annotation,
+		Others:     others,
+		Severity:   SeverityWarning,
+	}
+}
+
+// ConflictsWith returns a rule that reports annotation when any of the others is set.
+// It is an error by default
+func ConflictsWith(annotation string, others ...string) Rule {
+	return Rule{
+		Type:       RuleConflictsWith,
+		Annotation: annotation,
+		Others:     others,
+		Severity:   SeverityError,
+	}
+}
+
+// IgnoredWhen returns a rule that reports annotation when any of the others is set,
+// as the controller ignores it. It is a warning by default
+func IgnoredWhen(annotation string, others ...string) Rule {
+	return Rule{
+		Type:       RuleIgnoredWhen,
+		Annotation: annotation,
+		Others:     others,
+		Severity:   SeverityWarning,
+	}
+}
+
+// WithValues restricts the values of the other annotations that satisfy or trigger the rule
+func (r Rule) WithValues(values ...string) Rule {
+	r.Values = values
+	return r
+}
+
+// WithSeverity changes the severity used when the rule is violated
+func (r Rule) WithSeverity(severity Severity) Rule {
+	r.Severity = severity
+	return r
+}
+
+// String returns a human readable description of the rule
+func (r Rule) String() string {
+	others := strings.Join(r.Others, " or ")
+	if len(r.Values) > 0 {
+		others = fmt.Sprintf("%s set to %s", others, strings.Join(r.Values, " or "))
+	}
+	switch r.Type {
+	case RuleRequires:
+		return fmt.Sprintf("%s requires %s", r.Annotation, others)
+	case RuleConflictsWith:
+		return fmt.Sprintf("%s cannot be used together with %s", r.Annotation, others)
+	case RuleIgnoredWhen:
+		return fmt.Sprintf("%s is ignored when %s is set", r.Annotation, others)
+	default:
+		return fmt.Sprintf("%s has an unknown rule %s", r.Annotation, r.Type)
+	}
+}
+
+// violated returns if the rule is violated by the annotations. The annotations
+// must be indexed by their canonical name, without prefix
+func (r Rule) violated(annotations map[string]string) bool {
+	if annotations[r.Annotation] == "" {
+		return false
+	}
+	matched := slices.ContainsFunc(r.Others, func(other string) bool {
+		value := strings.TrimSpace(annotations[other])
+		if value == "" {
+			return false
+		}
+		return len(r.Values) == 0 || slices.ContainsFunc(r.Values, func(v string) bool {
+			return strings.EqualFold(v, value)
+		})
+	})
+	if r.Type == RuleRequires {
+		return !matched
+	}
+	return matched
+}
+
+// checkRules returns a finding for each rule violated by the annotations
+func checkRules(annotations map[string]string, config Config, rules []Rule, lookup func(string) (RegisteredAnnotation, bool)) *ValidationResult {
+	result := &ValidationResult{}
+	if len(rules) == 0 {
+		return result
+	}
+
+	// Rules refer to the canonical names, so aliases are resolved before
+	// evaluating them. The canonical annotation has precedence over its aliases
+	canonical := make(map[string]string)
+	fullNames := make(map[string]string)
+	for annotation, value := range annotations {
+		if !config.HasPrefix(annotation) {
+			continue
+		}
+		ann, ok := lookup(config.TrimAnnotationPrefix(annotation))
+		if !ok || (ann.IsAlias() && canonical[ann.Canonical] != "") {
+			continue
+		}
+		canonical[ann.Canonical] = value
+		fullNames[ann.Canonical] = annotation
+	}
+
+	for _, rule := range rules {
+		if !rule.violated(canonical) {
+			continue
+		}
+		ann, _ := lookup(rule.Annotation)
+		result.Add(newRuleFinding(fullNames[rule.Annotation], canonical[rule.Annotation], ann, rule))
+	}
+	return result
+}
+
+// newRuleFinding returns the finding of an annotation that violates a rule
+func newRuleFinding(annotation, value string, ann RegisteredAnnotation, rule Rule) Finding {
+	findingType := FindingMissingRequirement
+	switch rule.Type {
+	case RuleConflictsWith:
+		findingType = FindingConflict
+	case RuleIgnoredWhen:
+		findingType = FindingIgnoredAnnotation
+	}
+	return Finding{
+		Type:       findingType,
+		Severity:   rule.Severity,
+		Annotation: annotation,
+		Value:      value,
+		Feature:    ann.Feature,
+		Group:      ann.Group,
+		Risk:       ann.Config.Risk,
+		Reason:     rule.String(),
+		err:        ing_errors.NewInvalidAnnotationConfiguration(annotation, rule.String()),
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newRulesRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry := NewRegistry()
+	if err := registry.Register("affinity", Annotation{
+		Group: "affinity",
+		Annotations: AnnotationFields{
+			"affinity":            {Validator: ValidateNull},
+			"session-cookie-name": {Validator: ValidateNull, AnnotationAliases: []string{"session-cookie"}},
+			"auth-type":           {Validator: ValidateNull},
+			"auth-secret":         {Validator: ValidateNull},
+			"upstream-hash-by":    {Validator: ValidateNull},
+			"ssl-passthrough":     {Validator: ValidateNull},
+		},
+		Rules: []Rule{
+			Requires("session-cookie-name", "affinity").WithValues("cookie"),
+			Requires("auth-type", "auth-secret").WithSeverity(SeverityError),
+			IgnoredWhen("upstream-hash-by", "affinity").WithValues("cookie"),
+			ConflictsWith("ssl-passthrough", "affinity", "upstream-hash-by"),
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error registering feature: %s", err)
+	}
+	return registry
+}
+
+func TestRegistryRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantType    FindingType
+		wantSev     Severity
+		wantAnn     string
+	}{
+		{
+			name: "satisfied requirement should not be reported",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("affinity"):            "Cookie",
+				GetAnnotationWithPrefix("session-cookie-name"): "route",
+			},
+		},
+		{
+			name: "missing requirement should be reported",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("session-cookie-name"): "route",
+			},
+			wantType: FindingMissingRequirement,
+			wantSev:  SeverityWarning,
+			wantAnn:  GetAnnotationWithPrefix("session-cookie-name"),
+		},
+		{
+			name: "requirement with the wrong value should be reported using the alias name",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("affinity"):       "something",
+				GetAnnotationWithPrefix("session-cookie"): "route",
+			},
+			wantType: FindingMissingRequirement,
+			wantSev:  SeverityWarning,
+			wantAnn:  GetAnnotationWithPrefix("session-cookie"),
+		},
+		{
+			name: "missing requirement with error severity",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("auth-type"): "basic",
+			},
+			wantType: FindingMissingRequirement,
+			wantSev:  SeverityError,
+			wantAnn:  GetAnnotationWithPrefix("auth-type"),
+		},
+		{
+			name: "ignored annotation should be reported",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("affinity"):         "cookie",
+				GetAnnotationWithPrefix("session-cookie"):   "route",
+				GetAnnotationWithPrefix("upstream-hash-by"): "$request_uri",
+			},
+			wantType: FindingIgnoredAnnotation,
+			wantSev:  SeverityWarning,
+			wantAnn:  GetAnnotationWithPrefix("upstream-hash-by"),
+		},
+		{
+			name: "conflicting annotation should be reported",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("ssl-passthrough"):  "true",
+				GetAnnotationWithPrefix("upstream-hash-by"): "$request_uri",
+			},
+			wantType: FindingConflict,
+			wantSev:  SeverityError,
+			wantAnn:  GetAnnotationWithPrefix("ssl-passthrough"),
+		},
+	}
+	registry := newRulesRegistry(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := registry.Validate(&networking.Ingress{ObjectMeta: v1.ObjectMeta{Annotations: tt.annotations}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantType == "" {
+				if len(result.Findings) != 0 {
+					t.Errorf("expected no findings, got %+v", result.Findings)
+				}
+				return
+			}
+			if len(result.Findings) != 1 {
+				t.Fatalf("expected a single finding, got %+v", result.Findings)
+			}
+			finding := result.Findings[0]
+			if finding.Type != tt.wantType || finding.Severity != tt.wantSev || finding.Annotation != tt.wantAnn {
+				t.Errorf("unexpected finding %+v", finding)
+			}
+			if _, ok := finding.Err().(ing_errors.InvalidConfigurationError); !ok {
+				t.Errorf("expected InvalidConfigurationError, got %T", finding.Err())
+			}
+		})
+	}
+}
+
+func TestRegisterInvalidRule(t *testing.T) {
+	registry := NewRegistry()
+	err := registry.Register("feature", Annotation{
+		Annotations: AnnotationFields{"some-annotation": {Validator: ValidateNull}},
+		Rules:       []Rule{Requires("other-annotation", "some-annotation")},
+	})
+	if err == nil {
+		t.Error("expected rule of an annotation from another feature to fail")
+	}
+}
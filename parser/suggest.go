@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// maxSuggestions is the maximum amount of suggestions returned for an unknown annotation
+const maxSuggestions = 3
+
+// CheckUnknownAnnotations checks if the annotations using the controller prefix exist
+// on the fields. Annotations that are not known are returned as an UnknownAnnotationError
+// containing the most similar annotation names as suggestions.
+// Annotations without the controller prefix are ignored
+func CheckUnknownAnnotations(annotations map[string]string, config AnnotationFields) error {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	return checkUnknown(annotations, DefaultConfig(), keys, config.lookup).Err()
+}
+
+// checkUnknown returns a finding for each prefixed annotation that is not known
+func checkUnknown(annotations map[string]string, config Config, keys []string, lookup func(string) (RegisteredAnnotation, bool)) *ValidationResult {
+	// Sorting guarantees the same suggestions on every execution
+	keys = slices.Sorted(slices.Values(keys))
+
+	result := &ValidationResult{}
+	for annotation, value := range annotations {
+		if !config.HasPrefix(annotation) {
+			continue
+		}
+		annTrim := config.TrimAnnotationPrefix(annotation)
+		if _, ok := lookup(annTrim); ok {
+			continue
+		}
+		suggestions := SuggestAnnotations(annTrim, keys)
+		for i := range suggestions {
+			suggestions[i] = config.AnnotationWithPrefix(suggestions[i])
+		}
+		result.Add(newUnknownFinding(annotation, value, suggestions))
+	}
+	return result
+}
+
+// SuggestAnnotations returns the annotation names from keys that are most similar
+// to name. Names with a small edit distance (typos like proxy-body-sise) and names
+// that contain all the characters of name in order (like enable-cor) are considered
+// similar. The closest names are returned first
+func SuggestAnnotations(name string, keys []string) []string {
+	type candidate struct {
+		key      string
+		distance int
+	}
+
+	maxDistance := min(max(len(name)/4, 1), 3)
+	candidates := make([]candidate, 0)
+	added := make(map[string]bool)
+	for _, key := range keys {
+		if distance := levenshtein(name, key); distance <= maxDistance {
+			candidates = append(candidates, candidate{key: key, distance: distance})
+			added[key] = true
+		}
+	}
+	for _, match := range fuzzy.Find(name, keys) {
+		if !added[match.Str] {
+			candidates = append(candidates, candidate{key: match.Str, distance: levenshtein(name, match.Str)})
+			added[match.Str] = true
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.key, b.key)
+	})
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].key)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
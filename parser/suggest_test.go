@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	ing_errors "github.com/rikatz/ingress-nginx-annotations/errors"
+)
+
+var suggestionKeys = []string{
+	"cors-allow-origin",
+	"enable-cors",
+	"enable-modsecurity",
+	"enable-owasp-core-rules",
+	"proxy-body-size",
+	"proxy-buffer-size",
+	"proxy-busy-buffers-size",
+	"rewrite-target",
+}
+
+func TestSuggestAnnotations(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantFirst string
+		wantEmpty bool
+	}{
+		{
+			name:      "typo should suggest the closest annotation",
+			value:     "proxy-body-sise",
+			wantFirst: "proxy-body-size",
+		},
+		{
+			name:      "missing character should suggest the annotation",
+			value:     "enable-cor",
+			wantFirst: "enable-cors",
+		},
+		{
+			name:      "swapped characters should suggest the annotation",
+			value:     "rewrite-taregt",
+			wantFirst: "rewrite-target",
+		},
+		{
+			name:      "completely different annotation should not suggest anything",
+			value:     "xyzw",
+			wantEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestAnnotations(tt.value, suggestionKeys)
+			if tt.wantEmpty {
+				if len(got) != 0 {
+					t.Errorf("SuggestAnnotations() = %v, want empty", got)
+				}
+				return
+			}
+			if len(got) == 0 || got[0] != tt.wantFirst {
+				t.Errorf("SuggestAnnotations() = %v, want first %v", got, tt.wantFirst)
+			}
+			if len(got) > maxSuggestions {
+				t.Errorf("SuggestAnnotations() returned %d suggestions, max is %d", len(got), maxSuggestions)
+			}
+		})
+	}
+}
+
+func TestCheckUnknownAnnotations(t *testing.T) {
+	config := AnnotationFields{}
+	for _, key := range suggestionKeys {
+		config[key] = AnnotationConfig{Validator: ValidateNull}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantUnknown []string
+	}{
+		{
+			name: "known annotations and other prefixes should be accepted",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("enable-cors"): "true",
+				"kubernetes.io/ingress.class":          "nginx",
+				"other.io/proxy-body-sise":             "1m",
+			},
+		},
+		{
+			name: "unknown annotations should be reported",
+			annotations: map[string]string{
+				GetAnnotationWithPrefix("enable-cors"):     "true",
+				GetAnnotationWithPrefix("proxy-body-sise"): "1m",
+				GetAnnotationWithPrefix("something"):       "1m",
+			},
+			wantUnknown: []string{GetAnnotationWithPrefix("proxy-body-sise"), GetAnnotationWithPrefix("something")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckUnknownAnnotations(tt.annotations, config)
+			if (err != nil) != (len(tt.wantUnknown) > 0) {
+				t.Fatalf("CheckUnknownAnnotations() error = %v, want unknown %v", err, tt.wantUnknown)
+			}
+			if err == nil {
+				return
+			}
+			joined, ok := err.(interface{ Unwrap() []error })
+			if !ok {
+				t.Fatalf("expected a joined error, got %T", err)
+			}
+			got := make([]string, 0)
+			for _, e := range joined.Unwrap() {
+				var unknown ing_errors.UnknownAnnotationError
+				if !errors.As(e, &unknown) {
+					t.Fatalf("expected UnknownAnnotationError, got %T", e)
+				}
+				if unknown.Annotation == GetAnnotationWithPrefix("proxy-body-sise") &&
+					(len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != GetAnnotationWithPrefix("proxy-body-size")) {
+					t.Errorf("unexpected suggestions %v", unknown.Suggestions)
+				}
+				got = append(got, unknown.Annotation)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.wantUnknown) {
+				t.Errorf("CheckUnknownAnnotations() reported %v, want %v", got, tt.wantUnknown)
+			}
+		})
+	}
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package parser
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -82,10 +81,17 @@ var (
 	MaliciousRegex = regexp.MustCompile(`\r|\n`)
 )
 
+const (
+	// commonNamePrefix is the prefix of the Common Name annotations
+	commonNamePrefix = "CN="
+	// serverNameSeparator separates the server names of an array of server names
+	serverNameSeparator = ","
+)
+
 // ValidateArrayOfServerName validates if all fields on a Server name annotation are
 // regexes. They can be *.something*, ~^www\d+\.example\.com$ but not fancy character
 func ValidateArrayOfServerName(value string) error {
-	for _, fqdn := range strings.Split(value, ",") {
+	for _, fqdn := range strings.Split(value, serverNameSeparator) {
 		if err := ValidateServerName(fqdn); err != nil {
 			return err
 		}
@@ -125,15 +131,24 @@ func ValidateRegex(regex *regexp.Regexp, removeSpace bool) AnnotationValidator {
 // CommonNameAnnotationValidator checks whether the annotation value starts with
 // 'CN=' and is followed by a valid regex.
 func CommonNameAnnotationValidator(s string) error {
-	if !strings.HasPrefix(s, "CN=") {
-		return fmt.Errorf("value %s is not a valid Common Name annotation: missing prefix 'CN='", s)
+	_, err := CompileCommonName(s)
+	return err
+}
+
+// CompileCommonName compiles a value starting with 'CN=' and followed by a regex. The
+// prefix is part of the returned expression, as nginx matches the whole value against
+// the subject of the client certificate
+func CompileCommonName(s string) (*regexp.Regexp, error) {
+	if !strings.HasPrefix(s, commonNamePrefix) {
+		return nil, fmt.Errorf("value %s is not a valid Common Name annotation: missing prefix '%s'", s, commonNamePrefix)
 	}
 
-	if _, err := regexp.Compile(s[3:]); err != nil {
-		return fmt.Errorf("value %s is not a valid regex: %w", s, err)
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("value %s is not a valid regex: %w", s, err)
 	}
 
-	return nil
+	return re, nil
 }
 
 // ValidateOptions receives an array of valid options that can be the value of annotation.
@@ -203,20 +218,26 @@ func ValidateServiceName(value string) error {
 // 4 - Runs the validator on the value
 // It will return the full annotation name if all is fine
 func CheckAnnotation(name string, ing *networking.Ingress, fields AnnotationFields) (string, error) {
+	return CheckAnnotationWithConfig(name, ing, fields, DefaultConfig())
+}
+
+// CheckAnnotationWithConfig does the same checks of CheckAnnotation, using the prefix
+// and validation settings of config instead of the package level attributes
+func CheckAnnotationWithConfig(name string, ing *networking.Ingress, fields AnnotationFields, config Config) (string, error) {
 	var validateFunc AnnotationValidator
 	if fields != nil {
-		config, ok := fields[name]
+		fieldConfig, ok := fields[name]
 		if !ok {
 			return "", fmt.Errorf("annotation does not contain a valid internal configuration, this is an Ingress Controller issue! Please raise an issue on github.com/kubernetes/ingress-nginx")
 		}
-		validateFunc = config.Validator
+		validateFunc = fieldConfig.Validator
 	}
 
 	if ing == nil || len(ing.GetAnnotations()) == 0 {
 		return "", ing_errors.ErrMissingAnnotations
 	}
 
-	annotationFullName := GetAnnotationWithPrefix(name)
+	annotationFullName := config.AnnotationWithPrefix(name)
 	if annotationFullName == "" {
 		return "", ing_errors.ErrInvalidAnnotationName
 	}
@@ -228,7 +249,7 @@ func CheckAnnotation(name string, ing *networking.Ingress, fields AnnotationFiel
 		}
 		if annotationValue == "" {
 			for _, annotationAlias := range fields[name].AnnotationAliases {
-				tempAnnotationFullName := GetAnnotationWithPrefix(annotationAlias)
+				tempAnnotationFullName := config.AnnotationWithPrefix(annotationAlias)
 				if aliasVal := ing.GetAnnotations()[tempAnnotationFullName]; aliasVal != "" {
 					annotationValue = aliasVal
 					annotationFullName = tempAnnotationFullName
@@ -237,9 +258,9 @@ func CheckAnnotation(name string, ing *networking.Ingress, fields AnnotationFiel
 			}
 		}
 		// We don't run validation against empty values
-		if EnableAnnotationValidation && annotationValue != "" {
+		if config.EnableValidation && annotationValue != "" {
 			if err := validateFunc(annotationValue); err != nil {
-				return "", ing_errors.NewValidationError(annotationFullName)
+				return "", ing_errors.NewAnnotationValidationError(annotationFullName, annotationValue, err)
 			}
 		}
 	}
@@ -247,13 +268,21 @@ func CheckAnnotation(name string, ing *networking.Ingress, fields AnnotationFiel
 	return annotationFullName, nil
 }
 
+// CheckAnnotationRisk checks if any of the annotations has a risk higher than maxrisk
 func CheckAnnotationRisk(annotations map[string]string, maxrisk AnnotationRisk, config AnnotationFields) error {
-	var err error
-	for annotation := range annotations {
-		annPure := TrimAnnotationPrefix(annotation)
-		if cfg, ok := config[annPure]; ok && cfg.Risk > maxrisk {
-			err = errors.Join(err, fmt.Errorf("annotation %s is too risky for environment", annotation))
+	cfg := DefaultConfig()
+	cfg.MaxRisk = maxrisk
+	return checkRisk(annotations, cfg, config.lookup).Err()
+}
+
+// checkRisk returns a finding for each known annotation with a risk higher than the configured maximum
+func checkRisk(annotations map[string]string, config Config, lookup func(string) (RegisteredAnnotation, bool)) *ValidationResult {
+	result := &ValidationResult{}
+	for annotation, value := range annotations {
+		annPure := config.TrimAnnotationPrefix(annotation)
+		if ann, ok := lookup(annPure); ok && ann.Config.Risk > config.MaxRisk {
+			result.Add(newRiskyFinding(annotation, value, ann, config.MaxRisk))
 		}
 	}
-	return err
+	return result
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package references
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Fake is an in-memory implementation of all the listers, to be used on tests and on
+// tools validating manifests that are not applied to a cluster yet
+type Fake struct {
+	secrets    map[types.NamespacedName]*corev1.Secret
+	configMaps map[types.NamespacedName]*corev1.ConfigMap
+	services   map[types.NamespacedName]*corev1.Service
+}
+
+// NewFake returns a Fake containing the objects. Objects that are not a Secret,
+// ConfigMap or Service are ignored
+func NewFake(objects ...any) *Fake {
+	f := &Fake{
+		secrets:    make(map[types.NamespacedName]*corev1.Secret),
+		configMaps: make(map[types.NamespacedName]*corev1.ConfigMap),
+		services:   make(map[types.NamespacedName]*corev1.Service),
+	}
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *corev1.Secret:
+			f.secrets[types.NamespacedName{Namespace: o.Namespace, Name: o.Name}] = o
+		case *corev1.ConfigMap:
+			f.configMaps[types.NamespacedName{Namespace: o.Namespace, Name: o.Name}] = o
+		case *corev1.Service:
+			f.services[types.NamespacedName{Namespace: o.Namespace, Name: o.Name}] = o
+		}
+	}
+	return f
+}
+
+// Listers returns the Fake as the lister of all the kinds
+func (f *Fake) Listers() Listers {
+	return Listers{Secrets: f, ConfigMaps: f, Services: f}
+}
+
+// GetSecret returns a Secret of the Fake
+func (f *Fake) GetSecret(namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := f.secrets[types.NamespacedName{Namespace: namespace, Name: name}]; ok {
+		return secret, nil
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+}
+
+// GetConfigMap returns a ConfigMap of the Fake
+func (f *Fake) GetConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
+	if configMap, ok := f.configMaps[types.NamespacedName{Namespace: namespace, Name: name}]; ok {
+		return configMap, nil
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+}
+
+// GetService returns a Service of the Fake
+func (f *Fake) GetService(namespace, name string) (*corev1.Service, error) {
+	if service, ok := f.services[types.NamespacedName{Namespace: namespace, Name: name}]; ok {
+		return service, nil
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("services"), name)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package references resolves the Secrets, ConfigMaps and Services referenced by
+// annotations, and validates them the way ingress-nginx uses them
+package references
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecretLister returns the Secrets referenced by annotations, like auth-secret. It
+// must return an error satisfying k8s.io/apimachinery/pkg/api/errors.IsNotFound when
+// the Secret does not exist
+type SecretLister interface {
+	GetSecret(namespace, name string) (*corev1.Secret, error)
+}
+
+// ConfigMapLister returns the ConfigMaps referenced by annotations, like custom-headers
+type ConfigMapLister interface {
+	GetConfigMap(namespace, name string) (*corev1.ConfigMap, error)
+}
+
+// ServiceLister returns the Services referenced by annotations, like default-backend
+type ServiceLister interface {
+	GetService(namespace, name string) (*corev1.Service, error)
+}
+
+// Listers are used to read the referenced objects. The references to the kinds
+// without a lister are only checked for the namespace rules
+type Listers struct {
+	Secrets    SecretLister
+	ConfigMaps ConfigMapLister
+	Services   ServiceLister
+}
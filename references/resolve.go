@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package references
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/auth"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/authreq"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/authtls"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/customheaders"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/defaultbackend"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/fastcgi"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/proxyssl"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	authSecretAnnotation             = "auth-secret"
+	authTLSSecretAnnotation          = "auth-tls-secret"
+	proxySSLSecretAnnotation         = "proxy-ssl-secret"
+	customHeadersAnnotation          = "custom-headers"
+	authProxySetHeadersAnnotation    = "auth-proxy-set-headers"
+	fastCGIParamsConfigMapAnnotation = "fastcgi-params-configmap"
+	defaultBackendAnnotation         = "default-backend"
+)
+
+// Keys of the Secrets used by ingress-nginx
+const (
+	CACertificateKey    = "ca.crt"
+	CARevocationListKey = "ca.crl"
+	TLSCertificateKey   = corev1.TLSCertKey
+	TLSPrivateKeyKey    = corev1.TLSPrivateKeyKey
+)
+
+const (
+	kindSecret    = "secret"
+	kindConfigMap = "configmap"
+	kindService   = "service"
+
+	pemCertificateType = "CERTIFICATE"
+	pemRevocationList  = "X509 CRL"
+)
+
+// reference is an annotation referencing an object
+type reference struct {
+	feature    string
+	annotation parser.Annotation
+	name       string
+	kind       string
+	// requireNamespace is true when the value must be in the form namespace/name
+	requireNamespace bool
+	// nameOnly is true when the value is the name of an object of the namespace of the Ingress
+	nameOnly bool
+}
+
+// references are the annotations referencing objects, in the order they are resolved
+var references = []reference{
+	{feature: "auth", annotation: auth.AuthSecretAnnotations, name: authSecretAnnotation, kind: kindSecret},
+	{feature: "authtls", annotation: authtls.AuthTLSAnnotations, name: authTLSSecretAnnotation, kind: kindSecret, requireNamespace: true},
+	{feature: "proxyssl", annotation: proxyssl.ProxySSLAnnotation, name: proxySSLSecretAnnotation, kind: kindSecret, requireNamespace: true},
+	{feature: "customheaders", annotation: customheaders.CustomHeadersAnnotation, name: customHeadersAnnotation, kind: kindConfigMap},
+	{feature: "authreq", annotation: authreq.AuthReqAnnotations, name: authProxySetHeadersAnnotation, kind: kindConfigMap},
+	{feature: "fastcgi", annotation: fastcgi.FastCGIAnnotations, name: fastCGIParamsConfigMapAnnotation, kind: kindConfigMap},
+	{feature: "defaultbackend", annotation: defaultbackend.DefaultBackendAnnotations, name: defaultBackendAnnotation, kind: kindService, nameOnly: true},
+}
+
+// Resolve resolves the objects referenced by the annotations of the Ingress, using the
+// default parser configuration
+func Resolve(ing *networking.Ingress, listers Listers) *parser.ValidationResult {
+	return ResolveWithConfig(ing, listers, parser.DefaultConfig())
+}
+
+// ResolveWithConfig resolves the Secrets, ConfigMaps and Services referenced by the
+// annotations of the Ingress, and validates them as ingress-nginx uses them. References
+// to other namespaces, objects that do not exist, missing keys, invalid PEM data and
+// invalid headers are returned as UnresolvedReference findings. Annotations with invalid values are skipped,
+// as they are already reported by the registry validation
+func ResolveWithConfig(ing *networking.Ingress, listers Listers, config parser.Config) *parser.ValidationResult {
+	result := &parser.ValidationResult{}
+	if ing == nil {
+		return result
+	}
+	for _, ref := range references {
+		r := parser.NewAnnotationReader(ing, ref.annotation.Annotations, config)
+		value, ok := r.Value(ref.name)
+		if !ok {
+			continue
+		}
+		if reason := resolve(ing, listers, config, ref, value); reason != "" {
+			result.Add(newFinding(ref, config, value, reason))
+		}
+	}
+	return result
+}
+
+// resolve returns why the object referenced by the annotation cannot be used, or an
+// empty string when it is valid
+func resolve(ing *networking.Ingress, listers Listers, config parser.Config, ref reference, value string) string {
+	var name types.NamespacedName
+	if ref.nameOnly {
+		value = strings.TrimSpace(value)
+		if value == "" || strings.Contains(value, "/") {
+			return fmt.Sprintf("%q must be the name of a %s in the namespace %s", value, ref.kind, ing.Namespace)
+		}
+		name = types.NamespacedName{Namespace: ing.Namespace, Name: value}
+	} else {
+		var err error
+		name, err = parser.ParseObjectReference(ref.kind, value, ing.Namespace, ref.requireNamespace)
+		if err != nil {
+			return err.Error()
+		}
+	}
+
+	var err error
+	switch ref.kind {
+	case kindSecret:
+		if listers.Secrets == nil {
+			return ""
+		}
+		var secret *corev1.Secret
+		if secret, err = listers.Secrets.GetSecret(name.Namespace, name.Name); err == nil {
+			return checkSecret(ing, config, ref.name, secret)
+		}
+	case kindConfigMap:
+		if listers.ConfigMaps == nil {
+			return ""
+		}
+		var configMap *corev1.ConfigMap
+		if configMap, err = listers.ConfigMaps.GetConfigMap(name.Namespace, name.Name); err == nil {
+			return checkConfigMap(ref.name, configMap)
+		}
+	case kindService:
+		if listers.Services == nil {
+			return ""
+		}
+		_, err = listers.Services.GetService(name.Namespace, name.Name)
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		return fmt.Sprintf("%s %s does not exist", ref.kind, name)
+	case err != nil:
+		return fmt.Sprintf("error reading %s %s: %s", ref.kind, name, err)
+	}
+	return ""
+}
+
+// checkSecret returns why the Secret cannot be used by the annotation, or an empty
+// string when it contains the keys ingress-nginx requires
+func checkSecret(ing *networking.Ingress, config parser.Config, annotation string, secret *corev1.Secret) string {
+	name := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	switch annotation {
+	case authSecretAnnotation:
+		// Invalid annotations were already reported, and auth-secret-type keeps the default
+		c, _ := auth.ParseWithConfig(ing, config)
+		if c.SecretType == auth.SecretTypeAuthMap {
+			if len(secret.Data) == 0 {
+				return fmt.Sprintf("secret %s does not contain any user", name)
+			}
+			return ""
+		}
+		if _, ok := secret.Data[auth.AuthFileKey]; !ok {
+			return fmt.Sprintf("secret %s does not contain the key %s", name, auth.AuthFileKey)
+		}
+	case authTLSSecretAnnotation:
+		if err := checkCertificates(secret, CACertificateKey); err != nil {
+			return fmt.Sprintf("secret %s: %s", name, err)
+		}
+		if crl, ok := secret.Data[CARevocationListKey]; ok {
+			if err := checkRevocationList(crl); err != nil {
+				return fmt.Sprintf("secret %s: key %s %s", name, CARevocationListKey, err)
+			}
+		}
+	case proxySSLSecretAnnotation:
+		if err := checkCertificates(secret, CACertificateKey); err != nil {
+			return fmt.Sprintf("secret %s: %s", name, err)
+		}
+		// The client certificate is optional, and only used when the Secret contains it
+		_, hasCert := secret.Data[TLSCertificateKey]
+		_, hasKey := secret.Data[TLSPrivateKeyKey]
+		if !hasCert && !hasKey {
+			return ""
+		}
+		for _, key := range []string{TLSCertificateKey, TLSPrivateKeyKey} {
+			if _, ok := secret.Data[key]; !ok {
+				return fmt.Sprintf("secret %s does not contain the key %s", name, key)
+			}
+		}
+		if _, err := tls.X509KeyPair(secret.Data[TLSCertificateKey], secret.Data[TLSPrivateKeyKey]); err != nil {
+			return fmt.Sprintf("secret %s does not contain a valid certificate and key: %s", name, err)
+		}
+	}
+	return ""
+}
+
+// checkConfigMap returns why the ConfigMap cannot be used by the annotation, or an
+// empty string when it is valid. The ConfigMaps of headers are rejected when any
+// header could inject other headers or break the connection
+func checkConfigMap(annotation string, configMap *corev1.ConfigMap) string {
+	switch annotation {
+	case customHeadersAnnotation, authProxySetHeadersAnnotation:
+		if _, err := customheaders.ParseHeaders(configMap.Data); err != nil {
+			return fmt.Sprintf("configmap %s/%s contains invalid headers: %s", configMap.Namespace, configMap.Name, strings.ReplaceAll(err.Error(), "\n", ", "))
+		}
+	}
+	return ""
+}
+
+// checkCertificates returns an error when the key of the Secret does not contain PEM
+// encoded certificates
+func checkCertificates(secret *corev1.Secret, key string) error {
+	data, ok := secret.Data[key]
+	if !ok {
+		return fmt.Errorf("does not contain the key %s", key)
+	}
+	found := false
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != pemCertificateType {
+			return fmt.Errorf("key %s contains a PEM block of type %s instead of %s", key, block.Type, pemCertificateType)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("key %s contains an invalid certificate: %w", key, err)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("key %s does not contain any PEM encoded certificate", key)
+	}
+	return nil
+}
+
+// checkRevocationList returns an error when the data is not a PEM encoded certificate revocation list
+func checkRevocationList(data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemRevocationList {
+		return fmt.Errorf("does not contain a PEM encoded certificate revocation list")
+	}
+	if _, err := x509.ParseRevocationList(block.Bytes); err != nil {
+		return fmt.Errorf("contains an invalid certificate revocation list: %w", err)
+	}
+	return nil
+}
+
+// newFinding returns the finding of an annotation referencing an object that cannot be used
+func newFinding(ref reference, config parser.Config, value, reason string) parser.Finding {
+	return parser.Finding{
+		Type:       parser.FindingUnresolvedReference,
+		Severity:   parser.SeverityError,
+		Annotation: config.AnnotationWithPrefix(ref.name),
+		Value:      value,
+		Feature:    ref.feature,
+		Group:      ref.annotation.Group,
+		Risk:       ref.annotation.Annotations[ref.name].Risk,
+		Reason:     reason,
+	}
+}
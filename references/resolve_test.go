@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package references
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// certificate returns a PEM encoded self signed certificate, its key and an empty revocation list
+func certificate(t *testing.T) (cert, key, crl []byte) {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{Number: big.NewInt(1), ThisUpdate: time.Now(), NextUpdate: time.Now().Add(time.Hour)}, parsed, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER})
+}
+
+func secret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Data: data}
+}
+
+func TestResolve(t *testing.T) {
+	cert, key, crl := certificate(t)
+	fake := NewFake(
+		secret("users", map[string][]byte{"auth": []byte("bob:{PLAIN}secret\n")}),
+		secret("empty", map[string][]byte{}),
+		secret("ca", map[string][]byte{CACertificateKey: cert, CARevocationListKey: crl}),
+		secret("bad-ca", map[string][]byte{CACertificateKey: []byte("not a certificate")}),
+		secret("bad-crl", map[string][]byte{CACertificateKey: cert, CARevocationListKey: cert}),
+		secret("client", map[string][]byte{TLSCertificateKey: cert, TLSPrivateKeyKey: key, CACertificateKey: cert}),
+		secret("no-key", map[string][]byte{TLSCertificateKey: cert, CACertificateKey: cert}),
+		secret("mismatch", map[string][]byte{TLSCertificateKey: cert, TLSPrivateKeyKey: []byte("key"), CACertificateKey: cert}),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "headers", Namespace: "default"}, Data: map[string]string{"X-Frame-Options": "DENY"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "injection", Namespace: "default"}, Data: map[string]string{"X-A": "a\r\nSet-Cookie: b"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}},
+	)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		listers     Listers
+		// errors are the annotations with UnresolvedReference findings
+		errors []string
+	}{
+		{
+			name: "valid references",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-secret":              "users",
+				"nginx.ingress.kubernetes.io/auth-tls-secret":          "default/ca",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret":         "default/client",
+				"nginx.ingress.kubernetes.io/custom-headers":           "headers",
+				"nginx.ingress.kubernetes.io/auth-proxy-set-headers":   "default/headers",
+				"nginx.ingress.kubernetes.io/fastcgi-params-configmap": "headers",
+				"nginx.ingress.kubernetes.io/default-backend":          "backend",
+			},
+			listers: fake.Listers(),
+		},
+		{
+			name: "missing objects",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-secret":     "missing",
+				"nginx.ingress.kubernetes.io/custom-headers":  "missing",
+				"nginx.ingress.kubernetes.io/default-backend": "missing",
+			},
+			listers: fake.Listers(),
+			errors: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/custom-headers",
+				"nginx.ingress.kubernetes.io/default-backend",
+			},
+		},
+		{
+			name: "cross namespace references",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-secret":    "other/users",
+				"nginx.ingress.kubernetes.io/custom-headers": "other/headers",
+			},
+			errors: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/custom-headers",
+			},
+		},
+		{
+			name: "namespace required",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-tls-secret": "ca",
+			},
+			listers: fake.Listers(),
+			errors:  []string{"nginx.ingress.kubernetes.io/auth-tls-secret"},
+		},
+		{
+			name: "missing keys",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-secret":      "empty",
+				"nginx.ingress.kubernetes.io/auth-tls-secret":  "default/empty",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret": "default/empty",
+			},
+			listers: fake.Listers(),
+			errors: []string{
+				"nginx.ingress.kubernetes.io/auth-secret",
+				"nginx.ingress.kubernetes.io/auth-tls-secret",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret",
+			},
+		},
+		{
+			name: "proxy ssl secret without client certificate",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret": "default/ca",
+			},
+			listers: fake.Listers(),
+		},
+		{
+			name: "proxy ssl secret without client key",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret": "default/no-key",
+			},
+			listers: fake.Listers(),
+			errors:  []string{"nginx.ingress.kubernetes.io/proxy-ssl-secret"},
+		},
+		{
+			name: "auth map without users",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-secret":      "empty",
+				"nginx.ingress.kubernetes.io/auth-secret-type": "auth-map",
+			},
+			listers: fake.Listers(),
+			errors:  []string{"nginx.ingress.kubernetes.io/auth-secret"},
+		},
+		{
+			name: "invalid PEM",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-tls-secret":  "default/bad-ca",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret": "default/mismatch",
+			},
+			listers: fake.Listers(),
+			errors: []string{
+				"nginx.ingress.kubernetes.io/auth-tls-secret",
+				"nginx.ingress.kubernetes.io/proxy-ssl-secret",
+			},
+		},
+		{
+			name: "invalid revocation list",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-tls-secret": "default/bad-crl",
+			},
+			listers: fake.Listers(),
+			errors:  []string{"nginx.ingress.kubernetes.io/auth-tls-secret"},
+		},
+		{
+			name: "invalid headers",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/custom-headers":           "injection",
+				"nginx.ingress.kubernetes.io/auth-proxy-set-headers":   "injection",
+				"nginx.ingress.kubernetes.io/fastcgi-params-configmap": "injection",
+			},
+			listers: fake.Listers(),
+			errors: []string{
+				"nginx.ingress.kubernetes.io/auth-proxy-set-headers",
+				"nginx.ingress.kubernetes.io/custom-headers",
+			},
+		},
+		{
+			name: "kinds without listers are not read",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-secret":    "missing",
+				"nginx.ingress.kubernetes.io/custom-headers": "missing",
+			},
+			listers: Listers{Secrets: fake},
+			errors:  []string{"nginx.ingress.kubernetes.io/auth-secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "default", Annotations: tt.annotations},
+			}
+			result := Resolve(ing, tt.listers)
+			errors := make([]string, 0)
+			for _, finding := range result.Findings {
+				if finding.Type != parser.FindingUnresolvedReference || finding.Severity != parser.SeverityError {
+					t.Errorf("unexpected finding %v", finding)
+				}
+				errors = append(errors, finding.Annotation)
+			}
+			if tt.errors == nil {
+				tt.errors = []string{}
+			}
+			if !reflect.DeepEqual(errors, tt.errors) {
+				t.Errorf("expected findings of %v, got %v: %v", tt.errors, errors, result.Findings)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations_test
+
+import (
+	"testing"
+
+	annotations "github.com/rikatz/ingress-nginx-annotations"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+)
+
+func TestNewRegistry(t *testing.T) {
+	registry := annotations.NewRegistry()
+
+	ann, ok := registry.Lookup("limit-whitelist")
+	if !ok {
+		t.Fatal("expected limit-whitelist to be registered")
+	}
+	if ann.Canonical != "limit-allowlist" || ann.Feature != "ratelimit" || ann.Group != "rate-limit" {
+		t.Errorf("unexpected annotation %+v", ann)
+	}
+
+	if got := len(registry.ByFeature("canary")); got != 7 {
+		t.Errorf("expected 7 canary annotations, got %d", got)
+	}
+
+	if len(annotations.NewAnnotationFactory()) != len(registry.Fields()) {
+		t.Error("expected the factory to contain the same annotations of the registry")
+	}
+
+	if err := registry.ValidateRules(); err != nil {
+		t.Errorf("unexpected error validating the rules: %s", err)
+	}
+
+	for _, ann := range registry.Annotations() {
+		if ann.Config.Format == "" {
+			t.Errorf("annotation %s does not describe its format", ann.Name)
+		}
+		switch ann.Config.GatewayAPICompatibility {
+		case parser.GatewayAPICompatible, parser.GatewayAPIPartial:
+			if ann.Config.GatewayAPI == "" || ann.Config.GatewayAPIRef == "" {
+				t.Errorf("annotation %s is %s but does not describe its Gateway API replacement", ann.Name, ann.Config.GatewayAPICompatibility)
+			}
+		case parser.GatewayAPIIncompatible:
+		default:
+			t.Errorf("annotation %s does not define its Gateway API compatibility", ann.Name)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snippets
+
+import (
+	"fmt"
+
+	"github.com/rikatz/ingress-nginx-annotations/annotations/authreq"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/modsecurity"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/serversnippet"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/snippet"
+	"github.com/rikatz/ingress-nginx-annotations/annotations/streamsnippet"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+)
+
+// snippetAnnotation is an annotation containing a snippet
+type snippetAnnotation struct {
+	feature    string
+	annotation parser.Annotation
+	name       string
+	// modSecurity is true when the snippet contains ModSecurity configuration instead of nginx configuration
+	modSecurity bool
+}
+
+// snippetAnnotations are the annotations containing snippets, in the order they are analyzed
+var snippetAnnotations = []snippetAnnotation{
+	{feature: "snippet", annotation: snippet.ConfigurationSnippetAnnotations, name: "configuration-snippet"},
+	{feature: "serversnippet", annotation: serversnippet.ServerSnippetAnnotations, name: "server-snippet"},
+	{feature: "streamsnippet", annotation: streamsnippet.StreamSnippetAnnotations, name: "stream-snippet"},
+	{feature: "authreq", annotation: authreq.AuthReqAnnotations, name: "auth-snippet"},
+	{feature: "modsecurity", annotation: modsecurity.ModsecurityAnnotation, name: "modsecurity-snippet", modSecurity: true},
+}
+
+// Snippet is the analysis of a snippet annotation of an Ingress
+type Snippet struct {
+	// Annotation is the full name of the annotation, including the prefix
+	Annotation string      `json:"annotation"`
+	Feature    string      `json:"feature"`
+	Directives []Directive `json:"directives"`
+	// Categories summarizes what the snippet does
+	Categories []Category `json:"categories"`
+	// Error is set when the snippet cannot be parsed, and Directives is empty
+	Error string `json:"error,omitempty"`
+
+	group parser.AnnotationGroup
+	risk  parser.AnnotationRisk
+	value string
+}
+
+// Dangerous returns the directives of the snippet flagged as dangerous, including the
+// ones inside blocks
+func (s Snippet) Dangerous() []Directive {
+	dangerous := make([]Directive, 0)
+	Walk(s.Directives, func(d Directive) {
+		if d.IsDangerous() {
+			dangerous = append(dangerous, d)
+		}
+	})
+	return dangerous
+}
+
+// Analysis is the result of analyzing the snippets of an Ingress
+type Analysis struct {
+	Snippets []Snippet `json:"snippets"`
+}
+
+// Result returns the findings of the snippets: a DangerousSnippet warning for each
+// dangerous directive, and an InvalidSnippet error for each snippet that cannot be parsed
+func (a *Analysis) Result() *parser.ValidationResult {
+	result := &parser.ValidationResult{}
+	for _, s := range a.Snippets {
+		if s.Error != "" {
+			result.Add(s.newFinding(parser.FindingInvalidSnippet, parser.SeverityError, s.Error))
+			continue
+		}
+		for _, d := range s.Dangerous() {
+			for _, danger := range d.Dangers {
+				result.Add(s.newFinding(parser.FindingDangerousSnippet, parser.SeverityWarning,
+					fmt.Sprintf("line %d: %s %s", d.Line, d.Name, danger)))
+			}
+		}
+	}
+	return result
+}
+
+// Analyze analyzes the snippets of the Ingress, using the default parser configuration
+func Analyze(ing *networking.Ingress) *Analysis {
+	return AnalyzeWithConfig(ing, parser.DefaultConfig())
+}
+
+// AnalyzeWithConfig parses the snippet annotations of the Ingress, configuration-snippet,
+// server-snippet, stream-snippet, auth-snippet and modsecurity-snippet, and classifies
+// their directives. Snippets that cannot be parsed are returned with their error
+func AnalyzeWithConfig(ing *networking.Ingress, config parser.Config) *Analysis {
+	analysis := &Analysis{Snippets: make([]Snippet, 0)}
+	if ing == nil {
+		return analysis
+	}
+	for _, a := range snippetAnnotations {
+		r := parser.NewAnnotationReader(ing, a.annotation.Annotations, config)
+		value, ok := r.Value(a.name)
+		if !ok {
+			continue
+		}
+
+		s := Snippet{
+			Annotation: config.AnnotationWithPrefix(a.name),
+			Feature:    a.feature,
+			Directives: make([]Directive, 0),
+			Categories: make([]Category, 0),
+			group:      a.annotation.Group,
+			risk:       a.annotation.Annotations[a.name].Risk,
+			value:      value,
+		}
+		parse := Parse
+		if a.modSecurity {
+			parse = ParseModSecurity
+		}
+		directives, err := parse(value)
+		if err != nil {
+			s.Error = err.Error()
+		} else {
+			s.Directives, s.Categories = directives, Categories(directives)
+		}
+		analysis.Snippets = append(analysis.Snippets, s)
+	}
+	return analysis
+}
+
+// newFinding returns a finding of the snippet
+func (s Snippet) newFinding(findingType parser.FindingType, severity parser.Severity, reason string) parser.Finding {
+	return parser.Finding{
+		Type:       findingType,
+		Severity:   severity,
+		Annotation: s.Annotation,
+		Value:      s.value,
+		Feature:    s.Feature,
+		Group:      s.group,
+		Risk:       s.risk,
+		Reason:     reason,
+	}
+}
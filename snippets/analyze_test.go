@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snippets
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnalyze(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ing",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/configuration-snippet": "more_set_headers \"X-Frame-Options: DENY\";\naccess_by_lua_block { ngx.exit(403) }",
+				"nginx.ingress.kubernetes.io/server-snippet":        "location /health { return 200 }",
+				"nginx.ingress.kubernetes.io/modsecurity-snippet":   "SecRuleEngine On",
+				"nginx.ingress.kubernetes.io/rewrite-target":        "/",
+			},
+		},
+	}
+
+	analysis := Analyze(ing)
+	categories := make(map[string][]Category)
+	errors := make(map[string]bool)
+	for _, s := range analysis.Snippets {
+		categories[s.Annotation] = s.Categories
+		errors[s.Annotation] = s.Error != ""
+	}
+	expected := map[string][]Category{
+		"nginx.ingress.kubernetes.io/configuration-snippet": {CategoryHeaders, CategoryLua},
+		"nginx.ingress.kubernetes.io/server-snippet":        {},
+		"nginx.ingress.kubernetes.io/modsecurity-snippet":   {CategoryAccessControl},
+	}
+	if !reflect.DeepEqual(categories, expected) {
+		t.Errorf("expected categories %v, got %v", expected, categories)
+	}
+	if !errors["nginx.ingress.kubernetes.io/server-snippet"] {
+		t.Errorf("expected server-snippet without semicolon to fail parsing")
+	}
+
+	result := analysis.Result()
+	findings := make([]parser.FindingType, 0)
+	for _, finding := range result.Findings {
+		findings = append(findings, finding.Type)
+		if finding.Feature == "" || finding.Risk != parser.AnnotationRiskCritical {
+			t.Errorf("finding %v does not contain the feature and risk of the annotation", finding)
+		}
+	}
+	expectedFindings := []parser.FindingType{parser.FindingDangerousSnippet, parser.FindingInvalidSnippet}
+	if !reflect.DeepEqual(findings, expectedFindings) {
+		t.Errorf("expected findings %v, got %v", expectedFindings, result.Findings)
+	}
+}
+
+func TestAnalyzeWithoutSnippets(t *testing.T) {
+	if analysis := Analyze(nil); len(analysis.Snippets) != 0 {
+		t.Errorf("expected no snippets, got %v", analysis.Snippets)
+	}
+	ing := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "default"}}
+	if result := Analyze(ing).Result(); len(result.Findings) != 0 {
+		t.Errorf("expected no findings, got %v", result.Findings)
+	}
+}
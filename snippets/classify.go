@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snippets
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Category defines what a directive does
+type Category string
+
+const (
+	// CategoryHeaders adds, changes or removes headers of the requests or responses
+	CategoryHeaders Category = "HeaderManipulation"
+	// CategoryAccessControl allows or denies the requests, like allow, deny and SecRule
+	CategoryAccessControl Category = "AccessControl"
+	// CategoryLua runs Lua code
+	CategoryLua Category = "Lua"
+	// CategoryProxyPass sends the requests to a backend, like proxy_pass and grpc_pass
+	CategoryProxyPass Category = "ProxyPass"
+	// CategoryFileAccess reads or writes files of the controller, like root and access_log
+	CategoryFileAccess Category = "FileAccess"
+	// CategoryInclude includes other files or modules in the configuration
+	CategoryInclude Category = "Include"
+	// CategoryRewrite rewrites, redirects or directly answers the requests
+	CategoryRewrite Category = "Rewrite"
+	// CategoryOther is any other directive, like the proxy timeouts or location blocks
+	CategoryOther Category = "Other"
+)
+
+var (
+	// nginxCategories are the categories of the nginx directives not matched by a prefix
+	nginxCategories = map[string]Category{
+		"add_header":               CategoryHeaders,
+		"add_trailer":              CategoryHeaders,
+		"more_set_headers":         CategoryHeaders,
+		"more_clear_headers":       CategoryHeaders,
+		"more_set_input_headers":   CategoryHeaders,
+		"more_clear_input_headers": CategoryHeaders,
+		"proxy_set_header":         CategoryHeaders,
+		"proxy_hide_header":        CategoryHeaders,
+		"proxy_pass_header":        CategoryHeaders,
+		"proxy_ignore_headers":     CategoryHeaders,
+		"grpc_set_header":          CategoryHeaders,
+		"expires":                  CategoryHeaders,
+
+		"allow":                CategoryAccessControl,
+		"deny":                 CategoryAccessControl,
+		"satisfy":              CategoryAccessControl,
+		"auth_basic":           CategoryAccessControl,
+		"auth_request":         CategoryAccessControl,
+		"limit_except":         CategoryAccessControl,
+		"limit_req":            CategoryAccessControl,
+		"limit_conn":           CategoryAccessControl,
+		"limit_rate":           CategoryAccessControl,
+		"valid_referers":       CategoryAccessControl,
+		"secure_link":          CategoryAccessControl,
+		"secure_link_md5":      CategoryAccessControl,
+		"ssl_verify_client":    CategoryAccessControl,
+		"modsecurity":          CategoryAccessControl,
+		"modsecurity_rules":    CategoryAccessControl,
+		"client_max_body_size": CategoryAccessControl,
+
+		"proxy_pass":     CategoryProxyPass,
+		"grpc_pass":      CategoryProxyPass,
+		"fastcgi_pass":   CategoryProxyPass,
+		"uwsgi_pass":     CategoryProxyPass,
+		"scgi_pass":      CategoryProxyPass,
+		"memcached_pass": CategoryProxyPass,
+		"mirror":         CategoryProxyPass,
+
+		"root":                      CategoryFileAccess,
+		"alias":                     CategoryFileAccess,
+		"try_files":                 CategoryFileAccess,
+		"autoindex":                 CategoryFileAccess,
+		"access_log":                CategoryFileAccess,
+		"error_log":                 CategoryFileAccess,
+		"auth_basic_user_file":      CategoryFileAccess,
+		"modsecurity_rules_file":    CategoryFileAccess,
+		"ssl_certificate":           CategoryFileAccess,
+		"ssl_certificate_key":       CategoryFileAccess,
+		"ssl_client_certificate":    CategoryFileAccess,
+		"ssl_trusted_certificate":   CategoryFileAccess,
+		"proxy_ssl_certificate":     CategoryFileAccess,
+		"proxy_ssl_certificate_key": CategoryFileAccess,
+		"client_body_temp_path":     CategoryFileAccess,
+		"proxy_temp_path":           CategoryFileAccess,
+
+		"include":     CategoryInclude,
+		"load_module": CategoryInclude,
+
+		"rewrite":           CategoryRewrite,
+		"return":            CategoryRewrite,
+		"error_page":        CategoryRewrite,
+		"sub_filter":        CategoryRewrite,
+		"proxy_redirect":    CategoryRewrite,
+		"absolute_redirect": CategoryRewrite,
+	}
+
+	// nginxDangers are the reasons the nginx directives are dangerous
+	nginxDangers = map[string]string{
+		"include":     "includes files of the controller filesystem in the configuration",
+		"load_module": "loads a dynamic module in the controller",
+		"root":        "serves files of the controller filesystem, like the service account token and the TLS keys",
+		"alias":       "serves files of the controller filesystem, like the service account token and the TLS keys",
+	}
+
+	// modSecurityCategories are the categories of the ModSecurity directives, by lower case name
+	modSecurityCategories = map[string]Category{
+		"secruleengine":            CategoryAccessControl,
+		"secrule":                  CategoryAccessControl,
+		"secaction":                CategoryAccessControl,
+		"secdefaultaction":         CategoryAccessControl,
+		"secmarker":                CategoryAccessControl,
+		"secruleremovebyid":        CategoryAccessControl,
+		"secruleremovebytag":       CategoryAccessControl,
+		"secruleremovebymsg":       CategoryAccessControl,
+		"secruleupdateactionbyid":  CategoryAccessControl,
+		"secruleupdatetargetbyid":  CategoryAccessControl,
+		"secruleupdatetargetbytag": CategoryAccessControl,
+		"secrequestbodyaccess":     CategoryAccessControl,
+		"secrequestbodylimit":      CategoryAccessControl,
+		"secresponsebodyaccess":    CategoryAccessControl,
+
+		"secauditengine":        CategoryFileAccess,
+		"secauditlog":           CategoryFileAccess,
+		"secauditlog2":          CategoryFileAccess,
+		"secauditlogstoragedir": CategoryFileAccess,
+		"secdebuglog":           CategoryFileAccess,
+		"secdatadir":            CategoryFileAccess,
+		"sectmpdir":             CategoryFileAccess,
+		"secuploaddir":          CategoryFileAccess,
+		"secgeolookupdb":        CategoryFileAccess,
+
+		"include":        CategoryInclude,
+		"secremoterules": CategoryInclude,
+	}
+
+	// modSecurityDangers are the reasons the ModSecurity directives are dangerous, by lower case name
+	modSecurityDangers = map[string]string{
+		"include":        "includes files of the controller filesystem in the configuration",
+		"secremoterules": "downloads rules from a remote server",
+		"secauditlog":    "writes the audit log to a file of the controller",
+		"secdebuglog":    "writes the debug log to a file of the controller",
+	}
+
+	// secretVariableRegex matches the nginx variables with secret in the name, like $secret_key
+	secretVariableRegex = regexp.MustCompile(`(?i)\$\{?(\w*secret\w*)`)
+	// secretPaths are the directories of the controller containing credentials
+	secretPaths = []string{"/var/run/secrets", "/run/secrets", "/etc/ingress-controller/ssl", "/etc/ingress-controller/auth"}
+	// execActionRegex matches the exec action of the ModSecurity rules, that runs a program
+	execActionRegex = regexp.MustCompile(`(?i)(^|[,"'\s])exec:`)
+)
+
+// classify sets the category and the dangers of the nginx directives and of their blocks
+func classify(directives []Directive) {
+	for i := range directives {
+		d := &directives[i]
+		d.Category, d.Dangers = nginxCategory(d.Name), nil
+		if d.Category == CategoryLua {
+			d.Dangers = append(d.Dangers, "runs Lua code in the controller, with access to its memory and filesystem")
+		}
+		if reason, ok := nginxDangers[d.Name]; ok {
+			d.Dangers = append(d.Dangers, reason)
+		}
+		d.Dangers = append(d.Dangers, argumentDangers(d.Args)...)
+		classify(d.Block)
+	}
+}
+
+// classifyModSecurity sets the category and the dangers of the ModSecurity directives
+func classifyModSecurity(directives []Directive) {
+	for i := range directives {
+		d := &directives[i]
+		name := strings.ToLower(d.Name)
+		d.Category, d.Dangers = CategoryOther, nil
+		if category, ok := modSecurityCategories[name]; ok {
+			d.Category = category
+		}
+		if reason, ok := modSecurityDangers[name]; ok {
+			d.Dangers = append(d.Dangers, reason)
+		}
+		if slices.ContainsFunc(d.Args, execActionRegex.MatchString) {
+			d.Dangers = append(d.Dangers, "runs a program of the controller with the exec action")
+		}
+		d.Dangers = append(d.Dangers, argumentDangers(d.Args)...)
+	}
+}
+
+// nginxCategory returns the category of an nginx directive
+func nginxCategory(name string) Category {
+	switch {
+	case strings.HasPrefix(name, "lua_") || strings.Contains(name, "_by_lua"):
+		return CategoryLua
+	case strings.HasPrefix(name, "auth_") && name != "auth_basic_user_file":
+		return CategoryAccessControl
+	}
+	if category, ok := nginxCategories[name]; ok {
+		return category
+	}
+	return CategoryOther
+}
+
+// argumentDangers returns the reasons the arguments of a directive are dangerous, like
+// reading variables or files containing credentials
+func argumentDangers(args []string) []string {
+	dangers := make([]string, 0)
+	variables := make([]string, 0)
+	for _, arg := range args {
+		for _, match := range secretVariableRegex.FindAllStringSubmatch(arg, -1) {
+			if !slices.Contains(variables, match[1]) {
+				variables = append(variables, match[1])
+			}
+		}
+		// Only the first path is reported, as /run/secrets is also part of /var/run/secrets
+		if i := slices.IndexFunc(secretPaths, func(path string) bool { return strings.Contains(arg, path) }); i >= 0 {
+			dangers = append(dangers, fmt.Sprintf("references the credentials of the controller in %s", secretPaths[i]))
+		}
+	}
+	for _, variable := range variables {
+		dangers = append(dangers, fmt.Sprintf("reads the variable $%s", variable))
+	}
+	if len(dangers) == 0 {
+		return nil
+	}
+	return dangers
+}
+
+// Categories returns the categories of the directives and of their blocks, sorted
+func Categories(directives []Directive) []Category {
+	categories := make([]Category, 0)
+	Walk(directives, func(d Directive) {
+		if !slices.Contains(categories, d.Category) {
+			categories = append(categories, d.Category)
+		}
+	})
+	slices.Sort(categories)
+	return categories
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snippets parses the nginx and ModSecurity configuration of the snippet
+// annotations, and classifies what each directive does
+package snippets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Directive is a directive of a snippet, like add_header X-Frame-Options DENY;
+type Directive struct {
+	Name string `json:"name"`
+	// Args are the arguments of the directive, without quotes. The Lua code of the
+	// *_by_lua_block directives is the only argument of the directive
+	Args []string `json:"args,omitempty"`
+	// Line is the line of the snippet where the directive starts
+	Line int `json:"line"`
+	// Block contains the directives of the block opened by the directive, like location
+	Block    []Directive `json:"block,omitempty"`
+	Category Category    `json:"category"`
+	// Dangers are the reasons the directive is dangerous, empty when it is not
+	Dangers []string `json:"dangers,omitempty"`
+}
+
+// IsDangerous returns if the directive is flagged as dangerous
+func (d Directive) IsDangerous() bool {
+	return len(d.Dangers) > 0
+}
+
+// String returns the directive as nginx configuration, without its block
+func (d Directive) String() string {
+	return strings.Join(append([]string{d.Name}, d.Args...), " ")
+}
+
+// Walk calls fn for each directive, and for the directives of their blocks
+func Walk(directives []Directive, fn func(Directive)) {
+	for _, d := range directives {
+		fn(d)
+		Walk(d.Block, fn)
+	}
+}
+
+// Parse parses the nginx configuration of a snippet, like configuration-snippet, into
+// classified directives. The *_by_lua_block directives are not parsed, and their Lua
+// code is kept as the argument of the directive
+func Parse(text string) ([]Directive, error) {
+	p := &nginxParser{lexer: &lexer{text: text, line: 1}}
+	directives, err := p.parseBlock(false)
+	if err != nil {
+		return nil, err
+	}
+	classify(directives)
+	return directives, nil
+}
+
+// ParseModSecurity parses the ModSecurity configuration of modsecurity-snippet into
+// classified directives. Each line contains a directive, and lines ending with a
+// backslash continue on the next line
+func ParseModSecurity(text string) ([]Directive, error) {
+	directives := make([]Directive, 0)
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		start := i
+		line := strings.TrimSpace(lines[i])
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, "\\") + " " + strings.TrimSpace(lines[i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words, err := fields(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", start+1, err)
+		}
+		directives = append(directives, Directive{Name: words[0], Args: words[1:], Line: start + 1})
+	}
+	classifyModSecurity(directives)
+	return directives, nil
+}
+
+// token is a word of an nginx snippet. The special characters ; { and } are tokens
+// of their own, unless they are quoted
+type token struct {
+	value   string
+	line    int
+	special bool
+}
+
+// lexer splits an nginx snippet in tokens, following the rules of the nginx
+// configuration parser
+type lexer struct {
+	text string
+	pos  int
+	line int
+}
+
+// next returns the next token, and false at the end of the snippet
+func (l *lexer) next() (token, bool, error) {
+	l.skipSpaceAndComments()
+	if l.pos >= len(l.text) {
+		return token{}, false, nil
+	}
+
+	line := l.line
+	switch ch := l.text[l.pos]; ch {
+	case ';', '{', '}':
+		l.pos++
+		return token{value: string(ch), line: line, special: true}, true, nil
+	case '"', '\'':
+		value, err := l.quoted(ch)
+		return token{value: value, line: line}, true, err
+	}
+
+	var b strings.Builder
+	for l.pos < len(l.text) {
+		ch := l.text[l.pos]
+		switch {
+		case isSpace(ch) || ch == ';' || ch == '{' || ch == '}':
+			return token{value: b.String(), line: line}, true, nil
+		case ch == '\\' && l.pos+1 < len(l.text):
+			b.WriteByte(l.text[l.pos+1])
+			l.pos += 2
+		case ch == '$' && l.pos+1 < len(l.text) && l.text[l.pos+1] == '{':
+			// ${name} variables are part of the word
+			end := strings.IndexByte(l.text[l.pos:], '}')
+			if end < 0 {
+				return token{}, false, fmt.Errorf("line %d: variable is not terminated by }", line)
+			}
+			b.WriteString(l.text[l.pos : l.pos+end+1])
+			l.pos += end + 1
+		default:
+			b.WriteByte(ch)
+			l.pos++
+		}
+	}
+	return token{value: b.String(), line: line}, true, nil
+}
+
+// quoted returns the content of a quoted string starting at the current position
+func (l *lexer) quoted(quote byte) (string, error) {
+	line := l.line
+	var b strings.Builder
+	for l.pos++; l.pos < len(l.text); l.pos++ {
+		ch := l.text[l.pos]
+		switch {
+		case ch == quote:
+			l.pos++
+			return b.String(), nil
+		case ch == '\\' && l.pos+1 < len(l.text) && (l.text[l.pos+1] == quote || l.text[l.pos+1] == '\\'):
+			l.pos++
+			b.WriteByte(l.text[l.pos])
+		default:
+			if ch == '\n' {
+				l.line++
+			}
+			b.WriteByte(ch)
+		}
+	}
+	return "", fmt.Errorf("line %d: string is not terminated by %c", line, quote)
+}
+
+// rawBlock returns the content of a block that is not nginx configuration, like Lua
+// code, after its opening brace. Braces inside strings and comments are ignored
+func (l *lexer) rawBlock() (string, error) {
+	line := l.line
+	start := l.pos
+	depth := 1
+	for ; l.pos < len(l.text); l.pos++ {
+		switch ch := l.text[l.pos]; ch {
+		case '\n':
+			l.line++
+		case '"', '\'':
+			if _, err := l.quoted(ch); err != nil {
+				return "", err
+			}
+			l.pos--
+		case '-':
+			if strings.HasPrefix(l.text[l.pos:], "--") {
+				for l.pos < len(l.text) && l.text[l.pos] != '\n' {
+					l.pos++
+				}
+				l.pos--
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				code := l.text[start:l.pos]
+				l.pos++
+				return strings.TrimSpace(code), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("line %d: block is not terminated by }", line)
+}
+
+// skipSpaceAndComments moves the position to the start of the next token
+func (l *lexer) skipSpaceAndComments() {
+	for l.pos < len(l.text) {
+		switch ch := l.text[l.pos]; {
+		case ch == '\n':
+			l.line++
+			l.pos++
+		case isSpace(ch):
+			l.pos++
+		case ch == '#':
+			for l.pos < len(l.text) && l.text[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n'
+}
+
+// nginxParser builds the directives from the tokens of a snippet
+type nginxParser struct {
+	lexer *lexer
+}
+
+// parseBlock parses directives until the end of the snippet or, when nested is true,
+// until the end of the block
+func (p *nginxParser) parseBlock(nested bool) ([]Directive, error) {
+	directives := make([]Directive, 0)
+	for {
+		tok, ok, err := p.lexer.next()
+		switch {
+		case err != nil:
+			return nil, err
+		case !ok && nested:
+			return nil, fmt.Errorf("line %d: block is not terminated by }", p.lexer.line)
+		case !ok:
+			return directives, nil
+		case tok.special && tok.value == "}" && nested:
+			return directives, nil
+		case tok.special:
+			return nil, fmt.Errorf("line %d: unexpected %s", tok.line, tok.value)
+		}
+
+		directive, err := p.parseDirective(tok)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive)
+	}
+}
+
+// parseDirective parses the arguments and the block of the directive named by tok
+func (p *nginxParser) parseDirective(tok token) (Directive, error) {
+	d := Directive{Name: tok.value, Line: tok.line}
+	for {
+		arg, ok, err := p.lexer.next()
+		switch {
+		case err != nil:
+			return d, err
+		case !ok:
+			return d, fmt.Errorf("line %d: directive %s is not terminated by ;", d.Line, d.Name)
+		case arg.special && arg.value == ";":
+			return d, nil
+		case arg.special && arg.value == "{" && strings.HasSuffix(d.Name, "_by_lua_block"):
+			code, err := p.lexer.rawBlock()
+			if err != nil {
+				return d, err
+			}
+			d.Args = append(d.Args, code)
+			return d, nil
+		case arg.special && arg.value == "{":
+			d.Block, err = p.parseBlock(true)
+			return d, err
+		case arg.special:
+			return d, fmt.Errorf("line %d: unexpected %s in directive %s", arg.line, arg.value, d.Name)
+		}
+		d.Args = append(d.Args, arg.value)
+	}
+}
+
+// fields splits a ModSecurity directive in words, removing the quotes of quoted words
+func fields(line string) ([]string, error) {
+	words := make([]string, 0)
+	for i := 0; i < len(line); {
+		if isSpace(line[i]) {
+			i++
+			continue
+		}
+		if line[i] != '"' && line[i] != '\'' {
+			end := strings.IndexAny(line[i:], " \t")
+			if end < 0 {
+				end = len(line) - i
+			}
+			words = append(words, line[i:i+end])
+			i += end
+			continue
+		}
+
+		quote := line[i]
+		var b strings.Builder
+		for i++; i < len(line) && line[i] != quote; i++ {
+			if line[i] == '\\' && i+1 < len(line) && line[i+1] == quote {
+				i++
+			}
+			b.WriteByte(line[i])
+		}
+		if i >= len(line) {
+			return nil, fmt.Errorf("string is not terminated by %c", quote)
+		}
+		words = append(words, b.String())
+		i++
+	}
+	return words, nil
+}
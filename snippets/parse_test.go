@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snippets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		snippet  string
+		expected []Directive
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			snippet:  "  # only a comment\n",
+			expected: []Directive{},
+		},
+		{
+			name:    "headers and quotes",
+			snippet: "more_set_headers \"X-Frame-Options: DENY\";\nadd_header X-Id '$request_id' always; # comment\n",
+			expected: []Directive{
+				{Name: "more_set_headers", Args: []string{"X-Frame-Options: DENY"}, Line: 1, Category: CategoryHeaders},
+				{Name: "add_header", Args: []string{"X-Id", "$request_id", "always"}, Line: 2, Category: CategoryHeaders},
+			},
+		},
+		{
+			name:    "blocks",
+			snippet: "location /admin {\n  allow 10.0.0.0/8;\n  deny all;\n  proxy_pass http://admin.default.svc;\n}\n",
+			expected: []Directive{{
+				Name:     "location",
+				Args:     []string{"/admin"},
+				Line:     1,
+				Category: CategoryOther,
+				Block: []Directive{
+					{Name: "allow", Args: []string{"10.0.0.0/8"}, Line: 2, Category: CategoryAccessControl},
+					{Name: "deny", Args: []string{"all"}, Line: 3, Category: CategoryAccessControl},
+					{Name: "proxy_pass", Args: []string{"http://admin.default.svc"}, Line: 4, Category: CategoryProxyPass},
+				},
+			}},
+		},
+		{
+			name:    "braces of variables",
+			snippet: `if ($http_x_mode = a${arg_b}) { return 403; }`,
+			expected: []Directive{{
+				Name:     "if",
+				Args:     []string{"($http_x_mode", "=", "a${arg_b})"},
+				Line:     1,
+				Category: CategoryOther,
+				Block:    []Directive{{Name: "return", Args: []string{"403"}, Line: 1, Category: CategoryRewrite}},
+			}},
+		},
+		{
+			name:    "lua block",
+			snippet: "content_by_lua_block {\n  if ngx.var.a == \"}\" then -- }\n    ngx.say(\"{\")\n  end\n}\nreturn 200;",
+			expected: []Directive{
+				{
+					Name:     "content_by_lua_block",
+					Args:     []string{"if ngx.var.a == \"}\" then -- }\n    ngx.say(\"{\")\n  end"},
+					Line:     1,
+					Category: CategoryLua,
+					Dangers:  []string{"runs Lua code in the controller, with access to its memory and filesystem"},
+				},
+				{Name: "return", Args: []string{"200"}, Line: 6, Category: CategoryRewrite},
+			},
+		},
+		{
+			name:    "dangerous directives",
+			snippet: "include /etc/nginx/extra.conf;\nlua_shared_dict cache 10m;\nalias /var/run/secrets/kubernetes.io/;\nproxy_set_header X-Key $secret_key;",
+			expected: []Directive{
+				{
+					Name:     "include",
+					Args:     []string{"/etc/nginx/extra.conf"},
+					Line:     1,
+					Category: CategoryInclude,
+					Dangers:  []string{"includes files of the controller filesystem in the configuration"},
+				},
+				{
+					Name:     "lua_shared_dict",
+					Args:     []string{"cache", "10m"},
+					Line:     2,
+					Category: CategoryLua,
+					Dangers:  []string{"runs Lua code in the controller, with access to its memory and filesystem"},
+				},
+				{
+					Name:     "alias",
+					Args:     []string{"/var/run/secrets/kubernetes.io/"},
+					Line:     3,
+					Category: CategoryFileAccess,
+					Dangers: []string{
+						"serves files of the controller filesystem, like the service account token and the TLS keys",
+						"references the credentials of the controller in /var/run/secrets",
+					},
+				},
+				{
+					Name:     "proxy_set_header",
+					Args:     []string{"X-Key", "$secret_key"},
+					Line:     4,
+					Category: CategoryHeaders,
+					Dangers:  []string{"reads the variable $secret_key"},
+				},
+			},
+		},
+		{
+			name:    "missing semicolon",
+			snippet: "add_header X-A a",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated block",
+			snippet: "location / {\n return 200;",
+			wantErr: true,
+		},
+		{
+			name:    "unexpected closing brace",
+			snippet: "return 200; }",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			snippet: "add_header X-A \"a;",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directives, err := Parse(tt.snippet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(directives, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, directives)
+			}
+		})
+	}
+}
+
+func TestParseModSecurity(t *testing.T) {
+	snippet := `SecRuleEngine On
+# comment
+SecRule REQUEST_HEADERS:User-Agent "@contains scanner" \
+    "id:1000,phase:1,deny,status:403"
+SecRule ARGS "@rx attack" "id:1001,phase:2,exec:/tmp/run.sh"
+Include /etc/nginx/owasp-modsecurity-crs/nginx-modsecurity.conf
+`
+	expected := []Directive{
+		{Name: "SecRuleEngine", Args: []string{"On"}, Line: 1, Category: CategoryAccessControl},
+		{Name: "SecRule", Args: []string{"REQUEST_HEADERS:User-Agent", "@contains scanner", "id:1000,phase:1,deny,status:403"}, Line: 3, Category: CategoryAccessControl},
+		{
+			Name:     "SecRule",
+			Args:     []string{"ARGS", "@rx attack", "id:1001,phase:2,exec:/tmp/run.sh"},
+			Line:     5,
+			Category: CategoryAccessControl,
+			Dangers:  []string{"runs a program of the controller with the exec action"},
+		},
+		{
+			Name:     "Include",
+			Args:     []string{"/etc/nginx/owasp-modsecurity-crs/nginx-modsecurity.conf"},
+			Line:     6,
+			Category: CategoryInclude,
+			Dangers:  []string{"includes files of the controller filesystem in the configuration"},
+		},
+	}
+
+	directives, err := ParseModSecurity(snippet)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(directives, expected) {
+		t.Errorf("expected %+v, got %+v", expected, directives)
+	}
+
+	if _, err := ParseModSecurity(`SecRule ARGS "@rx attack`); err == nil {
+		t.Errorf("expected error parsing an unterminated string")
+	}
+}
+
+func TestCategories(t *testing.T) {
+	directives, err := Parse("location /a { add_header X-A a; deny all; }\nrewrite ^/b /c;\nadd_header X-B b;")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := []Category{CategoryAccessControl, CategoryHeaders, CategoryOther, CategoryRewrite}
+	if categories := Categories(directives); !reflect.DeepEqual(categories, expected) {
+		t.Errorf("expected %v, got %v", expected, categories)
+	}
+}
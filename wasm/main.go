@@ -7,6 +7,7 @@ import (
 	"syscall/js"
 
 	annotations "github.com/rikatz/ingress-nginx-annotations"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
 	"github.com/sahilm/fuzzy"
 )
 
@@ -46,10 +47,17 @@ func main() {
 			gw := val.GatewayAPI
 			icon := "fa-check-circle"
 			theme := "w3-light-blue"
-			if gw == "" {
+			switch val.GatewayAPICompatibility {
+			case parser.GatewayAPICompatible:
+			case parser.GatewayAPIPartial:
+				icon = "fa-adjust"
+				theme = "w3-pale-yellow"
+			default:
 				icon = "fa-exclamation-circle"
-				gw = "Not supported yet."
 				theme = "w3-pale-red"
+				if gw == "" {
+					gw = "Not supported yet."
+				}
 			}
 			buf.WriteString(fmt.Sprintf("<header class=\"w3-container %s\"><h1>%s <i class=\"fa %s\"></i></h1></header><div class=\"w3-container\">", theme, v.Str, icon))
 			buf.WriteString(fmt.Sprintf("<p>%s</p>", gw))
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements a validating admission webhook for the annotations
+// of Ingress objects, that does not require running the ingress controller
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	annotations "github.com/rikatz/ingress-nginx-annotations"
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	admissionv1 "k8s.io/api/admission/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxRequestSize is the maximum size of an AdmissionReview accepted by the handler
+const maxRequestSize = 3 * 1024 * 1024
+
+// Handler is an http.Handler that validates the annotations of the Ingress objects
+// received as admission.k8s.io/v1 AdmissionReview requests
+type Handler struct {
+	registry *parser.Registry
+}
+
+// NewHandler returns a Handler validating Ingresses with all the known annotations.
+// The configuration defines the prefix, the maximum risk, the allowed groups and
+// if unknown annotations are reported
+func NewHandler(config parser.Config) *Handler {
+	return &Handler{
+		registry: annotations.NewRegistry().WithConfig(config),
+	}
+}
+
+// ServeHTTP decodes the AdmissionReview, validates the Ingress and writes back the review response
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding AdmissionReview: %s", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview does not contain a request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.Review(review.Request)
+	review.Request = nil
+	review.APIVersion = admissionv1.SchemeGroupVersion.String()
+	review.Kind = "AdmissionReview"
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		slog.Error("error writing AdmissionReview response", "error", err)
+	}
+}
+
+// Review validates the Ingress of an admission request. Requests for other
+// resources and deletions are always allowed
+func (h *Handler) Review(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{
+		UID:     request.UID,
+		Allowed: true,
+	}
+	isIngress := request.Resource.Group == networking.GroupName && request.Resource.Resource == "ingresses"
+	if !isIngress || request.Operation == admissionv1.Delete {
+		return response
+	}
+
+	ing := &networking.Ingress{}
+	if err := json.Unmarshal(request.Object.Raw, ing); err != nil {
+		return deny(response, http.StatusBadRequest, metav1.StatusReasonBadRequest, fmt.Sprintf("error decoding Ingress: %s", err))
+	}
+
+	result, err := h.registry.Validate(ing)
+	if err != nil {
+		return deny(response, http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+	}
+
+	for _, finding := range result.Warnings() {
+		response.Warnings = append(response.Warnings, finding.Err().Error())
+	}
+	if !result.HasErrors() {
+		return response
+	}
+
+	messages := make([]string, 0)
+	for _, finding := range result.Errors() {
+		messages = append(messages, finding.Err().Error())
+	}
+	return deny(response, http.StatusForbidden, metav1.StatusReasonForbidden,
+		fmt.Sprintf("ingress %s contains invalid annotations: %s", objectName(request, ing), strings.Join(messages, "; ")))
+}
+
+func deny(response *admissionv1.AdmissionResponse, code int32, reason metav1.StatusReason, message string) *admissionv1.AdmissionResponse {
+	response.Allowed = false
+	response.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    code,
+		Reason:  reason,
+		Message: message,
+	}
+	return response
+}
+
+// objectName returns the name of the Ingress. Objects being created may use
+// generateName and have the name only on the request
+func objectName(request *admissionv1.AdmissionRequest, ing *networking.Ingress) string {
+	name := ing.Name
+	if name == "" {
+		name = request.Name
+	}
+	namespace := ing.Namespace
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rikatz/ingress-nginx-annotations/parser"
+	admissionv1 "k8s.io/api/admission/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newReview(t *testing.T, operation admissionv1.Operation, resource string, annotations map[string]string) []byte {
+	t.Helper()
+	ing, err := json.Marshal(&networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	review, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("12345"),
+			Operation: operation,
+			Resource:  metav1.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: resource},
+			Object:    runtime.RawExtension{Raw: ing},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return review
+}
+
+func TestHandler(t *testing.T) {
+	config := parser.DefaultConfig()
+	config.EnableValidation = true
+	config.MaxRisk = parser.AnnotationRiskHigh
+	server := httptest.NewServer(NewHandler(config))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		resource    string
+		annotations map[string]string
+		allowed     bool
+		message     string
+		warnings    int
+	}{
+		{
+			name:      "valid ingress is allowed",
+			operation: admissionv1.Create,
+			resource:  "ingresses",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "30",
+			},
+			allowed: true,
+		},
+		{
+			name:      "invalid value is denied",
+			operation: admissionv1.Create,
+			resource:  "ingresses",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "abc",
+			},
+			message: "nginx.ingress.kubernetes.io/proxy-read-timeout contains invalid value",
+		},
+		{
+			name:      "risky annotation is denied",
+			operation: admissionv1.Update,
+			resource:  "ingresses",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/server-snippet": "return 200;",
+			},
+			message: "nginx.ingress.kubernetes.io/server-snippet is too risky",
+		},
+		{
+			name:      "rule violation is returned as warning",
+			operation: admissionv1.Create,
+			resource:  "ingresses",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/canary-weight": "10",
+			},
+			allowed:  true,
+			warnings: 1,
+		},
+		{
+			name:      "deletion is allowed",
+			operation: admissionv1.Delete,
+			resource:  "ingresses",
+			allowed:   true,
+		},
+		{
+			name:      "other resources are allowed",
+			operation: admissionv1.Create,
+			resource:  "ingressclasses",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/server-snippet": "return 200;",
+			},
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := newReview(t, tt.operation, tt.resource, tt.annotations)
+			resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", resp.StatusCode)
+			}
+
+			review := &admissionv1.AdmissionReview{}
+			if err := json.NewDecoder(resp.Body).Decode(review); err != nil {
+				t.Fatalf("unexpected error decoding response: %s", err)
+			}
+			if review.Kind != "AdmissionReview" || review.Response == nil || review.Response.UID != "12345" {
+				t.Fatalf("unexpected review %+v", review)
+			}
+			if review.Response.Allowed != tt.allowed {
+				t.Errorf("expected allowed to be %t, got %+v", tt.allowed, review.Response.Result)
+			}
+			if tt.message != "" && (review.Response.Result == nil || !strings.Contains(review.Response.Result.Message, tt.message)) {
+				t.Errorf("expected message to contain %q, got %+v", tt.message, review.Response.Result)
+			}
+			if len(review.Response.Warnings) != tt.warnings {
+				t.Errorf("expected %d warnings, got %v", tt.warnings, review.Response.Warnings)
+			}
+		})
+	}
+}
+
+func TestHandlerInvalidRequest(t *testing.T) {
+	handler := NewHandler(parser.DefaultConfig())
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			name:        "wrong method",
+			method:      http.MethodGet,
+			contentType: "application/json",
+			status:      http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "wrong content type",
+			method:      http.MethodPost,
+			contentType: "text/plain",
+			body:        "{}",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "invalid body",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        "{",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "review without request",
+			method:      http.MethodPost,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/validate", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+}